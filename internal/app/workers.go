@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// runTrashPurger периодически удаляет задачи, пролежавшие в корзине дольше trashRetention.
+// Репозиторий удаляет строки с FOR UPDATE SKIP LOCKED, поэтому очистку можно
+// запускать на нескольких репликах одновременно. После очистки корзины из хранилища
+// удаляются файлы вложений, метаданные которых удалены вместе с задачами.
+func (a *App) runTrashPurger(ctx context.Context) {
+	ticker := time.NewTicker(a.trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.taskUseCase.PurgeDeleted(ctx, a.trashRetention); err != nil && ctx.Err() == nil {
+			logger.Log.WithError(err).Error("Trash purge failed")
+		}
+		a.collectAttachments(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recurrenceBatch — сколько серий реплика берет в работу за один проход планировщика.
+const recurrenceBatch = 50
+
+// runRecurrenceScheduler периодически создает наступившие экземпляры повторяющихся задач.
+// Серии берутся в аренду с FOR UPDATE SKIP LOCKED, а ID экземпляра определяется серией
+// и моментом экземпляра, поэтому реплики не создают дубликатов.
+func (a *App) runRecurrenceScheduler(ctx context.Context) {
+	ticker := time.NewTicker(a.recurrenceInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			created, err := a.recurrenceUseCase.RunDue(ctx, time.Now(), recurrenceBatch)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Log.WithError(err).Error("Recurring tasks scheduling failed")
+				}
+				break
+			}
+			if created < recurrenceBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runRankRebalancer периодически перераспределяет ранги колонок доски, в которых частые
+// перемещения задач удлинили ранги. Колонка перебалансируется под блокировкой её задач,
+// а необходимость перебалансировки перепроверяется под блокировкой, поэтому реплики
+// не мешают друг другу.
+func (a *App) runRankRebalancer(ctx context.Context) {
+	ticker := time.NewTicker(a.rebalanceInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.taskUseCase.RebalanceRanks(ctx); err != nil && ctx.Err() == nil {
+			logger.Log.WithError(err).Error("Board rank rebalance failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// attachmentGCBatch — сколько файлов вложений удаляется из хранилища за один проход.
+const attachmentGCBatch = 100
+
+// collectAttachments удаляет файлы вложений из очереди, пока она не опустеет
+// или хранилище не вернет ошибку. Повторное удаление файла разными репликами безвредно.
+func (a *App) collectAttachments(ctx context.Context) {
+	for ctx.Err() == nil {
+		removed, err := a.attachmentUseCase.CollectGarbage(ctx, attachmentGCBatch)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Log.WithError(err).Error("Attachment garbage collection failed")
+			}
+			return
+		}
+		if removed < attachmentGCBatch {
+			return
+		}
+	}
+}
+
+// customFieldIndexBatch — сколько индексов пользовательских полей реплика строит за один проход.
+const customFieldIndexBatch = 10
+
+// runCustomFieldIndexer периодически строит индексы значений созданных пользовательских
+// полей. Построение берется в аренду, поэтому реплики не строят один индекс одновременно.
+func (a *App) runCustomFieldIndexer(ctx context.Context) {
+	ticker := time.NewTicker(a.customFieldIndexInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			built, err := a.customFieldUseCase.BuildIndexes(ctx, customFieldIndexBatch)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Log.WithError(err).Error("Custom field indexing failed")
+				}
+				break
+			}
+			if built < customFieldIndexBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Ключи метаданных с автором изменений и ID запроса, которые записываются в историю задач.
+const (
+	ActorMetadataKey     = "x-actor"
+	RequestIDMetadataKey = "x-request-id"
+)
+
+// AuditInterceptor передает в контекст вызова автора и ID запроса из метаданных.
+// Если клиент не передал ID запроса, он генерируется.
+func AuditInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	audit := usecase.Audit{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ActorMetadataKey); len(values) > 0 {
+			audit.Actor = values[0]
+		}
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+			audit.RequestID = values[0]
+		}
+	}
+	if audit.RequestID == "" {
+		audit.RequestID = uuid.NewString()
+	}
+	return handler(usecase.WithAudit(ctx, audit), req)
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthorizationMetadataKey — ключ метаданных с токеном доступа в виде "Bearer <token>".
+const AuthorizationMetadataKey = "authorization"
+
+// AuthInterceptor пропускает только вызовы с действительным токеном доступа, области
+// которого разрешают метод, и передает владельца токена в контекст вызова. Владелец токена записывается автором изменений
+// в историю задач, поэтому перехватчик должен идти в цепочке после AuditInterceptor.
+func AuthInterceptor(verifier usecase.TokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(AuthorizationMetadataKey); len(values) > 0 {
+				scheme, value, found := strings.Cut(values[0], " ")
+				if found && strings.EqualFold(scheme, "Bearer") {
+					token = strings.TrimSpace(value)
+				}
+			}
+		}
+		if token == "" {
+			return nil, toStatusError(fmt.Errorf("%w: authentication required", usecase.ErrUnauthenticated))
+		}
+
+		principal, err := verifier.Verify(ctx, token)
+		if err != nil {
+			logger.Log.Warn("Access token rejected", "method", info.FullMethod, "error", err)
+			return nil, toStatusError(fmt.Errorf("%w: invalid access token", usecase.ErrUnauthenticated))
+		}
+
+		ctx = usecase.WithPrincipal(ctx, principal)
+		if err := usecase.RequireScope(ctx, methodScope(info.FullMethod)); err != nil {
+			return nil, toStatusError(err)
+		}
+		audit := usecase.AuditFromContext(ctx)
+		audit.Actor = principal.Subject
+		return handler(usecase.WithAudit(ctx, audit), req)
+	}
+}
+
+// readMethods — методы TaskService, которым достаточно области tasks:read.
+var readMethods = map[string]bool{
+	"GetTask":            true,
+	"ListTasks":          true,
+	"SearchTasks":        true,
+	"ListTransitions":    true,
+	"GetDependencyGraph": true,
+}
+
+// methodScope возвращает область доступа, которая нужна для вызова метода.
+func methodScope(fullMethod string) string {
+	if readMethods[path.Base(fullMethod)] {
+		return entity.ScopeTasksRead
+	}
+	return entity.ScopeTasksWrite
+}
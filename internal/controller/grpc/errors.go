@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain — домен ошибок сервиса в errdetails.ErrorInfo.
+const ErrorDomain = "task-service"
+
+// statusCodes сопоставляет категориям доменных ошибок коды gRPC.
+var statusCodes = map[entity.ErrorKind]codes.Code{
+	entity.KindNotFound:             codes.NotFound,
+	entity.KindInvalidID:            codes.InvalidArgument,
+	entity.KindInvalidArgument:      codes.InvalidArgument,
+	entity.KindValidation:           codes.InvalidArgument,
+	entity.KindFailedPrecondition:   codes.FailedPrecondition,
+	entity.KindConflict:             codes.FailedPrecondition,
+	entity.KindAlreadyExists:        codes.AlreadyExists,
+	entity.KindVersionConflict:      codes.Aborted,
+	entity.KindForbidden:            codes.PermissionDenied,
+	entity.KindUnauthenticated:      codes.Unauthenticated,
+	entity.KindTooLarge:             codes.InvalidArgument,
+	entity.KindUnsupportedMediaType: codes.InvalidArgument,
+	entity.KindRateLimited:          codes.ResourceExhausted,
+}
+
+// toStatusError преобразует ошибку в gRPC-статус.
+func toStatusError(err error) error {
+	return toStatus(err).Err()
+}
+
+// toStatus выбирает код gRPC по категории доменной ошибки и добавляет в детали статуса
+// ErrorInfo со стабильным кодом ошибки в Reason, BadRequest с ошибками по полям и
+// PreconditionFailure с невыполненными условиями перехода. Ошибки без категории
+// считаются внутренними и отдаются без подробностей.
+func toStatus(err error) *status.Status {
+	var code codes.Code
+	domainErr, ok := entity.AsError(err)
+	if ok {
+		code, ok = statusCodes[domainErr.Kind]
+	}
+	if !ok {
+		switch {
+		case errors.Is(err, context.Canceled):
+			return status.New(codes.Canceled, err.Error())
+		case errors.Is(err, context.DeadlineExceeded):
+			return status.New(codes.DeadlineExceeded, err.Error())
+		default:
+			logger.Log.WithError(err).Error("gRPC request failed")
+			return status.New(codes.Internal, "internal server error")
+		}
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: domainErr.Code, Domain: ErrorDomain}}
+	if fields := entity.Fields(err); len(fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+		for i, field := range fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if violations := preconditionViolations(err); len(violations) > 0 {
+		details = append(details, &errdetails.PreconditionFailure{Violations: violations})
+	}
+
+	st := status.New(code, err.Error())
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		logger.Log.WithError(detailsErr).Warn("Failed to attach gRPC error details")
+		return st
+	}
+	return withDetails
+}
+
+// preconditionViolations перечисляет блокирующие задачи и невыполненные условия перехода статуса.
+func preconditionViolations(err error) []*errdetails.PreconditionFailure_Violation {
+	var violations []*errdetails.PreconditionFailure_Violation
+	var blockedErr *usecase.BlockedError
+	if errors.As(err, &blockedErr) {
+		for _, id := range blockedErr.Blockers {
+			violations = append(violations, &errdetails.PreconditionFailure_Violation{
+				Type:        "BLOCKED",
+				Subject:     "tasks/" + id.String(),
+				Description: "blocking task is not completed",
+			})
+		}
+	}
+	var transitionErr *usecase.TransitionError
+	if errors.As(err, &transitionErr) {
+		for _, guard := range transitionErr.FailedGuards {
+			violations = append(violations, &errdetails.PreconditionFailure_Violation{
+				Type:        "GUARD",
+				Subject:     guard,
+				Description: "transition to " + transitionErr.To + " requires " + guard,
+			})
+		}
+	}
+	return violations
+}
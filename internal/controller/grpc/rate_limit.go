@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimitInterceptor применяет к методам TaskService лимиты группы маршрутов задач
+// с тем же HTTP-методом, что и у соответствующего маршрута REST API, поэтому вызовы
+// по REST и gRPC расходуют общий лимит клиента. Перехватчик идет в цепочке после
+// AuthInterceptor, чтобы учитывать запросы по ключу API или пользователю. Состояние
+// лимита передается в заголовках ratelimit-*, отклоненные вызовы получают
+// ResourceExhausted с RetryInfo.
+func RateLimitInterceptor(limiter usecase.RateLimitUseCase) grpc.UnaryServerInterceptor {
+	return rateLimitInterceptor(limiter, entity.RateLimitGroupTasks, func(ctx context.Context) string {
+		return usecase.RateLimitIdentity(ctx, peerIP(ctx))
+	})
+}
+
+// ClientIPRateLimitInterceptor ограничивает частоту вызовов с одного IP-адреса по лимиту
+// группы entity.RateLimitGroupClientIP, общему с REST API. Перехватчик идет в цепочке
+// до AuthInterceptor, чтобы перебор ключей API и токенов тоже расходовал лимит.
+func ClientIPRateLimitInterceptor(limiter usecase.RateLimitUseCase) grpc.UnaryServerInterceptor {
+	return rateLimitInterceptor(limiter, entity.RateLimitGroupClientIP, func(ctx context.Context) string {
+		return "ip:" + peerIP(ctx)
+	})
+}
+
+func rateLimitInterceptor(limiter usecase.RateLimitUseCase, group string, identify func(ctx context.Context) string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		result, err := limiter.Allow(ctx, identify(ctx), group, httpMethod(info.FullMethod))
+		if err != nil {
+			logger.Log.Error("Failed to check rate limit", "method", info.FullMethod, "error", err)
+			return handler(ctx, req)
+		}
+
+		if result.Limit.Enabled() {
+			header := metadata.Pairs(
+				"ratelimit-limit", strconv.Itoa(result.Limit.Requests),
+				"ratelimit-remaining", strconv.Itoa(result.Remaining),
+				"ratelimit-reset", strconv.Itoa(seconds(result.Reset.Seconds())),
+				"ratelimit-policy", fmt.Sprintf("%d;w=%d", result.Limit.Requests, seconds(result.Limit.Window.Seconds())),
+			)
+			if !result.Allowed {
+				header.Set("retry-after", strconv.Itoa(seconds(result.RetryAfter.Seconds())))
+			}
+			if err := grpc.SetHeader(ctx, header); err != nil {
+				logger.Log.Warn("Failed to set rate limit headers", "error", err)
+			}
+		}
+		if !result.Allowed {
+			st := toStatus(usecase.ErrRateLimited)
+			if withRetry, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(result.RetryAfter)}); err == nil {
+				st = withRetry
+			}
+			return nil, st.Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// httpMethod возвращает HTTP-метод маршрута REST API, соответствующего методу TaskService.
+func httpMethod(fullMethod string) string {
+	method := path.Base(fullMethod)
+	switch {
+	case readMethods[method]:
+		return "GET"
+	case strings.HasPrefix(method, "Update"), strings.HasPrefix(method, "BatchUpdate"):
+		return "PUT"
+	case strings.HasPrefix(method, "Delete"), strings.HasPrefix(method, "BatchDelete"),
+		strings.HasPrefix(method, "Remove"), strings.HasPrefix(method, "Detach"):
+		return "DELETE"
+	default:
+		return "POST"
+	}
+}
+
+// peerIP возвращает IP-адрес клиента вызова.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// seconds округляет число секунд вверх до целого.
+func seconds(s float64) int {
+	return int(math.Ceil(s))
+}
@@ -0,0 +1,624 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	pb "github.com/KarpovAlexandrGo/task-service/proto"
+	"github.com/google/uuid"
+)
+
+// TaskServer реализует gRPC-сервис TaskService поверх TaskUseCase.
+type TaskServer struct {
+	pb.UnimplementedTaskServiceServer
+	taskUseCase usecase.TaskUseCase
+}
+
+// NewTaskServer создает новый экземпляр TaskServer.
+func NewTaskServer(taskUseCase usecase.TaskUseCase) *TaskServer {
+	return &TaskServer{
+		taskUseCase: taskUseCase,
+	}
+}
+
+// CreateTask создает новую задачу.
+func (s *TaskServer) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.CreateTaskResponse, error) {
+	projectID, err := parseProjectID(req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := parseOptionalID(req.GetParentId(), "parent")
+	if err != nil {
+		return nil, err
+	}
+	startAt, dueAt, err := parseSchedule(req.GetStartAt(), req.GetDueAt())
+	if err != nil {
+		return nil, err
+	}
+	customFields, err := parseCustomFields(req.GetCustomFields())
+	if err != nil {
+		return nil, err
+	}
+	task := entity.Task{
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		Status:       req.GetStatus(),
+		ProjectID:    projectID,
+		ParentID:     parentID,
+		StartAt:      startAt,
+		DueAt:        dueAt,
+		Priority:     int(req.GetPriority()),
+		CustomFields: customFields,
+		AssigneeID:   req.GetAssigneeId(),
+	}
+	if err := task.Validate(); err != nil {
+		return nil, toStatusError(fmt.Errorf("%w: %w", usecase.ErrInvalidTask, err))
+	}
+
+	createdTask, err := s.taskUseCase.Create(ctx, task)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreateTaskResponse{Task: toProtoTask(createdTask)}, nil
+}
+
+// GetTask возвращает задачу по её ID.
+func (s *TaskServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.GetTaskResponse, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+
+	task, err := s.taskUseCase.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.GetTaskResponse{Task: toProtoTask(task)}, nil
+}
+
+// ListTasks возвращает список задач с курсорной пагинацией.
+func (s *TaskServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	projectID, err := parseProjectID(req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	params := entity.TaskListParams{
+		Filter: entity.TaskFilter{
+			ProjectID:     projectID,
+			Statuses:      req.GetStatuses(),
+			TitleContains: req.GetTitleContains(),
+			Overdue:       req.GetOverdue(),
+			DueToday:      req.GetDueToday(),
+			DueWithinDays: int(req.GetDueWithinDays()),
+			LabelsAny:     req.GetLabelsAny(),
+			LabelsAll:     req.GetLabelsAll(),
+			CustomFields:  parseCustomFieldFilters(req.GetCustomFields()),
+			AssigneeID:    req.GetAssigneeId(),
+			CreatedBy:     req.GetCreatedBy(),
+		},
+		Sort:   entity.TaskSort{Field: req.GetSortBy(), Desc: req.GetSortDesc()},
+		Cursor: req.GetCursor(),
+		Limit:  int(req.GetLimit()),
+	}
+	timeParams := []struct {
+		name  string
+		value string
+		dst   **time.Time
+	}{
+		{"created_from", req.GetCreatedFrom(), &params.Filter.CreatedFrom},
+		{"created_to", req.GetCreatedTo(), &params.Filter.CreatedTo},
+		{"updated_from", req.GetUpdatedFrom(), &params.Filter.UpdatedFrom},
+		{"updated_to", req.GetUpdatedTo(), &params.Filter.UpdatedTo},
+		{"due_from", req.GetDueFrom(), &params.Filter.DueFrom},
+		{"due_to", req.GetDueTo(), &params.Filter.DueTo},
+	}
+	for _, p := range timeParams {
+		if p.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, p.value)
+		if err != nil {
+			return nil, toStatusError(entity.InvalidRequest("%s must be an RFC 3339 timestamp", p.name))
+		}
+		*p.dst = &t
+	}
+
+	if params.Filter.DueWithinDays < 0 {
+		return nil, toStatusError(entity.InvalidRequest("due_within_days must not be negative"))
+	}
+
+	page, err := s.taskUseCase.List(ctx, params)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.ListTasksResponse{
+		Tasks:      make([]*pb.Task, 0, len(page.Tasks)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for _, task := range page.Tasks {
+		resp.Tasks = append(resp.Tasks, toProtoTask(task))
+	}
+	return resp, nil
+}
+
+// SearchTasks выполняет полнотекстовый поиск задач.
+func (s *TaskServer) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	projectID, err := parseProjectID(req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.taskUseCase.Search(ctx, entity.TaskSearchParams{
+		Query:     req.GetQuery(),
+		ProjectID: projectID,
+		Statuses:  req.GetStatuses(),
+		Limit:     int(req.GetLimit()),
+		Offset:    int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.SearchTasksResponse{Results: make([]*pb.SearchResult, 0, len(results))}
+	for _, res := range results {
+		resp.Results = append(resp.Results, &pb.SearchResult{
+			Task:                 toProtoTask(res.Task),
+			Rank:                 res.Rank,
+			TitleHighlight:       res.TitleHighlight,
+			DescriptionHighlight: res.DescriptionHighlight,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateTask обновляет существующую задачу. При заданной update_mask
+// обновляются только перечисленные в ней поля.
+func (s *TaskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.UpdateTaskResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+
+	projectID, err := parseProjectID(req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	startAt, dueAt, err := parseSchedule(req.GetStartAt(), req.GetDueAt())
+	if err != nil {
+		return nil, err
+	}
+	customFields, err := parseCustomFields(req.GetCustomFields())
+	if err != nil {
+		return nil, err
+	}
+	task := entity.Task{
+		ID:           id,
+		Title:        req.GetTitle(),
+		Description:  req.GetDescription(),
+		Status:       req.GetStatus(),
+		Version:      req.GetVersion(),
+		ProjectID:    projectID,
+		StartAt:      startAt,
+		DueAt:        dueAt,
+		Priority:     int(req.GetPriority()),
+		CustomFields: customFields,
+		AssigneeID:   req.GetAssigneeId(),
+	}
+
+	var opts []usecase.UpdateOption
+	if req.GetIgnoreBlockers() {
+		opts = append(opts, usecase.IgnoreBlockers())
+	}
+
+	if paths := req.GetUpdateMask().GetPaths(); len(paths) > 0 {
+		patch, err := usecase.NewFieldMaskPatch(paths, task)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		patchedTask, err := s.taskUseCase.Patch(ctx, id.String(), req.GetVersion(), patch, opts...)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		return &pb.UpdateTaskResponse{Task: toProtoTask(patchedTask)}, nil
+	}
+
+	if err := task.Validate(); err != nil {
+		return nil, toStatusError(fmt.Errorf("%w: %w", usecase.ErrInvalidTask, err))
+	}
+
+	updatedTask, err := s.taskUseCase.Update(ctx, task, opts...)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.UpdateTaskResponse{Task: toProtoTask(updatedTask)}, nil
+}
+
+// DeleteTask удаляет задачу по её ID.
+func (s *TaskServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.DeleteTaskResponse, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+
+	if err := s.taskUseCase.Delete(ctx, req.GetId(), req.GetVersion()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.DeleteTaskResponse{Success: true}, nil
+}
+
+// BatchCreateTasks создает задачи пакетом.
+func (s *TaskServer) BatchCreateTasks(ctx context.Context, req *pb.BatchCreateTasksRequest) (*pb.BatchResponse, error) {
+	tasks := make([]entity.Task, len(req.GetTasks()))
+	for i, t := range req.GetTasks() {
+		projectID, err := parseProjectID(t.GetProjectId())
+		if err != nil {
+			return nil, err
+		}
+		parentID, err := parseOptionalID(t.GetParentId(), "parent")
+		if err != nil {
+			return nil, err
+		}
+		startAt, dueAt, err := parseSchedule(t.GetStartAt(), t.GetDueAt())
+		if err != nil {
+			return nil, err
+		}
+		customFields, err := parseCustomFields(t.GetCustomFields())
+		if err != nil {
+			return nil, err
+		}
+		tasks[i] = entity.Task{
+			Title:        t.GetTitle(),
+			Description:  t.GetDescription(),
+			Status:       t.GetStatus(),
+			ProjectID:    projectID,
+			ParentID:     parentID,
+			StartAt:      startAt,
+			DueAt:        dueAt,
+			Priority:     int(t.GetPriority()),
+			CustomFields: customFields,
+		}
+	}
+
+	result, err := s.taskUseCase.BatchCreate(ctx, tasks, req.GetAtomic())
+	return toBatchResponse(result, err)
+}
+
+// BatchUpdateTasks полностью обновляет задачи пакетом.
+func (s *TaskServer) BatchUpdateTasks(ctx context.Context, req *pb.BatchUpdateTasksRequest) (*pb.BatchResponse, error) {
+	tasks := make([]entity.Task, len(req.GetTasks()))
+	for i, t := range req.GetTasks() {
+		// Некорректный ID превращается в uuid.Nil, и usecase вернет ошибку для этого элемента
+		id, _ := uuid.Parse(t.GetId())
+		projectID, err := parseProjectID(t.GetProjectId())
+		if err != nil {
+			return nil, err
+		}
+		startAt, dueAt, err := parseSchedule(t.GetStartAt(), t.GetDueAt())
+		if err != nil {
+			return nil, err
+		}
+		customFields, err := parseCustomFields(t.GetCustomFields())
+		if err != nil {
+			return nil, err
+		}
+		tasks[i] = entity.Task{
+			ID:           id,
+			Title:        t.GetTitle(),
+			Description:  t.GetDescription(),
+			Status:       t.GetStatus(),
+			Version:      t.GetVersion(),
+			ProjectID:    projectID,
+			StartAt:      startAt,
+			DueAt:        dueAt,
+			Priority:     int(t.GetPriority()),
+			CustomFields: customFields,
+		}
+	}
+
+	result, err := s.taskUseCase.BatchUpdate(ctx, tasks, req.GetAtomic())
+	return toBatchResponse(result, err)
+}
+
+// BatchDeleteTasks перемещает задачи в корзину пакетом.
+func (s *TaskServer) BatchDeleteTasks(ctx context.Context, req *pb.BatchDeleteTasksRequest) (*pb.BatchResponse, error) {
+	refs := make([]entity.TaskRef, len(req.GetTasks()))
+	for i, t := range req.GetTasks() {
+		id, _ := uuid.Parse(t.GetId())
+		refs[i] = entity.TaskRef{ID: id, Version: t.GetVersion()}
+	}
+
+	result, err := s.taskUseCase.BatchDelete(ctx, refs, req.GetAtomic())
+	return toBatchResponse(result, err)
+}
+
+// ListTransitions возвращает статусы, в которые можно перевести задачу.
+func (s *TaskServer) ListTransitions(ctx context.Context, req *pb.ListTransitionsRequest) (*pb.ListTransitionsResponse, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+
+	transitions, err := s.taskUseCase.NextStatuses(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.ListTransitionsResponse{Transitions: make([]*pb.StatusTransition, 0, len(transitions))}
+	for _, t := range transitions {
+		resp.Transitions = append(resp.Transitions, &pb.StatusTransition{
+			Status:       t.Status,
+			Allowed:      t.Allowed,
+			FailedGuards: t.FailedGuards,
+		})
+	}
+	return resp, nil
+}
+
+// MoveTask переносит задачу вместе с подзадачами под другого родителя.
+func (s *TaskServer) MoveTask(ctx context.Context, req *pb.MoveTaskRequest) (*pb.MoveTaskResponse, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+	parentID, err := parseOptionalID(req.GetParentId(), "parent")
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskUseCase.Move(ctx, req.GetId(), parentID, req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.MoveTaskResponse{Task: toProtoTask(task)}, nil
+}
+
+// AddDependency отмечает, что одна задача блокирует другую.
+func (s *TaskServer) AddDependency(ctx context.Context, req *pb.AddDependencyRequest) (*pb.AddDependencyResponse, error) {
+	if _, err := uuid.Parse(req.GetBlockedId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+	blockerID, err := uuid.Parse(req.GetBlockerId())
+	if err != nil {
+		return nil, toStatusError(entity.InvalidID("blocker"))
+	}
+
+	dep, err := s.taskUseCase.AddDependency(ctx, req.GetBlockedId(), blockerID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.AddDependencyResponse{Dependency: toProtoDependency(dep)}, nil
+}
+
+// RemoveDependency удаляет связь между блокирующей и блокируемой задачами.
+func (s *TaskServer) RemoveDependency(ctx context.Context, req *pb.RemoveDependencyRequest) (*pb.RemoveDependencyResponse, error) {
+	if _, err := uuid.Parse(req.GetBlockedId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+	blockerID, err := uuid.Parse(req.GetBlockerId())
+	if err != nil {
+		return nil, toStatusError(entity.InvalidID("blocker"))
+	}
+
+	if err := s.taskUseCase.RemoveDependency(ctx, req.GetBlockedId(), blockerID); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.RemoveDependencyResponse{}, nil
+}
+
+// GetDependencyGraph возвращает граф зависимостей задачи.
+func (s *TaskServer) GetDependencyGraph(ctx context.Context, req *pb.GetDependencyGraphRequest) (*pb.GetDependencyGraphResponse, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+	if req.GetDepth() < 0 {
+		return nil, toStatusError(entity.InvalidRequest("depth must not be negative"))
+	}
+
+	graph, err := s.taskUseCase.DependencyGraph(ctx, req.GetId(), int(req.GetDepth()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.GetDependencyGraphResponse{
+		Nodes: make([]*pb.Task, 0, len(graph.Nodes)),
+		Edges: make([]*pb.TaskDependency, 0, len(graph.Edges)),
+	}
+	for _, task := range graph.Nodes {
+		resp.Nodes = append(resp.Nodes, toProtoTask(task))
+	}
+	for _, dep := range graph.Edges {
+		resp.Edges = append(resp.Edges, toProtoDependency(dep))
+	}
+	return resp, nil
+}
+
+// AttachLabel назначает метку задаче.
+func (s *TaskServer) AttachLabel(ctx context.Context, req *pb.AttachLabelRequest) (*pb.AttachLabelResponse, error) {
+	if _, err := uuid.Parse(req.GetTaskId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+	labelID, err := uuid.Parse(req.GetLabelId())
+	if err != nil {
+		return nil, toStatusError(entity.InvalidID("label"))
+	}
+
+	task, err := s.taskUseCase.AttachLabel(ctx, req.GetTaskId(), labelID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.AttachLabelResponse{Task: toProtoTask(task)}, nil
+}
+
+// DetachLabel снимает метку с задачи.
+func (s *TaskServer) DetachLabel(ctx context.Context, req *pb.DetachLabelRequest) (*pb.DetachLabelResponse, error) {
+	if _, err := uuid.Parse(req.GetTaskId()); err != nil {
+		return nil, toStatusError(entity.InvalidID("task"))
+	}
+	labelID, err := uuid.Parse(req.GetLabelId())
+	if err != nil {
+		return nil, toStatusError(entity.InvalidID("label"))
+	}
+
+	task, err := s.taskUseCase.DetachLabel(ctx, req.GetTaskId(), labelID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.DetachLabelResponse{Task: toProtoTask(task)}, nil
+}
+
+// toBatchResponse преобразует результат пакета. Отмененный атомарный пакет
+// возвращается без ошибки с committed = false и причиной в элементах.
+func toBatchResponse(result entity.BatchResult, err error) (*pb.BatchResponse, error) {
+	if err != nil && !errors.Is(err, usecase.ErrBatchAborted) {
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.BatchResponse{
+		Committed: result.Committed,
+		Succeeded: int32(result.Succeeded),
+		Failed:    int32(result.Failed),
+		Items:     make([]*pb.BatchItemResult, 0, len(result.Items)),
+	}
+	for _, item := range result.Items {
+		pbItem := &pb.BatchItemResult{
+			Index: int32(item.Index),
+			Id:    item.ID.String(),
+			Error: item.Error,
+		}
+		if item.Task != nil {
+			pbItem.Task = toProtoTask(*item.Task)
+		}
+		resp.Items = append(resp.Items, pbItem)
+	}
+	return resp, nil
+}
+
+// parseProjectID разбирает необязательный ID проекта; пустая строка означает задачу вне проекта.
+func parseProjectID(s string) (*uuid.UUID, error) {
+	return parseOptionalID(s, "project")
+}
+
+// parseOptionalID разбирает необязательный UUID объекта name; для пустой строки возвращается nil.
+func parseOptionalID(s, name string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, toStatusError(entity.InvalidID(name))
+	}
+	return &id, nil
+}
+
+// parseSchedule разбирает необязательные даты начала и срока задачи в RFC 3339;
+// пустая строка означает, что дата не задана.
+func parseSchedule(startAt, dueAt string) (*time.Time, *time.Time, error) {
+	parse := func(s, name string) (*time.Time, error) {
+		if s == "" {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, toStatusError(entity.InvalidRequest("%s must be an RFC 3339 timestamp", name))
+		}
+		return &t, nil
+	}
+	start, err := parse(startAt, "start_at")
+	if err != nil {
+		return nil, nil, err
+	}
+	due, err := parse(dueAt, "due_at")
+	if err != nil {
+		return nil, nil, err
+	}
+	return start, due, nil
+}
+
+// parseCustomFields разбирает значения пользовательских полей, переданные в JSON.
+// Соответствие значений схемам полей проверяет usecase.
+func parseCustomFields(values map[string]string) (map[string]json.RawMessage, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	fields := make(map[string]json.RawMessage, len(values))
+	for key, value := range values {
+		if !json.Valid([]byte(value)) {
+			return nil, toStatusError(entity.InvalidRequest("custom field %q must be a JSON value", key))
+		}
+		fields[key] = json.RawMessage(value)
+	}
+	return fields, nil
+}
+
+func parseCustomFieldFilters(filters []*pb.CustomFieldFilter) []entity.CustomFieldFilter {
+	var result []entity.CustomFieldFilter
+	for _, f := range filters {
+		op := f.GetOp()
+		if op == "" {
+			op = entity.CustomFieldEq
+		}
+		result = append(result, entity.CustomFieldFilter{Key: f.GetKey(), Op: op, Value: f.GetValue()})
+	}
+	return result
+}
+
+func toProtoTask(task entity.Task) *pb.Task {
+	pbTask := &pb.Task{
+		Id:          task.ID.String(),
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+		Version:     task.Version,
+		Priority:    int32(task.Priority),
+		Rank:        task.Rank,
+		CreatedBy:   task.CreatedBy,
+		AssigneeId:  task.AssigneeID,
+	}
+	if task.ProjectID != nil {
+		pbTask.ProjectId = task.ProjectID.String()
+	}
+	if task.ParentID != nil {
+		pbTask.ParentId = task.ParentID.String()
+	}
+	if task.StartAt != nil {
+		pbTask.StartAt = task.StartAt.Format(time.RFC3339)
+	}
+	if task.DueAt != nil {
+		pbTask.DueAt = task.DueAt.Format(time.RFC3339)
+	}
+	for _, label := range task.Labels {
+		pbTask.Labels = append(pbTask.Labels, &pb.Label{
+			Id:    label.ID.String(),
+			Name:  label.Name,
+			Color: label.Color,
+		})
+	}
+	if len(task.CustomFields) > 0 {
+		pbTask.CustomFields = make(map[string]string, len(task.CustomFields))
+		for key, value := range task.CustomFields {
+			pbTask.CustomFields[key] = string(value)
+		}
+	}
+	return pbTask
+}
+
+func toProtoDependency(dep entity.TaskDependency) *pb.TaskDependency {
+	return &pb.TaskDependency{
+		BlockerId: dep.BlockerID.String(),
+		BlockedId: dep.BlockedID.String(),
+		CreatedAt: dep.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// APIKeyHandler обрабатывает HTTP-запросы для управления ключами API.
+type APIKeyHandler struct {
+	apiKeyUseCase usecase.APIKeyUseCase
+}
+
+// NewAPIKeyHandler создает новый экземпляр APIKeyHandler.
+func NewAPIKeyHandler(apiKeyUseCase usecase.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+// IssueAPIKey обрабатывает выпуск ключа API.
+// @Summary      Выпустить ключ API
+// @Description  Выпускает ключ с именем, областями доступа (tasks:read, tasks:write, admin) и необязательным сроком действия. Секрет ключа возвращается только в этом ответе
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Param        key body     entity.APIKey true "Имя, области доступа и срок действия ключа"
+// @Success      201 {object} entity.IssuedAPIKey
+// @Failure      400 {object} Problem "Неверный формат данных"
+// @Failure      403 {object} Problem "Нужна область доступа admin"
+// @Failure      422 {object} Problem "Ошибка валидации"
+// @Router       /v1/api-keys [post]
+func (h *APIKeyHandler) IssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	var key entity.APIKey
+	if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	issuedKey, err := h.apiKeyUseCase.Issue(r.Context(), key)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(issuedKey)
+}
+
+// ListAPIKeys обрабатывает получение списка ключей API.
+// @Summary      Список ключей API
+// @Description  Возвращает все ключи, включая отозванные и просроченные, от новых к старым. Секреты не возвращаются
+// @Tags         api-keys
+// @Produce      json
+// @Success      200 {array}  entity.APIKey
+// @Failure      403 {object} Problem "Нужна область доступа admin"
+// @Failure      500 {object} Problem "Внутренняя ошибка сервера"
+// @Router       /v1/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyUseCase.List(r.Context())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// RevokeAPIKey обрабатывает отзыв ключа API.
+// @Summary      Отозвать ключ API
+// @Description  Отзывает ключ: запросы с ним сразу перестают приниматься. Ключ остается в списке с временем отзыва
+// @Tags         api-keys
+// @Param        kid path string true "ID ключа"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Нужна область доступа admin"
+// @Failure      404 {object} Problem "Ключ не найден"
+// @Router       /v1/api-keys/{kid} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "kid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("API key"))
+		return
+	}
+
+	if err := h.apiKeyUseCase.Revoke(r.Context(), id); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// AttachmentFormField — имя поля multipart-формы с загружаемым файлом.
+const AttachmentFormField = "file"
+
+// ErrNoAttachmentFile возвращается, если в multipart-форме нет поля AttachmentFormField.
+var ErrNoAttachmentFile = entity.NewError(entity.KindInvalidArgument, "no_attachment_file", "expected multipart form with a file field")
+
+// AttachmentHandler обрабатывает HTTP-запросы для работы с вложениями задач.
+type AttachmentHandler struct {
+	attachmentUseCase usecase.AttachmentUseCase
+}
+
+// NewAttachmentHandler создает новый экземпляр AttachmentHandler.
+func NewAttachmentHandler(attachmentUseCase usecase.AttachmentUseCase) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUseCase: attachmentUseCase,
+	}
+}
+
+// UploadAttachment обрабатывает загрузку вложения.
+// @Summary      Загрузить вложение
+// @Description  Загружает файл из поля file multipart-формы. Файл передается в хранилище потоком; тип содержимого определяется по первым байтам файла
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id   path     string true "ID задачи"
+// @Param        file formData file   true "Файл"
+// @Success      201 {object} entity.Attachment
+// @Failure      400 {object} Problem "Неверный формат ID или формы"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Failure      413 {object} Problem "Файл превышает допустимый размер"
+// @Failure      422 {object} Problem "Недопустимое имя файла"
+// @Router       /v1/tasks/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	attachment, err := UploadMultipartAttachment(r, h.attachmentUseCase, id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(attachment)
+}
+
+// ListAttachments обрабатывает получение вложений задачи.
+// @Summary      Вложения задачи
+// @Description  Возвращает метаданные вложений задачи в порядке загрузки
+// @Tags         attachments
+// @Produce      json
+// @Param        id path string true "ID задачи"
+// @Success      200 {array}  entity.Attachment
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Router       /v1/tasks/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	attachments, err := h.attachmentUseCase.List(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attachments)
+}
+
+// DownloadAttachment обрабатывает скачивание вложения.
+// @Summary      Скачать вложение
+// @Description  Отдает содержимое файла потоком. ETag содержит SHA-256 файла, поэтому If-None-Match позволяет не скачивать файл повторно
+// @Tags         attachments
+// @Produce      octet-stream
+// @Param        id  path string true "ID задачи"
+// @Param        aid path string true "ID вложения"
+// @Success      200 {file}   file
+// @Success      304
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача или вложение не найдены"
+// @Router       /v1/tasks/{id}/attachments/{aid} [get]
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	aid := chi.URLParam(r, "aid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+	if _, err := uuid.Parse(aid); err != nil {
+		WriteProblem(w, r, entity.InvalidID("attachment"))
+		return
+	}
+
+	attachment, content, err := h.attachmentUseCase.Download(r.Context(), id, aid)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	WriteAttachment(w, r, attachment, content)
+}
+
+// DeleteAttachment обрабатывает удаление вложения.
+// @Summary      Удалить вложение
+// @Description  Удаляет вложение задачи вместе с содержимым файла
+// @Tags         attachments
+// @Param        id  path string true "ID задачи"
+// @Param        aid path string true "ID вложения"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача или вложение не найдены"
+// @Router       /v1/tasks/{id}/attachments/{aid} [delete]
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	aid := chi.URLParam(r, "aid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+	if _, err := uuid.Parse(aid); err != nil {
+		WriteProblem(w, r, entity.InvalidID("attachment"))
+		return
+	}
+
+	if err := h.attachmentUseCase.Delete(r.Context(), id, aid); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UploadMultipartAttachment находит в multipart-теле запроса поле AttachmentFormField и
+// передает его содержимое в usecase, не буферизуя форму целиком, как ParseMultipartForm.
+func UploadMultipartAttachment(r *http.Request, uc usecase.AttachmentUseCase, taskID string) (entity.Attachment, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return entity.Attachment{}, fmt.Errorf("%w: %w", ErrNoAttachmentFile, err)
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return entity.Attachment{}, ErrNoAttachmentFile
+		}
+		if err != nil {
+			return entity.Attachment{}, entity.InvalidRequest("malformed multipart form: %v", err)
+		}
+		if part.FormName() != AttachmentFormField {
+			part.Close()
+			continue
+		}
+		defer part.Close()
+		return uc.Upload(r.Context(), taskID, part.FileName(), part)
+	}
+}
+
+// WriteAttachment отдает содержимое вложения потоком. Тип содержимого берется из
+// метаданных, а nosniff и Content-Disposition: attachment не дают браузеру исполнить файл.
+func WriteAttachment(w http.ResponseWriter, r *http.Request, attachment entity.Attachment, content io.Reader) {
+	etag := `"` + attachment.Checksum + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if _, err := io.Copy(w, content); err != nil {
+		logger.Log.Warn("Attachment download interrupted", "id", attachment.ID, "error", err)
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// BearerToken возвращает токен из заголовка Authorization со схемой Bearer.
+func BearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// Authenticate пропускает только запросы с действительным токеном доступа и передает
+// его владельца в контекст запроса. Владелец токена записывается автором изменений
+// в историю задач вместо заголовка ActorHeader, поэтому middleware подключается после Audit.
+func Authenticate(verifier usecase.TokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := BearerToken(r.Header.Get("Authorization"))
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				WriteProblem(w, r, fmt.Errorf("%w: authentication required", usecase.ErrUnauthenticated))
+				return
+			}
+
+			principal, err := verifier.Verify(r.Context(), token)
+			if err != nil {
+				logger.Log.Warn("Access token rejected", "error", err)
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				WriteProblem(w, r, fmt.Errorf("%w: invalid access token", usecase.ErrUnauthenticated))
+				return
+			}
+
+			ctx := usecase.WithPrincipal(r.Context(), principal)
+			audit := usecase.AuditFromContext(ctx)
+			audit.Actor = principal.Subject
+			next.ServeHTTP(w, r.WithContext(usecase.WithAudit(ctx, audit)))
+		})
+	}
+}
+
+// RequireScope ограничивает маршруты областями доступа: чтение (GET, HEAD, OPTIONS) требует
+// области read, остальные методы — области write. Запросы без аутентификации пропускаются,
+// поэтому middleware подключается после Authenticate.
+func RequireScope(read, write string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := write
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				scope = read
+			}
+			if err := usecase.RequireScope(r.Context(), scope); err != nil {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, scope))
+				WriteProblem(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAdmin ограничивает маршруты областью admin для всех методов.
+func RequireAdmin(next http.Handler) http.Handler {
+	return RequireScope(entity.ScopeAdmin, entity.ScopeAdmin)(next)
+}
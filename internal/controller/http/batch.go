@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// BulkTasksRequest — тело запросов пакетного создания и обновления задач.
+type BulkTasksRequest struct {
+	Atomic bool          `json:"atomic"`
+	Tasks  []entity.Task `json:"tasks"`
+}
+
+// BulkDeleteRequest — тело запроса пакетного удаления задач.
+type BulkDeleteRequest struct {
+	Atomic bool             `json:"atomic"`
+	Items  []entity.TaskRef `json:"items"`
+}
+
+// BulkCreateTasks обрабатывает пакетное создание задач.
+// @Summary      Создать задачи пакетом
+// @Description  Создает до 1000 задач атомарно или независимо друг от друга
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body     BulkTasksRequest true "Задачи"
+// @Success      200     {object} entity.BatchResult
+// @Failure      400     {object} Problem "Пустой или слишком большой пакет"
+// @Failure      409     {object} Problem "Задача уже существует"
+// @Failure      422     {object} entity.BatchResult "Атомарный пакет отменен"
+// @Router       /v1/tasks/bulk/create [post]
+func (h *TaskHandler) BulkCreateTasks(w http.ResponseWriter, r *http.Request) {
+	var req BulkTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	result, err := h.taskUseCase.BatchCreate(r.Context(), req.Tasks, req.Atomic)
+	writeBatchResult(w, r, result, err)
+}
+
+// BulkUpdateTasks обрабатывает пакетное обновление задач.
+// @Summary      Обновить задачи пакетом
+// @Description  Полностью обновляет до 1000 задач; ненулевая version включает проверку версии
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body     BulkTasksRequest true "Задачи"
+// @Success      200     {object} entity.BatchResult
+// @Failure      400     {object} Problem "Пустой или слишком большой пакет"
+// @Failure      422     {object} entity.BatchResult "Атомарный пакет отменен"
+// @Router       /v1/tasks/bulk/update [post]
+func (h *TaskHandler) BulkUpdateTasks(w http.ResponseWriter, r *http.Request) {
+	var req BulkTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	result, err := h.taskUseCase.BatchUpdate(r.Context(), req.Tasks, req.Atomic)
+	writeBatchResult(w, r, result, err)
+}
+
+// BulkDeleteTasks обрабатывает пакетное удаление задач.
+// @Summary      Удалить задачи пакетом
+// @Description  Перемещает до 1000 задач в корзину
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        request body     BulkDeleteRequest true "Задачи"
+// @Success      200     {object} entity.BatchResult
+// @Failure      400     {object} Problem "Пустой или слишком большой пакет"
+// @Failure      422     {object} entity.BatchResult "Атомарный пакет отменен"
+// @Router       /v1/tasks/bulk/delete [post]
+func (h *TaskHandler) BulkDeleteTasks(w http.ResponseWriter, r *http.Request) {
+	var req BulkDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	result, err := h.taskUseCase.BatchDelete(r.Context(), req.Items, req.Atomic)
+	writeBatchResult(w, r, result, err)
+}
+
+// writeBatchResult отдает результат пакетной операции. Отмененный атомарный пакет
+// возвращается с кодом 422 и результатами по каждой операции.
+func writeBatchResult(w http.ResponseWriter, r *http.Request, result entity.BatchResult, err error) {
+	code := http.StatusOK
+	switch {
+	case err == nil:
+	case errors.Is(err, usecase.ErrBatchAborted):
+		code = http.StatusUnprocessableEntity
+	default:
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(result)
+}
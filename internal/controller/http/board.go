@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// MoveOnBoard обрабатывает перемещение задачи на доске.
+// @Summary      Переместить задачу на доске
+// @Description  Переводит задачу в колонку status и ставит её между after_id и before_id. Без соседей задача встает в конец колонки, без status остается в текущей колонке
+// @Tags         board
+// @Accept       json
+// @Produce      json
+// @Param        id              path     string               true  "ID задачи"
+// @Param        position        body     entity.BoardPosition true  "Колонка и соседи задачи"
+// @Param        If-Match        header   string               false "ETag задачи"
+// @Param        ignore_blockers query    bool                 false "Сменить статус, несмотря на невыполненные блокирующие задачи"
+// @Success      200 {object} entity.Task
+// @Failure      400 {object} Problem "Неверный формат ID или данных"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Failure      409 {object} Problem "Задача заблокирована"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      412 {object} Problem "Задача изменена другим клиентом"
+// @Failure      422 {object} Problem "Недопустимый переход или соседи"
+// @Router       /v1/tasks/{id}/board-move [post]
+func (h *TaskHandler) MoveOnBoard(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	version, err := ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	opts, err := ParseUpdateOptions(r.URL.Query())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	var position entity.BoardPosition
+	if err := json.NewDecoder(r.Body).Decode(&position); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	movedTask, err := h.taskUseCase.MoveOnBoard(r.Context(), id, version, position, opts...)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(movedTask.Version))
+	json.NewEncoder(w).Encode(movedTask)
+}
+
+// GetBoard обрабатывает получение доски задач.
+// @Summary      Доска задач
+// @Description  Возвращает колонки доски в порядке статусов процесса работы с первыми limit задачами каждой колонки в порядке рангов. Продолжение колонки выдает список задач с фильтром status, sort=rank и курсором next_cursor
+// @Tags         board
+// @Produce      json
+// @Param        pid   path     string false "ID проекта (для вложенного маршрута)"
+// @Param        limit query    int    false "Количество задач в колонке" default(20)
+// @Success      200   {object} entity.Board
+// @Failure      400   {object} Problem "Неверный формат ID проекта"
+// @Failure      403   {object} Problem "Недостаточно прав"
+// @Failure      404   {object} Problem "Проект не найден"
+// @Failure      500   {object} Problem "Внутренняя ошибка сервера"
+// @Router       /v1/board [get]
+// @Router       /v1/projects/{pid}/board [get]
+func (h *TaskHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	params := ParseBoardParams(r.URL.Query())
+	var err error
+	if params.ProjectID, err = ProjectScope(r); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	board, err := h.taskUseCase.Board(r.Context(), params)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(board)
+}
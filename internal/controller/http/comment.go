@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CreateCommentRequest — тело запроса на создание комментария.
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+	// ParentID задается при ответе на комментарий верхнего уровня.
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// UpdateCommentRequest — тело запроса на изменение текста комментария.
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentHandler обрабатывает HTTP-запросы для работы с комментариями задач.
+type CommentHandler struct {
+	commentUseCase usecase.CommentUseCase
+}
+
+// NewCommentHandler создает новый экземпляр CommentHandler.
+func NewCommentHandler(commentUseCase usecase.CommentUseCase) *CommentHandler {
+	return &CommentHandler{
+		commentUseCase: commentUseCase,
+	}
+}
+
+// CreateComment обрабатывает создание комментария.
+// @Summary      Добавить комментарий
+// @Description  Добавляет комментарий к задаче или ответ на комментарий верхнего уровня
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id      path     string               true "ID задачи"
+// @Param        comment body     CreateCommentRequest true "Комментарий"
+// @Success      201 {object} entity.Comment
+// @Failure      400 {object} Problem "Неверный формат ID или данных"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача или комментарий не найдены"
+// @Failure      422 {object} Problem "Ошибка валидации или ответ на ответ"
+// @Router       /v1/tasks/{id}/comments [post]
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	comment, err := h.commentUseCase.Create(r.Context(), id, entity.Comment{Body: req.Body, ParentID: req.ParentID})
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// ListComments обрабатывает получение комментариев задачи.
+// @Summary      Комментарии задачи
+// @Description  Возвращает страницу комментариев верхнего уровня в порядке создания вместе с ответами на них
+// @Tags         comments
+// @Produce      json
+// @Param        id     path     string true  "ID задачи"
+// @Param        cursor query    string false "Курсор следующей страницы"
+// @Param        limit  query    int    false "Количество комментариев на странице" default(20)
+// @Success      200 {object} entity.CommentPage
+// @Failure      400 {object} Problem "Неверный формат ID или курсора"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Router       /v1/tasks/{id}/comments [get]
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	params := ParseCommentListParams(r.URL.Query())
+	page, err := h.commentUseCase.List(r.Context(), id, params)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// UpdateComment обрабатывает изменение комментария.
+// @Summary      Изменить комментарий
+// @Description  Меняет текст комментария и отмечает время правки в edited_at
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id      path     string               true "ID задачи"
+// @Param        cid     path     string               true "ID комментария"
+// @Param        comment body     UpdateCommentRequest true "Новый текст"
+// @Success      200 {object} entity.Comment
+// @Failure      400 {object} Problem "Неверный формат ID или данных"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача или комментарий не найдены"
+// @Failure      422 {object} Problem "Ошибка валидации"
+// @Router       /v1/tasks/{id}/comments/{cid} [put]
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	cid := chi.URLParam(r, "cid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+	if _, err := uuid.Parse(cid); err != nil {
+		WriteProblem(w, r, entity.InvalidID("comment"))
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	comment, err := h.commentUseCase.Update(r.Context(), id, cid, req.Body)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comment)
+}
+
+// DeleteComment обрабатывает удаление комментария.
+// @Summary      Удалить комментарий
+// @Description  Удаляет комментарий вместе с ответами на него
+// @Tags         comments
+// @Param        id  path string true "ID задачи"
+// @Param        cid path string true "ID комментария"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача или комментарий не найдены"
+// @Router       /v1/tasks/{id}/comments/{cid} [delete]
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	cid := chi.URLParam(r, "cid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+	if _, err := uuid.Parse(cid); err != nil {
+		WriteProblem(w, r, entity.InvalidID("comment"))
+		return
+	}
+
+	if err := h.commentUseCase.Delete(r.Context(), id, cid); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
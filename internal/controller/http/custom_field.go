@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CustomFieldHandler обрабатывает HTTP-запросы для работы со схемами пользовательских полей.
+type CustomFieldHandler struct {
+	customFieldUseCase usecase.CustomFieldUseCase
+}
+
+// NewCustomFieldHandler создает новый экземпляр CustomFieldHandler.
+func NewCustomFieldHandler(customFieldUseCase usecase.CustomFieldUseCase) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		customFieldUseCase: customFieldUseCase,
+	}
+}
+
+// CreateCustomField обрабатывает создание пользовательского поля.
+// @Summary      Создать пользовательское поле
+// @Description  Создает поле задач с типом string, number, enum, date или user. Значения поля задаются в custom_fields задачи по ключу поля
+// @Tags         custom-fields
+// @Accept       json
+// @Produce      json
+// @Param        field body     entity.CustomField true "Схема поля"
+// @Success      201   {object} entity.CustomField
+// @Failure      400   {object} Problem "Неверный формат данных"
+// @Failure      409   {object} Problem "Поле с таким ключом уже существует"
+// @Failure      422   {object} Problem "Ошибка валидации"
+// @Router       /v1/custom-fields [post]
+func (h *CustomFieldHandler) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	var field entity.CustomField
+	if err := json.NewDecoder(r.Body).Decode(&field); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	createdField, err := h.customFieldUseCase.Create(r.Context(), field)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdField)
+}
+
+// ListCustomFields обрабатывает получение списка пользовательских полей.
+// @Summary      Список пользовательских полей
+// @Description  Возвращает все схемы пользовательских полей, упорядоченные по ключу
+// @Tags         custom-fields
+// @Produce      json
+// @Success      200 {array}  entity.CustomField
+// @Failure      500 {object} Problem "Внутренняя ошибка сервера"
+// @Router       /v1/custom-fields [get]
+func (h *CustomFieldHandler) ListCustomFields(w http.ResponseWriter, r *http.Request) {
+	fields, err := h.customFieldUseCase.List(r.Context())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(fields)
+}
+
+// GetCustomField обрабатывает получение пользовательского поля по ID.
+// @Summary      Получить пользовательское поле
+// @Description  Возвращает схему пользовательского поля
+// @Tags         custom-fields
+// @Produce      json
+// @Param        fid path     string true "ID поля"
+// @Success      200 {object} entity.CustomField
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Поле не найдено"
+// @Router       /v1/custom-fields/{fid} [get]
+func (h *CustomFieldHandler) GetCustomField(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "fid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("custom field"))
+		return
+	}
+
+	field, err := h.customFieldUseCase.Get(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(field)
+}
+
+// UpdateCustomField обрабатывает обновление пользовательского поля.
+// @Summary      Обновить пользовательское поле
+// @Description  Обновляет название, варианты и обязательность поля. Ключ и тип поля не меняются; сохраненные значения проверяются при следующем изменении задачи
+// @Tags         custom-fields
+// @Accept       json
+// @Produce      json
+// @Param        fid   path     string             true "ID поля"
+// @Param        field body     entity.CustomField true "Обновленная схема поля"
+// @Success      200   {object} entity.CustomField
+// @Failure      400   {object} Problem "Неверный формат ID или данных"
+// @Failure      404   {object} Problem "Поле не найдено"
+// @Failure      422   {object} Problem "Ошибка валидации"
+// @Router       /v1/custom-fields/{fid} [put]
+func (h *CustomFieldHandler) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "fid"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidID("custom field"))
+		return
+	}
+
+	var field entity.CustomField
+	if err := json.NewDecoder(r.Body).Decode(&field); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+	field.ID = id
+
+	updatedField, err := h.customFieldUseCase.Update(r.Context(), field)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updatedField)
+}
+
+// DeleteCustomField обрабатывает удаление пользовательского поля.
+// @Summary      Удалить пользовательское поле
+// @Description  Удаляет схему поля и его значения у всех задач; удаление значений записывается в историю задач
+// @Tags         custom-fields
+// @Param        fid path string true "ID поля"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Поле не найдено"
+// @Router       /v1/custom-fields/{fid} [delete]
+func (h *CustomFieldHandler) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "fid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("custom field"))
+		return
+	}
+
+	if err := h.customFieldUseCase.Delete(r.Context(), id); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
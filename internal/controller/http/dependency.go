@@ -0,0 +1,154 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// AddDependencyRequest — тело запроса на добавление блокирующей задачи.
+type AddDependencyRequest struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+}
+
+// ParseUpdateOptions разбирает параметры обновления задачи: ignore_blockers=true
+// разрешает смену статуса при невыполненных блокирующих задачах.
+func ParseUpdateOptions(q url.Values) ([]usecase.UpdateOption, error) {
+	var opts []usecase.UpdateOption
+	if v := q.Get("ignore_blockers"); v != "" {
+		ignore, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, entity.InvalidRequest("ignore_blockers must be a boolean")
+		}
+		if ignore {
+			opts = append(opts, usecase.IgnoreBlockers())
+		}
+	}
+	return opts, nil
+}
+
+// ParseGraphDepth разбирает параметр depth графа зависимостей; 0 означает глубину по умолчанию.
+func ParseGraphDepth(q url.Values) (int, error) {
+	v := q.Get("depth")
+	if v == "" {
+		return 0, nil
+	}
+	depth, err := strconv.Atoi(v)
+	if err != nil || depth < 1 {
+		return 0, entity.InvalidRequest("depth must be a positive integer")
+	}
+	return depth, nil
+}
+
+// AddBlocker обрабатывает добавление блокирующей задачи.
+// @Summary      Добавить блокирующую задачу
+// @Description  Отмечает, что задача blocker_id блокирует задачу. Связь, образующая цикл, отклоняется
+// @Tags         dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id      path     string               true "ID блокируемой задачи"
+// @Param        request body     AddDependencyRequest true "Блокирующая задача"
+// @Success      201 {object} entity.TaskDependency
+// @Failure      400 {object} Problem "Неверный формат ID или данных"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Failure      409 {object} Problem "Связь образует цикл"
+// @Router       /v1/tasks/{id}/blockers [post]
+func (h *TaskHandler) AddBlocker(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	var req AddDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.BlockerID == uuid.Nil {
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	dep, err := h.taskUseCase.AddDependency(r.Context(), id, req.BlockerID)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dep)
+}
+
+// RemoveBlocker обрабатывает удаление блокирующей задачи.
+// @Summary      Удалить блокирующую задачу
+// @Description  Удаляет связь «blocker_id блокирует задачу»
+// @Tags         dependencies
+// @Param        id         path string true "ID блокируемой задачи"
+// @Param        blocker_id path string true "ID блокирующей задачи"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Связь не найдена"
+// @Router       /v1/tasks/{id}/blockers/{blocker_id} [delete]
+func (h *TaskHandler) RemoveBlocker(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+	blockerID, err := uuid.Parse(chi.URLParam(r, "blocker_id"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidID("blocker"))
+		return
+	}
+
+	if err := h.taskUseCase.RemoveDependency(r.Context(), id, blockerID); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDependencyGraph обрабатывает получение графа зависимостей задачи.
+// @Summary      Граф зависимостей
+// @Description  Возвращает задачи, связанные с задачей зависимостями в обе стороны, и связи между ними
+// @Tags         dependencies
+// @Produce      json
+// @Param        id    path     string true  "ID задачи"
+// @Param        depth query    int    false "Максимальное число связей от задачи" default(10)
+// @Success      200 {object} entity.TaskGraph
+// @Failure      400 {object} Problem "Неверный формат ID или глубины"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Router       /v1/tasks/{id}/graph [get]
+func (h *TaskHandler) GetDependencyGraph(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	depth, err := ParseGraphDepth(r.URL.Query())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	graph, err := h.taskUseCase.DependencyGraph(r.Context(), id, depth)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(graph)
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+)
+
+var ErrInvalidIfMatch = entity.NewError(entity.KindInvalidArgument, "invalid_if_match", "invalid If-Match header")
+
+// ErrWeakIfMatch возвращается для слабого ETag в If-Match: условие If-Match сравнивает
+// ETag строго (RFC 7232, раздел 3.1), и слабый ETag ему не соответствует, поэтому
+// запрос отклоняется со статусом 412.
+var ErrWeakIfMatch = entity.NewError(entity.KindVersionConflict, "weak_if_match", "If-Match requires a strong ETag")
+
+// ETag формирует сильный ETag из версии задачи.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch извлекает ожидаемую версию задачи из заголовка If-Match.
+// Пустой заголовок и "*" возвращают 0, то есть отсутствие проверки версии.
+// Поддерживается один сильный ETag; слабый (W/"3") отклоняется с ErrWeakIfMatch.
+func ParseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag, weak := strings.CutPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, ErrInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, ErrInvalidIfMatch
+	}
+	if weak {
+		return 0, ErrWeakIfMatch
+	}
+	return version, nil
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int64
+		wantErr error
+	}{
+		{header: "", want: 0},
+		{header: "*", want: 0},
+		{header: `"3"`, want: 3},
+		{header: ` "42" `, want: 42},
+		{header: `W/"3"`, wantErr: ErrWeakIfMatch},
+		{header: `W/"x"`, wantErr: ErrInvalidIfMatch},
+		{header: `3`, wantErr: ErrInvalidIfMatch},
+		{header: `"0"`, wantErr: ErrInvalidIfMatch},
+		{header: `"-1"`, wantErr: ErrInvalidIfMatch},
+		{header: `"3", "4"`, wantErr: ErrInvalidIfMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := ParseIfMatch(tt.header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseIfMatch(%q) error = %v, want %v", tt.header, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseIfMatch(%q) = %d, want %d", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+// ActorHeader — заголовок с именем автора изменений, которое записывается в историю задач.
+const ActorHeader = "X-Actor"
+
+// Audit передает в контекст запроса автора из ActorHeader и ID запроса, назначенный
+// middleware.RequestID, чтобы изменения задач попали в историю с ними.
+func Audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := usecase.WithAudit(r.Context(), usecase.Audit{
+			Actor:     r.Header.Get(ActorHeader),
+			RequestID: middleware.GetReqID(r.Context()),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// HistoryHandler обрабатывает HTTP-запросы для работы с историей изменений задач.
+type HistoryHandler struct {
+	historyUseCase usecase.HistoryUseCase
+}
+
+// NewHistoryHandler создает новый экземпляр HistoryHandler.
+func NewHistoryHandler(historyUseCase usecase.HistoryUseCase) *HistoryHandler {
+	return &HistoryHandler{
+		historyUseCase: historyUseCase,
+	}
+}
+
+// ListHistory обрабатывает получение истории задачи.
+// @Summary      История задачи
+// @Description  Возвращает изменения задачи от новых к старым: действие, измененные поля со значениями до и после, автора и ID запроса
+// @Tags         history
+// @Produce      json
+// @Param        id     path  string true  "ID задачи"
+// @Param        before query int    false "Вернуть ревизии меньше указанной"
+// @Param        limit  query int    false "Размер страницы (1-100)" default(20)
+// @Success      200 {object} entity.TaskHistoryPage
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Router       /v1/tasks/{id}/history [get]
+func (h *HistoryHandler) ListHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	page, err := h.historyUseCase.List(r.Context(), id, ParseHistoryParams(r.URL.Query()))
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// RevertTask обрабатывает возврат задачи к ревизии.
+// @Summary      Вернуть задачу к ревизии
+// @Description  Возвращает изменяемые поля задачи к состоянию после указанной ревизии. Возврат проверяется как обычное обновление и записывается в историю с действием revert; родитель задачи не меняется
+// @Tags         history
+// @Produce      json
+// @Param        id       path   string true  "ID задачи"
+// @Param        revision path   int    true  "Номер ревизии"
+// @Param        If-Match header string false "ETag задачи"
+// @Success      200 {object} entity.Task
+// @Failure      400 {object} Problem "Неверный формат ID или ревизии"
+// @Failure      404 {object} Problem "Задача или ревизия не найдены"
+// @Failure      409 {object} Problem "Задача заблокирована"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      412 {object} Problem "Задача изменена другим клиентом"
+// @Failure      422 {object} Problem "Переход статуса запрещен"
+// @Router       /v1/tasks/{id}/history/{revision}/revert [post]
+func (h *HistoryHandler) RevertTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+	revision, err := strconv.ParseInt(chi.URLParam(r, "revision"), 10, 64)
+	if err != nil || revision < 1 {
+		WriteProblem(w, r, entity.InvalidRequest("invalid revision"))
+		return
+	}
+	version, err := ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	task, err := h.historyUseCase.Revert(r.Context(), id, revision, version)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", ETag(task.Version))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// AttachLabelRequest — тело запроса на назначение метки задаче.
+type AttachLabelRequest struct {
+	LabelID uuid.UUID `json:"label_id"`
+}
+
+// LabelHandler обрабатывает HTTP-запросы для работы с метками.
+type LabelHandler struct {
+	labelUseCase usecase.LabelUseCase
+}
+
+// NewLabelHandler создает новый экземпляр LabelHandler.
+func NewLabelHandler(labelUseCase usecase.LabelUseCase) *LabelHandler {
+	return &LabelHandler{
+		labelUseCase: labelUseCase,
+	}
+}
+
+// CreateLabel обрабатывает создание метки.
+// @Summary      Создать метку
+// @Description  Создает метку с именем и цветом в формате #RRGGBB
+// @Tags         labels
+// @Accept       json
+// @Produce      json
+// @Param        label body     entity.Label true "Данные метки"
+// @Success      201   {object} entity.Label
+// @Failure      400   {object} Problem "Неверный формат данных"
+// @Failure      409   {object} Problem "Метка с таким именем уже существует"
+// @Failure      422   {object} Problem "Ошибка валидации"
+// @Router       /v1/labels [post]
+func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	var label entity.Label
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	createdLabel, err := h.labelUseCase.Create(r.Context(), label)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdLabel)
+}
+
+// ListLabels обрабатывает получение списка меток.
+// @Summary      Список меток
+// @Description  Возвращает все метки, упорядоченные по имени
+// @Tags         labels
+// @Produce      json
+// @Success      200 {array}  entity.Label
+// @Failure      500 {object} Problem "Внутренняя ошибка сервера"
+// @Router       /v1/labels [get]
+func (h *LabelHandler) ListLabels(w http.ResponseWriter, r *http.Request) {
+	labels, err := h.labelUseCase.List(r.Context())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(labels)
+}
+
+// UpdateLabel обрабатывает обновление метки.
+// @Summary      Обновить метку
+// @Description  Обновляет имя и цвет метки
+// @Tags         labels
+// @Accept       json
+// @Produce      json
+// @Param        lid   path     string       true "ID метки"
+// @Param        label body     entity.Label true "Обновленные данные метки"
+// @Success      200   {object} entity.Label
+// @Failure      400   {object} Problem "Неверный формат ID или данных"
+// @Failure      404   {object} Problem "Метка не найдена"
+// @Failure      409   {object} Problem "Метка с таким именем уже существует"
+// @Failure      422   {object} Problem "Ошибка валидации"
+// @Router       /v1/labels/{lid} [put]
+func (h *LabelHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "lid"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidID("label"))
+		return
+	}
+
+	var label entity.Label
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+	label.ID = id
+
+	updatedLabel, err := h.labelUseCase.Update(r.Context(), label)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updatedLabel)
+}
+
+// DeleteLabel обрабатывает удаление метки.
+// @Summary      Удалить метку
+// @Description  Удаляет метку и снимает её со всех задач
+// @Tags         labels
+// @Param        lid path string true "ID метки"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Метка не найдена"
+// @Router       /v1/labels/{lid} [delete]
+func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "lid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("label"))
+		return
+	}
+
+	if err := h.labelUseCase.Delete(r.Context(), id); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AttachLabel обрабатывает назначение метки задаче.
+// @Summary      Назначить метку
+// @Description  Назначает метку задаче; повторное назначение ничего не меняет
+// @Tags         labels
+// @Accept       json
+// @Produce      json
+// @Param        id      path     string             true "ID задачи"
+// @Param        request body     AttachLabelRequest true "Назначаемая метка"
+// @Success      200 {object} entity.Task
+// @Failure      400 {object} Problem "Неверный формат ID или данных"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Failure      422 {object} Problem "Метка не найдена"
+// @Router       /v1/tasks/{id}/labels [post]
+func (h *TaskHandler) AttachLabel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	var req AttachLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.LabelID == uuid.Nil {
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	task, err := h.taskUseCase.AttachLabel(r.Context(), id, req.LabelID)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
+
+// DetachLabel обрабатывает снятие метки с задачи.
+// @Summary      Снять метку
+// @Description  Снимает метку с задачи
+// @Tags         labels
+// @Produce      json
+// @Param        id       path string true "ID задачи"
+// @Param        label_id path string true "ID метки"
+// @Success      200 {object} entity.Task
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Метка не назначена задаче"
+// @Router       /v1/tasks/{id}/labels/{label_id} [delete]
+func (h *TaskHandler) DetachLabel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+	labelID, err := uuid.Parse(chi.URLParam(r, "label_id"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidID("label"))
+		return
+	}
+
+	task, err := h.taskUseCase.DetachLabel(r.Context(), id, labelID)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
+}
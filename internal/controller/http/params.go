@@ -0,0 +1,213 @@
+package http
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+)
+
+// ParseTaskListParams разбирает параметры запроса списка задач:
+//
+//	cursor, limit                      — пагинация;
+//	status                             — один или несколько статусов (повтор параметра или через запятую);
+//	created_from, created_to           — диапазон created_at в RFC 3339;
+//	updated_from, updated_to           — диапазон updated_at в RFC 3339;
+//	title                              — подстрока названия без учета регистра;
+//	labels_any                         — имена меток, хотя бы одна из которых есть у задачи;
+//	labels_all                         — имена меток, каждая из которых есть у задачи;
+//	assignee_id, created_by            — исполнитель и автор задачи;
+//	due_from, due_to                   — диапазон due_at в RFC 3339;
+//	overdue                            — только невыполненные задачи с истекшим сроком;
+//	due_today                          — только задачи со сроком сегодня;
+//	due_within_days                    — только задачи со сроком в ближайшие N суток;
+//	cf.<key>                           — значение пользовательского поля равно заданному;
+//	cf.<key>.<op>                      — сравнение значения поля: gt, gte, lt или lte;
+//	sort                               — created_at, updated_at, title, due_at, priority, rank или cf.<key>;
+//	order                              — asc или desc.
+func ParseTaskListParams(q url.Values) (entity.TaskListParams, error) {
+	params := entity.TaskListParams{
+		Cursor: q.Get("cursor"),
+	}
+
+	params.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+
+	params.Filter.Statuses = parseStatuses(q)
+	params.Filter.TitleContains = q.Get("title")
+	params.Filter.LabelsAny = parseList(q, "labels_any")
+	params.Filter.LabelsAll = parseList(q, "labels_all")
+	params.Filter.AssigneeID = q.Get("assignee_id")
+	params.Filter.CreatedBy = q.Get("created_by")
+
+	timeParams := []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &params.Filter.CreatedFrom},
+		{"created_to", &params.Filter.CreatedTo},
+		{"updated_from", &params.Filter.UpdatedFrom},
+		{"updated_to", &params.Filter.UpdatedTo},
+		{"due_from", &params.Filter.DueFrom},
+		{"due_to", &params.Filter.DueTo},
+	}
+	for _, p := range timeParams {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return params, entity.InvalidRequest("%s must be an RFC 3339 timestamp", p.name)
+		}
+		*p.dst = &t
+	}
+
+	boolParams := []struct {
+		name string
+		dst  *bool
+	}{
+		{"overdue", &params.Filter.Overdue},
+		{"due_today", &params.Filter.DueToday},
+	}
+	for _, p := range boolParams {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return params, entity.InvalidRequest("%s must be a boolean", p.name)
+		}
+		*p.dst = b
+	}
+
+	if v := q.Get("due_within_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			return params, entity.InvalidRequest("due_within_days must be a positive integer")
+		}
+		params.Filter.DueWithinDays = days
+	}
+
+	params.Filter.CustomFields = parseCustomFieldFilters(q)
+
+	params.Sort.Field = q.Get("sort")
+	switch strings.ToLower(q.Get("order")) {
+	case "", "asc":
+	case "desc":
+		params.Sort.Desc = true
+	default:
+		return params, entity.InvalidRequest("order must be asc or desc")
+	}
+
+	return params, nil
+}
+
+// parseCustomFieldFilters разбирает фильтры по пользовательским полям. Существование полей
+// и значения проверяются по схемам в usecase.
+func parseCustomFieldFilters(q url.Values) []entity.CustomFieldFilter {
+	var filters []entity.CustomFieldFilter
+	for name, values := range q {
+		rest, ok := strings.CutPrefix(name, entity.TaskSortCustomFieldPrefix)
+		if !ok {
+			continue
+		}
+		key, op, found := strings.Cut(rest, ".")
+		if !found {
+			op = entity.CustomFieldEq
+		}
+		for _, value := range values {
+			filters = append(filters, entity.CustomFieldFilter{Key: key, Op: op, Value: value})
+		}
+	}
+	// Порядок параметров в url.Values не определен, а фильтры входят в ключ кэша списка
+	sort.Slice(filters, func(i, j int) bool {
+		if filters[i].Key != filters[j].Key {
+			return filters[i].Key < filters[j].Key
+		}
+		if filters[i].Op != filters[j].Op {
+			return filters[i].Op < filters[j].Op
+		}
+		return filters[i].Value < filters[j].Value
+	})
+	return filters
+}
+
+// ParseTaskSearchParams разбирает параметры полнотекстового поиска: q, status, limit и offset.
+func ParseTaskSearchParams(q url.Values) entity.TaskSearchParams {
+	params := entity.TaskSearchParams{
+		Query:    q.Get("q"),
+		Statuses: parseStatuses(q),
+	}
+	params.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	params.Offset, _ = strconv.Atoi(q.Get("offset"))
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+	return params
+}
+
+// ParseCommentListParams разбирает параметры страницы комментариев: cursor и limit.
+func ParseCommentListParams(q url.Values) entity.CommentListParams {
+	params := entity.CommentListParams{Cursor: q.Get("cursor")}
+	params.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	return params
+}
+
+// ParseHistoryParams разбирает параметры страницы истории задачи: before и limit.
+func ParseHistoryParams(q url.Values) entity.TaskHistoryParams {
+	params := entity.TaskHistoryParams{}
+	params.Before, _ = strconv.ParseInt(q.Get("before"), 10, 64)
+	params.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	return params
+}
+
+// ParseBoardParams разбирает параметры доски: limit — число задач в каждой колонке.
+func ParseBoardParams(q url.Values) entity.BoardParams {
+	params := entity.BoardParams{}
+	params.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	return params
+}
+
+// ParsePreviewCount читает число экземпляров для предпросмотра серии из параметра count;
+// пределы проверяет usecase.
+func ParsePreviewCount(q url.Values) int {
+	count, _ := strconv.Atoi(q.Get("count"))
+	return count
+}
+
+// parseStatuses собирает статусы из повторяющегося параметра status и значений через запятую.
+func parseStatuses(q url.Values) []string {
+	return parseList(q, "status")
+}
+
+// parseList собирает значения повторяющегося параметра name, в том числе перечисленные через запятую.
+func parseList(q url.Values, name string) []string {
+	var values []string
+	for _, v := range q[name] {
+		for _, value := range strings.Split(v, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+)
+
+// Типы содержимого, которые принимает PATCH /tasks/{id}.
+const (
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeJSONPatch  = "application/json-patch+json"
+)
+
+// maxPatchSize ограничивает размер тела PATCH-запроса.
+const maxPatchSize = 1 << 20
+
+var ErrUnsupportedPatchType = entity.NewError(entity.KindUnsupportedMediaType, "unsupported_media_type",
+	"Content-Type must be "+ContentTypeMergePatch+" or "+ContentTypeJSONPatch)
+
+// DecodeTaskPatch читает тело PATCH-запроса и выбирает формат патча по Content-Type.
+func DecodeTaskPatch(w http.ResponseWriter, r *http.Request) (usecase.TaskPatch, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, ErrUnsupportedPatchType
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediaType {
+	case ContentTypeMergePatch:
+		return usecase.NewMergePatch(body)
+	case ContentTypeJSONPatch:
+		return usecase.NewJSONPatch(body)
+	default:
+		return nil, ErrUnsupportedPatchType
+	}
+}
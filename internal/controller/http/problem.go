@@ -0,0 +1,108 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// ContentTypeProblem — тип содержимого ответов об ошибках (RFC 7807).
+const ContentTypeProblem = "application/problem+json"
+
+// ProblemTypePrefix — префикс URI типа ошибки; за ним следует код ошибки.
+const ProblemTypePrefix = "urn:task-service:problem:"
+
+// ErrInvalidPayload возвращается, если тело запроса не удалось разобрать как JSON.
+var ErrInvalidPayload = entity.NewError(entity.KindInvalidArgument, "invalid_payload", "invalid request payload")
+
+// CodeInternal — код ошибок, не относящихся к доменным. Их текст пишется в журнал,
+// но не отдается клиенту.
+const CodeInternal = "internal"
+
+// Problem — описание ошибки в формате RFC 7807.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code — стабильный код ошибки, по которому клиент выбирает обработку,
+	// например task_not_found или version_conflict.
+	Code string `json:"code"`
+	// Errors перечисляет ошибки значений отдельных полей.
+	Errors entity.FieldErrors `json:"errors,omitempty"`
+	// Transition — отклоненный переход статуса с допустимыми статусами и невыполненными условиями.
+	Transition *usecase.TransitionError `json:"transition,omitempty"`
+	// Blocked — невыполненные задачи, блокирующие смену статуса.
+	Blocked *usecase.BlockedError `json:"blocked,omitempty"`
+}
+
+// problemStatuses сопоставляет категориям доменных ошибок HTTP-статусы.
+var problemStatuses = map[entity.ErrorKind]int{
+	entity.KindNotFound:             http.StatusNotFound,
+	entity.KindInvalidID:            http.StatusBadRequest,
+	entity.KindInvalidArgument:      http.StatusBadRequest,
+	entity.KindValidation:           http.StatusUnprocessableEntity,
+	entity.KindFailedPrecondition:   http.StatusUnprocessableEntity,
+	entity.KindConflict:             http.StatusConflict,
+	entity.KindAlreadyExists:        http.StatusConflict,
+	entity.KindVersionConflict:      http.StatusPreconditionFailed,
+	entity.KindForbidden:            http.StatusForbidden,
+	entity.KindUnauthenticated:      http.StatusUnauthorized,
+	entity.KindTooLarge:             http.StatusRequestEntityTooLarge,
+	entity.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	entity.KindRateLimited:          http.StatusTooManyRequests,
+}
+
+// NewProblem описывает ошибку err запроса r. Статус и код берутся из первой доменной
+// ошибки в цепочке err, текст ошибки становится detail. Ошибки без категории
+// считаются внутренними и отдаются со статусом 500 без подробностей.
+func NewProblem(r *http.Request, err error) Problem {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = &entity.Error{Kind: entity.KindTooLarge, Code: "request_too_large", Message: err.Error()}
+	}
+
+	var status int
+	domainErr, ok := entity.AsError(err)
+	if ok {
+		status, ok = problemStatuses[domainErr.Kind]
+	}
+	if !ok {
+		logger.Log.Error("Request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+		return Problem{
+			Type:     ProblemTypePrefix + CodeInternal,
+			Title:    http.StatusText(http.StatusInternalServerError),
+			Status:   http.StatusInternalServerError,
+			Detail:   "internal server error",
+			Instance: r.URL.Path,
+			Code:     CodeInternal,
+		}
+	}
+
+	problem := Problem{
+		Type:     ProblemTypePrefix + domainErr.Code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+		Code:     domainErr.Code,
+		Errors:   entity.Fields(err),
+	}
+	errors.As(err, &problem.Transition)
+	errors.As(err, &problem.Blocked)
+	return problem
+}
+
+// WriteProblem отвечает на запрос r ошибкой err в формате application/problem+json.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := NewProblem(r, err)
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
@@ -0,0 +1,185 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidProjectID возвращается, если ID проекта в пути не является UUID.
+var ErrInvalidProjectID = entity.InvalidID("project")
+
+// ProjectScope возвращает ID проекта из пути вложенного маршрута /projects/{pid}/tasks
+// или nil для маршрутов вне проекта.
+func ProjectScope(r *http.Request) (*uuid.UUID, error) {
+	pid := chi.URLParam(r, "pid")
+	if pid == "" {
+		return nil, nil
+	}
+	projectID, err := uuid.Parse(pid)
+	if err != nil {
+		return nil, ErrInvalidProjectID
+	}
+	return &projectID, nil
+}
+
+// ProjectHandler обрабатывает HTTP-запросы для работы с проектами.
+type ProjectHandler struct {
+	projectUseCase usecase.ProjectUseCase
+}
+
+// NewProjectHandler создает новый экземпляр ProjectHandler.
+func NewProjectHandler(projectUseCase usecase.ProjectUseCase) *ProjectHandler {
+	return &ProjectHandler{
+		projectUseCase: projectUseCase,
+	}
+}
+
+// CreateProject обрабатывает создание проекта.
+// @Summary      Создать проект
+// @Description  Создает новый проект для группировки задач
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project body     entity.Project true "Данные проекта"
+// @Success      201     {object} entity.Project
+// @Failure      400     {object} Problem "Неверный формат данных"
+// @Failure      409     {object} Problem "Проект с таким именем уже существует"
+// @Failure      422     {object} Problem "Ошибка валидации"
+// @Router       /v1/projects [post]
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var project entity.Project
+	if err := json.NewDecoder(r.Body).Decode(&project); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	createdProject, err := h.projectUseCase.Create(r.Context(), project)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdProject)
+}
+
+// ListProjects обрабатывает получение списка проектов.
+// @Summary      Список проектов
+// @Description  Возвращает все проекты, упорядоченные по имени
+// @Tags         projects
+// @Produce      json
+// @Success      200 {array}  entity.Project
+// @Failure      500 {object} Problem "Внутренняя ошибка сервера"
+// @Router       /v1/projects [get]
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := h.projectUseCase.List(r.Context())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(projects)
+}
+
+// GetProject обрабатывает получение проекта по ID.
+// @Summary      Получить проект
+// @Description  Возвращает проект по его ID
+// @Tags         projects
+// @Produce      json
+// @Param        pid path     string true "ID проекта"
+// @Success      200 {object} entity.Project
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Проект не найден"
+// @Router       /v1/projects/{pid} [get]
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "pid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("project"))
+		return
+	}
+
+	project, err := h.projectUseCase.Get(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(project)
+}
+
+// UpdateProject обрабатывает обновление проекта.
+// @Summary      Обновить проект
+// @Description  Обновляет имя и описание проекта
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        pid     path     string         true "ID проекта"
+// @Param        project body     entity.Project true "Обновленные данные проекта"
+// @Success      200     {object} entity.Project
+// @Failure      400     {object} Problem "Неверный формат ID или данных"
+// @Failure      403     {object} Problem "Недостаточно прав"
+// @Failure      404     {object} Problem "Проект не найден"
+// @Failure      409     {object} Problem "Проект с таким именем уже существует"
+// @Failure      422     {object} Problem "Ошибка валидации"
+// @Router       /v1/projects/{pid} [put]
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "pid"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidID("project"))
+		return
+	}
+
+	var project entity.Project
+	if err := json.NewDecoder(r.Body).Decode(&project); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+	project.ID = id
+
+	updatedProject, err := h.projectUseCase.Update(r.Context(), project)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updatedProject)
+}
+
+// DeleteProject обрабатывает удаление проекта.
+// @Summary      Удалить проект
+// @Description  Удаляет проект без задач, включая задачи в корзине
+// @Tags         projects
+// @Param        pid path string true "ID проекта"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Проект не найден"
+// @Failure      409 {object} Problem "В проекте есть задачи"
+// @Router       /v1/projects/{pid} [delete]
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "pid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("project"))
+		return
+	}
+
+	if err := h.projectUseCase.Delete(r.Context(), id); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
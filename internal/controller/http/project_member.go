@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SetProjectMemberRequest — тело запроса на назначение роли в проекте.
+type SetProjectMemberRequest struct {
+	Role entity.ProjectRole `json:"role" enums:"viewer,member,maintainer,owner"`
+}
+
+// ListMembers обрабатывает получение участников проекта.
+// @Summary      Участники проекта
+// @Description  Возвращает пользователей с ролями в проекте; доступно владельцу проекта
+// @Tags         projects
+// @Produce      json
+// @Param        pid path     string true "ID проекта"
+// @Success      200 {array}  entity.ProjectMember
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Проект не найден"
+// @Router       /v1/projects/{pid}/members [get]
+func (h *ProjectHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "pid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("project"))
+		return
+	}
+
+	members, err := h.projectUseCase.ListMembers(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(members)
+}
+
+// SetMember обрабатывает назначение роли в проекте.
+// @Summary      Назначить роль
+// @Description  Назначает пользователю роль в проекте или меняет её; проект не может остаться без владельца
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        pid     path     string                  true "ID проекта"
+// @Param        subject path     string                  true "Пользователь (subject токена)"
+// @Param        request body     SetProjectMemberRequest true "Роль"
+// @Success      200     {object} entity.ProjectMember
+// @Failure      400     {object} Problem "Неверный формат ID или данных"
+// @Failure      403     {object} Problem "Недостаточно прав"
+// @Failure      404     {object} Problem "Проект не найден"
+// @Failure      409     {object} Problem "У проекта не останется владельца"
+// @Failure      422     {object} Problem "Ошибка валидации"
+// @Router       /v1/projects/{pid}/members/{subject} [put]
+func (h *ProjectHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "pid"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidID("project"))
+		return
+	}
+	subject, err := url.PathUnescape(chi.URLParam(r, "subject"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidRequest("invalid subject format"))
+		return
+	}
+
+	var req SetProjectMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	member, err := h.projectUseCase.SetMember(r.Context(), entity.ProjectMember{ProjectID: id, Subject: subject, Role: req.Role})
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(member)
+}
+
+// RemoveMember обрабатывает снятие роли в проекте.
+// @Summary      Снять роль
+// @Description  Удаляет пользователя из участников проекта; проект не может остаться без владельца
+// @Tags         projects
+// @Param        pid     path string true "ID проекта"
+// @Param        subject path string true "Пользователь (subject токена)"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Проект или участник не найден"
+// @Failure      409 {object} Problem "У проекта не останется владельца"
+// @Router       /v1/projects/{pid}/members/{subject} [delete]
+func (h *ProjectHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "pid")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("project"))
+		return
+	}
+	subject, err := url.PathUnescape(chi.URLParam(r, "subject"))
+	if err != nil {
+		WriteProblem(w, r, entity.InvalidRequest("invalid subject format"))
+		return
+	}
+
+	if err := h.projectUseCase.RemoveMember(r.Context(), id, subject); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
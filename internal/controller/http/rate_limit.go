@@ -0,0 +1,83 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// RateLimit ограничивает частоту запросов к группе маршрутов group по лимитам политики
+// для метода запроса. Клиент определяется по ключу API или пользователю, поэтому middleware
+// подключается после Authenticate; без аутентификации — по IP-адресу. Ответы содержат
+// заголовки RateLimit-*, отклоненные запросы получают 429 с Retry-After.
+func RateLimit(limiter usecase.RateLimitUseCase, group string) func(http.Handler) http.Handler {
+	return rateLimit(limiter, group, func(r *http.Request) string {
+		return usecase.RateLimitIdentity(r.Context(), ClientIP(r))
+	})
+}
+
+// RateLimitClientIP ограничивает частоту запросов с одного IP-адреса по лимиту группы
+// entity.RateLimitGroupClientIP. Middleware подключается до Authenticate, чтобы перебор
+// ключей API и токенов тоже расходовал лимит.
+func RateLimitClientIP(limiter usecase.RateLimitUseCase) func(http.Handler) http.Handler {
+	return rateLimit(limiter, entity.RateLimitGroupClientIP, func(r *http.Request) string {
+		return "ip:" + ClientIP(r)
+	})
+}
+
+func rateLimit(limiter usecase.RateLimitUseCase, group string, identify func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := limiter.Allow(r.Context(), identify(r), group, r.Method)
+			if err != nil {
+				// Ограничитель не должен делать сервис недоступным
+				logger.Log.Error("Failed to check rate limit", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if result.Limit.Enabled() {
+				SetRateLimitHeaders(w.Header(), result)
+			}
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(Seconds(result.RetryAfter)))
+				WriteProblem(w, r, usecase.ErrRateLimited)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetRateLimitHeaders записывает состояние лимита в заголовки RateLimit-Limit,
+// RateLimit-Remaining, RateLimit-Reset и RateLimit-Policy.
+func SetRateLimitHeaders(h http.Header, result entity.RateLimitResult) {
+	h.Set("RateLimit-Limit", strconv.Itoa(result.Limit.Requests))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(Seconds(result.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", result.Limit.Requests, Seconds(result.Limit.Window)))
+}
+
+// Seconds округляет длительность вверх до целых секунд, как того требуют заголовки
+// Retry-After и RateLimit-*.
+func Seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// ClientIP возвращает IP-адрес клиента из адреса соединения. За обратным прокси адрес
+// соединения подменяется адресом из X-Forwarded-For middleware RealIP, если прокси
+// разрешено доверять (TRUST_PROXY_HEADERS).
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/repo/memory"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+)
+
+type rejectingVerifier struct{}
+
+func (rejectingVerifier) Verify(ctx context.Context, token string) (entity.Principal, error) {
+	return entity.Principal{}, errors.New("unknown token")
+}
+
+func TestRateLimitClientIPBeforeAuthenticate(t *testing.T) {
+	policy := entity.RateLimitPolicy{Groups: map[string]entity.RateLimitRule{
+		entity.RateLimitGroupClientIP: {RateLimit: entity.RateLimit{Requests: 3, Window: time.Minute}},
+	}}
+	limiter := usecase.NewRateLimitUseCase(memory.NewRateLimiter(), memory.NewRateLimiter(), policy)
+	handler := RateLimitClientIP(limiter)(Authenticate(rejectingVerifier{})(http.NotFoundHandler()))
+
+	tests := []struct {
+		remoteAddr string
+		want       int
+	}{
+		{remoteAddr: "192.0.2.1:1000", want: http.StatusUnauthorized},
+		{remoteAddr: "192.0.2.1:1001", want: http.StatusUnauthorized},
+		{remoteAddr: "192.0.2.1:1002", want: http.StatusUnauthorized},
+		{remoteAddr: "192.0.2.1:1003", want: http.StatusTooManyRequests},
+		{remoteAddr: "192.0.2.2:1000", want: http.StatusUnauthorized},
+	}
+	for i, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+		req.RemoteAddr = tt.remoteAddr
+		req.Header.Set("Authorization", "Bearer guess-"+tt.remoteAddr)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("request %d from %s: status %d, want %d", i, tt.remoteAddr, rec.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SetRecurrenceRequest — тело запроса на задание правила повторения задачи.
+type SetRecurrenceRequest struct {
+	// RRule — правило в подмножестве RFC 5545, например FREQ=WEEKLY;BYDAY=MO.
+	RRule string `json:"rrule"`
+	// StartsAt — начало серии; по умолчанию start_at задачи или текущий момент.
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	// Timezone — часовой пояс IANA, по умолчанию UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Recurrence возвращает серию, описанную запросом.
+func (req SetRecurrenceRequest) Recurrence() entity.Recurrence {
+	recurrence := entity.Recurrence{RRule: req.RRule, Timezone: req.Timezone}
+	if req.StartsAt != nil {
+		recurrence.StartsAt = *req.StartsAt
+	}
+	return recurrence
+}
+
+// RecurrenceHandler обрабатывает HTTP-запросы для работы с сериями повторяющихся задач.
+type RecurrenceHandler struct {
+	recurrenceUseCase usecase.RecurrenceUseCase
+}
+
+// NewRecurrenceHandler создает новый экземпляр RecurrenceHandler.
+func NewRecurrenceHandler(recurrenceUseCase usecase.RecurrenceUseCase) *RecurrenceHandler {
+	return &RecurrenceHandler{
+		recurrenceUseCase: recurrenceUseCase,
+	}
+}
+
+// SetRecurrence обрабатывает задание правила повторения.
+// @Summary      Задать повторение
+// @Description  Делает задачу шаблоном серии: по правилу RRULE (FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH) создаются её копии. Заменяет существующее правило
+// @Tags         recurrence
+// @Accept       json
+// @Produce      json
+// @Param        id         path     string               true "ID задачи-шаблона"
+// @Param        recurrence body     SetRecurrenceRequest true "Правило повторения"
+// @Success      200 {object} entity.Recurrence
+// @Failure      400 {object} Problem "Неверный формат ID или данных"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Failure      422 {object} Problem "Неверное правило или часовой пояс"
+// @Router       /v1/tasks/{id}/recurrence [put]
+func (h *RecurrenceHandler) SetRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	var req SetRecurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	recurrence, err := h.recurrenceUseCase.Set(r.Context(), id, req.Recurrence())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recurrence)
+}
+
+// GetRecurrence обрабатывает получение серии задачи.
+// @Summary      Повторение задачи
+// @Description  Возвращает правило повторения задачи-шаблона и время следующего экземпляра
+// @Tags         recurrence
+// @Produce      json
+// @Param        id path string true "ID задачи-шаблона"
+// @Success      200 {object} entity.Recurrence
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Повторение не задано"
+// @Router       /v1/tasks/{id}/recurrence [get]
+func (h *RecurrenceHandler) GetRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	recurrence, err := h.recurrenceUseCase.Get(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recurrence)
+}
+
+// DeleteRecurrence обрабатывает удаление серии.
+// @Summary      Отменить повторение
+// @Description  Удаляет правило повторения; уже созданные экземпляры и сам шаблон сохраняются
+// @Tags         recurrence
+// @Param        id path string true "ID задачи-шаблона"
+// @Success      204
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Повторение не задано"
+// @Router       /v1/tasks/{id}/recurrence [delete]
+func (h *RecurrenceHandler) DeleteRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	if err := h.recurrenceUseCase.Delete(r.Context(), id); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewRecurrence обрабатывает предпросмотр серии.
+// @Summary      Ближайшие экземпляры
+// @Description  Возвращает моменты ближайших экземпляров серии, не создавая задач
+// @Tags         recurrence
+// @Produce      json
+// @Param        id    path  string true  "ID задачи-шаблона"
+// @Param        count query int    false "Количество экземпляров (до 100)" default(10)
+// @Success      200 {object} entity.RecurrencePreview
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Повторение не задано"
+// @Router       /v1/tasks/{id}/recurrence/preview [get]
+func (h *RecurrenceHandler) PreviewRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	preview, err := h.recurrenceUseCase.Preview(r.Context(), id, ParsePreviewCount(r.URL.Query()))
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+// PauseRecurrence обрабатывает приостановку серии.
+// @Summary      Приостановить повторение
+// @Description  Приостанавливает создание экземпляров серии
+// @Tags         recurrence
+// @Produce      json
+// @Param        id path string true "ID задачи-шаблона"
+// @Success      200 {object} entity.Recurrence
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Повторение не задано"
+// @Router       /v1/tasks/{id}/recurrence/pause [post]
+func (h *RecurrenceHandler) PauseRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	recurrence, err := h.recurrenceUseCase.Pause(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recurrence)
+}
+
+// ResumeRecurrence обрабатывает возобновление серии.
+// @Summary      Возобновить повторение
+// @Description  Возобновляет серию с первого экземпляра после текущего момента; пропущенные за паузу экземпляры не создаются
+// @Tags         recurrence
+// @Produce      json
+// @Param        id path string true "ID задачи-шаблона"
+// @Success      200 {object} entity.Recurrence
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      404 {object} Problem "Повторение не задано"
+// @Router       /v1/tasks/{id}/recurrence/resume [post]
+func (h *RecurrenceHandler) ResumeRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	recurrence, err := h.recurrenceUseCase.Resume(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recurrence)
+}
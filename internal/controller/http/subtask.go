@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// MoveTaskRequest — тело запроса на перемещение задачи. Пустой parent_id
+// делает задачу задачей верхнего уровня.
+type MoveTaskRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+// ListChildren обрабатывает получение непосредственных подзадач.
+// @Summary      Подзадачи
+// @Description  Возвращает непосредственные подзадачи в порядке создания
+// @Tags         tasks
+// @Produce      json
+// @Param        id  path     string true "ID задачи"
+// @Success      200 {array}  entity.Task
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Router       /v1/tasks/{id}/children [get]
+func (h *TaskHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	children, err := h.taskUseCase.Children(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(children)
+}
+
+// GetSubtree обрабатывает получение всех вложенных задач.
+// @Summary      Дерево подзадач
+// @Description  Возвращает задачу, всех её потомков в порядке обхода в глубину и прогресс выполнения
+// @Tags         tasks
+// @Produce      json
+// @Param        id  path     string true "ID задачи"
+// @Success      200 {object} entity.TaskSubtree
+// @Failure      400 {object} Problem "Неверный формат ID"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Router       /v1/tasks/{id}/subtree [get]
+func (h *TaskHandler) GetSubtree(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	subtree, err := h.taskUseCase.Subtree(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subtree)
+}
+
+// MoveTask обрабатывает перемещение задачи вместе с подзадачами.
+// @Summary      Переместить задачу
+// @Description  Переносит задачу со всеми подзадачами под другого родителя
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id       path     string          true  "ID задачи"
+// @Param        request  body     MoveTaskRequest true  "Новый родитель"
+// @Param        If-Match header   string          false "ETag задачи"
+// @Success      200 {object} entity.Task
+// @Failure      400 {object} Problem "Неверный формат ID или данных"
+// @Failure      404 {object} Problem "Задача не найдена"
+// @Failure      403 {object} Problem "Недостаточно прав"
+// @Failure      412 {object} Problem "Задача изменена другим клиентом"
+// @Failure      422 {object} Problem "Недопустимый родитель"
+// @Router       /v1/tasks/{id}/move [post]
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	version, err := ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	var req MoveTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.Error("Failed to decode request body", "error", err)
+		WriteProblem(w, r, ErrInvalidPayload)
+		return
+	}
+
+	movedTask, err := h.taskUseCase.Move(r.Context(), id, req.ParentID, version)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(movedTask.Version))
+	json.NewEncoder(w).Encode(movedTask)
+}
@@ -2,9 +2,8 @@ package http
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/KarpovAlexandrGo/task-service/internal/entity"
 	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
@@ -25,53 +24,53 @@ func NewTaskHandler(taskUseCase usecase.TaskUseCase) *TaskHandler {
 	}
 }
 
-// RegisterRoutes регистрирует маршруты для обработки задач.
-func (h *TaskHandler) RegisterRoutes(r chi.Router) {
-	r.Route("/v1/tasks", func(r chi.Router) {
-		r.Post("/", h.CreateTask)
-		r.Get("/", h.ListTasks)
-		r.Route("/{id}", func(r chi.Router) {
-			r.Get("/", h.GetTask)
-			r.Put("/", h.UpdateTask)
-			r.Delete("/", h.DeleteTask)
-		})
-	})
-}
-
 // CreateTask обрабатывает создание новой задачи.
 // @Summary      Создать задачу
 // @Description  Создает новую задачу в системе
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
+// @Param        pid  path     string      false "ID проекта (для вложенного маршрута)"
 // @Param        task body     entity.Task true "Данные задачи"
 // @Success      201  {object} entity.Task
-// @Failure      400  {string} string "Неверный формат данных"
-// @Failure      422  {string} string "Ошибка валидации"
-// @Failure      500  {string} string "Внутренняя ошибка сервера"
+// @Failure      400  {object} Problem "Неверный формат данных"
+// @Failure      403  {object} Problem "Недостаточно прав"
+// @Failure      404  {object} Problem "Проект не найден"
+// @Failure      422  {object} Problem "Ошибка валидации"
+// @Failure      500  {object} Problem "Внутренняя ошибка сервера"
 // @Router       /v1/tasks [post]
+// @Router       /v1/projects/{pid}/tasks [post]
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	projectID, err := ProjectScope(r)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
 	var task entity.Task
 	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
 		logger.Log.Error("Failed to decode request body", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteProblem(w, r, ErrInvalidPayload)
 		return
 	}
+	if projectID != nil {
+		task.ProjectID = projectID
+	}
 
 	if err := validateTask(&task); err != nil {
 		logger.Log.Warn("Task validation failed", "error", err)
-		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		WriteProblem(w, r, err)
 		return
 	}
 
 	createdTask, err := h.taskUseCase.Create(r.Context(), task)
 	if err != nil {
-		logger.Log.Error("Failed to create task", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		WriteProblem(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(createdTask.Version))
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(createdTask)
 }
@@ -84,64 +83,118 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path     string true "ID задачи"
 // @Success      200  {object} entity.Task
-// @Failure      400  {string} string "Неверный формат ID"
-// @Failure      404  {string} string "Задача не найдена"
+// @Failure      400  {object} Problem "Неверный формат ID"
+// @Failure      403  {object} Problem "Недостаточно прав"
+// @Failure      404  {object} Problem "Задача не найдена"
 // @Router       /v1/tasks/{id} [get]
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if _, err := uuid.Parse(id); err != nil {
 		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
-		http.Error(w, "Invalid task ID format", http.StatusBadRequest)
+		WriteProblem(w, r, entity.InvalidID("task"))
 		return
 	}
 
 	task, err := h.taskUseCase.Get(r.Context(), id)
 	if err != nil {
-		if errors.Is(err, usecase.ErrTaskNotFound) {
-			logger.Log.Warn("Task not found", "id", id)
-			http.Error(w, "Task not found", http.StatusNotFound)
-		} else {
-			logger.Log.Error("Failed to get task", "id", id, "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-		}
+		WriteProblem(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(task.Version))
 	json.NewEncoder(w).Encode(task)
 }
 
 // ListTasks обрабатывает получение списка задач.
 // @Summary      Список задач
-// @Description  Возвращает список задач с пагинацией
+// @Description  Возвращает список задач с фильтрацией, сортировкой и курсорной пагинацией
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
-// @Param        page   query    int false "Номер страницы" default(1)
-// @Param        limit  query    int false "Количество элементов на странице" default(20)
-// @Success      200    {array}  entity.Task
-// @Failure      500    {string} string "Внутренняя ошибка сервера"
+// @Param        pid          path     string   false "ID проекта (для вложенного маршрута)"
+// @Param        cursor       query    string   false "Курсор следующей страницы"
+// @Param        limit        query    int      false "Количество элементов на странице" default(20)
+// @Param        status       query    []string false "Статусы задач" collectionFormat(multi)
+// @Param        created_from query    string   false "Создана не раньше (RFC 3339)"
+// @Param        created_to   query    string   false "Создана раньше (RFC 3339)"
+// @Param        updated_from query    string   false "Обновлена не раньше (RFC 3339)"
+// @Param        updated_to   query    string   false "Обновлена раньше (RFC 3339)"
+// @Param        title        query    string   false "Подстрока названия"
+// @Param        labels_any   query    []string false "Хотя бы одна из меток" collectionFormat(multi)
+// @Param        labels_all   query    []string false "Все перечисленные метки" collectionFormat(multi)
+// @Param        assignee_id  query    string   false "Исполнитель задачи"
+// @Param        created_by   query    string   false "Автор задачи"
+// @Param        due_from     query    string   false "Срок не раньше (RFC 3339)"
+// @Param        due_to       query    string   false "Срок раньше (RFC 3339)"
+// @Param        overdue      query    bool     false "Только просроченные невыполненные задачи"
+// @Param        due_today    query    bool     false "Только задачи со сроком сегодня"
+// @Param        due_within_days query int      false "Только задачи со сроком в ближайшие N суток"
+// @Param        cf.{key}     query    string   false "Значение пользовательского поля key; для сравнения — cf.{key}.gt, .gte, .lt, .lte"
+// @Param        sort         query    string   false "Поле сортировки: created_at, updated_at, title, due_at, priority, rank или cf.{key}"
+// @Param        order        query    string   false "Направление сортировки" Enums(asc, desc)
+// @Success      200    {object} entity.TaskPage
+// @Failure      400    {object} Problem "Неверные параметры запроса"
+// @Failure      403    {object} Problem "Недостаточно прав"
+// @Failure      404    {object} Problem "Проект не найден"
+// @Failure      500    {object} Problem "Внутренняя ошибка сервера"
 // @Router       /v1/tasks [get]
+// @Router       /v1/projects/{pid}/tasks [get]
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	params, err := ParseTaskListParams(r.URL.Query())
+	if err != nil {
+		logger.Log.Warn("Invalid list parameters", "error", err)
+		WriteProblem(w, r, err)
+		return
+	}
+	if params.Filter.ProjectID, err = ProjectScope(r); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
 
-	if page < 1 {
-		page = 1
+	page, err := h.taskUseCase.List(r.Context(), params)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
 	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// SearchTasks обрабатывает полнотекстовый поиск задач.
+// @Summary      Поиск задач
+// @Description  Ищет задачи по словам в названии и описании, результаты упорядочены по релевантности
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        pid    path     string   false "ID проекта (для вложенного маршрута)"
+// @Param        q      query    string   true  "Поисковый запрос"
+// @Param        status query    []string false "Статусы задач" collectionFormat(multi)
+// @Param        limit  query    int      false "Количество результатов" default(20)
+// @Param        offset query    int      false "Смещение" default(0)
+// @Success      200    {array}  entity.TaskSearchResult
+// @Failure      400    {object} Problem "Пустой поисковый запрос"
+// @Failure      403    {object} Problem "Недостаточно прав"
+// @Failure      500    {object} Problem "Внутренняя ошибка сервера"
+// @Router       /v1/tasks/search [get]
+// @Router       /v1/projects/{pid}/tasks/search [get]
+func (h *TaskHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	params := ParseTaskSearchParams(r.URL.Query())
+	var err error
+	if params.ProjectID, err = ProjectScope(r); err != nil {
+		WriteProblem(w, r, err)
+		return
 	}
 
-	tasks, err := h.taskUseCase.List(r.Context(), page, limit)
+	results, err := h.taskUseCase.Search(r.Context(), params)
 	if err != nil {
-		logger.Log.Error("Failed to list tasks", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		WriteProblem(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tasks)
+	json.NewEncoder(w).Encode(results)
 }
 
 // UpdateTask обрабатывает обновление задачи.
@@ -151,93 +204,259 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path     string true "ID задачи"
-// @Param        task body     entity.Task true "Обновленные данные задачи"
+// @Param        task     body     entity.Task true  "Обновленные данные задачи"
+// @Param        If-Match header   string      false "ETag задачи"
+// @Param        ignore_blockers query bool    false "Сменить статус, несмотря на невыполненные блокирующие задачи"
 // @Success      200  {object} entity.Task
-// @Failure      400  {string} string "Неверный формат ID или данных"
-// @Failure      404  {string} string "Задача не найдена"
-// @Failure      422  {string} string "Ошибка валидации"
+// @Failure      400  {object} Problem "Неверный формат ID или данных"
+// @Failure      404  {object} Problem "Задача не найдена"
+// @Failure      409  {object} Problem "Задача заблокирована"
+// @Failure      403  {object} Problem "Недостаточно прав"
+// @Failure      412  {object} Problem "Задача изменена другим клиентом"
+// @Failure      422  {object} Problem "Ошибка валидации"
 // @Router       /v1/tasks/{id} [put]
 func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if _, err := uuid.Parse(id); err != nil {
 		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
-		http.Error(w, "Invalid task ID format", http.StatusBadRequest)
+		WriteProblem(w, r, entity.InvalidID("task"))
 		return
 	}
 
 	var task entity.Task
 	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
 		logger.Log.Error("Failed to decode request body", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteProblem(w, r, ErrInvalidPayload)
 		return
 	}
 	task.ID, _ = uuid.Parse(id) // Устанавливаем ID из пути
 
+	var err error
+	if task.Version, err = ParseIfMatch(r.Header.Get("If-Match")); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	opts, err := ParseUpdateOptions(r.URL.Query())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
 	if err := validateTask(&task); err != nil {
 		logger.Log.Warn("Task validation failed", "error", err)
-		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		WriteProblem(w, r, err)
 		return
 	}
 
-	updatedTask, err := h.taskUseCase.Update(r.Context(), task)
+	updatedTask, err := h.taskUseCase.Update(r.Context(), task, opts...)
 	if err != nil {
-		if errors.Is(err, usecase.ErrTaskNotFound) {
-			logger.Log.Warn("Task not found for update", "id", id)
-			http.Error(w, "Task not found", http.StatusNotFound)
-		} else {
-			logger.Log.Error("Failed to update task", "id", id, "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-		}
+		WriteProblem(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(updatedTask.Version))
 	json.NewEncoder(w).Encode(updatedTask)
 }
 
+// PatchTask обрабатывает частичное обновление задачи.
+// @Summary      Частично обновить задачу
+// @Description  Применяет к задаче JSON Merge Patch (RFC 7396) или JSON Patch (RFC 6902)
+// @Tags         tasks
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id    path     string true "ID задачи"
+// @Param        patch    body     object true  "Патч"
+// @Param        If-Match header   string false "ETag задачи"
+// @Param        ignore_blockers query bool false "Сменить статус, несмотря на невыполненные блокирующие задачи"
+// @Success      200   {object} entity.Task
+// @Failure      400   {object} Problem "Неверный формат ID или патча"
+// @Failure      404   {object} Problem "Задача не найдена"
+// @Failure      409   {object} Problem "Задача заблокирована"
+// @Failure      403   {object} Problem "Недостаточно прав"
+// @Failure      412   {object} Problem "Задача изменена другим клиентом"
+// @Failure      415   {object} Problem "Неподдерживаемый тип патча"
+// @Failure      422   {object} Problem "Ошибка валидации"
+// @Router       /v1/tasks/{id} [patch]
+func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	version, err := ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	opts, err := ParseUpdateOptions(r.URL.Query())
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	patch, err := DecodeTaskPatch(w, r)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	patchedTask, err := h.taskUseCase.Patch(r.Context(), id, version, patch, opts...)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(patchedTask.Version))
+	json.NewEncoder(w).Encode(patchedTask)
+}
+
 // DeleteTask обрабатывает удаление задачи.
 // @Summary      Удалить задачу
-// @Description  Удаляет задачу по её ID
+// @Description  Перемещает задачу в корзину
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
-// @Param        id   path     string true "ID задачи"
+// @Param        id       path     string true  "ID задачи"
+// @Param        If-Match header   string false "ETag задачи"
 // @Success      204
-// @Failure      400  {string} string "Неверный формат ID"
-// @Failure      404  {string} string "Задача не найдена"
+// @Failure      400  {object} Problem "Неверный формат ID"
+// @Failure      404  {object} Problem "Задача не найдена"
+// @Failure      403  {object} Problem "Недостаточно прав"
+// @Failure      412  {object} Problem "Задача изменена другим клиентом"
 // @Router       /v1/tasks/{id} [delete]
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if _, err := uuid.Parse(id); err != nil {
 		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
-		http.Error(w, "Invalid task ID format", http.StatusBadRequest)
+		WriteProblem(w, r, entity.InvalidID("task"))
 		return
 	}
 
-	if err := h.taskUseCase.Delete(r.Context(), id); err != nil {
-		if errors.Is(err, usecase.ErrTaskNotFound) {
-			logger.Log.Warn("Task not found for deletion", "id", id)
-			http.Error(w, "Task not found", http.StatusNotFound)
-		} else {
-			logger.Log.Error("Failed to delete task", "id", id, "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-		}
+	version, err := ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	if err := h.taskUseCase.Delete(r.Context(), id, version); err != nil {
+		WriteProblem(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListTrash обрабатывает получение списка удаленных задач.
+// @Summary      Корзина
+// @Description  Возвращает задачи из корзины; параметры те же, что у списка задач
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        cursor query    string false "Курсор следующей страницы"
+// @Param        limit  query    int    false "Количество элементов на странице" default(20)
+// @Success      200    {object} entity.TaskPage
+// @Failure      400    {object} Problem "Неверные параметры запроса"
+// @Failure      500    {object} Problem "Внутренняя ошибка сервера"
+// @Router       /v1/tasks/trash [get]
+func (h *TaskHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	params, err := ParseTaskListParams(r.URL.Query())
+	if err != nil {
+		logger.Log.Warn("Invalid list parameters", "error", err)
+		WriteProblem(w, r, err)
+		return
+	}
+
+	page, err := h.taskUseCase.ListTrash(r.Context(), params)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// RestoreTask обрабатывает восстановление задачи из корзины.
+// @Summary      Восстановить задачу
+// @Description  Возвращает задачу из корзины
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id   path     string true "ID задачи"
+// @Success      200  {object} entity.Task
+// @Failure      400  {object} Problem "Неверный формат ID"
+// @Failure      403  {object} Problem "Недостаточно прав"
+// @Failure      404  {object} Problem "Задача не найдена в корзине"
+// @Router       /v1/tasks/{id}/restore [post]
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	task, err := h.taskUseCase.Restore(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(task.Version))
+	json.NewEncoder(w).Encode(task)
+}
+
+// ListTransitions обрабатывает получение статусов, в которые можно перевести задачу.
+// @Summary      Доступные переходы
+// @Description  Возвращает статусы, в которые процесс позволяет перевести задачу, и невыполненные условия переходов
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        id   path     string true "ID задачи"
+// @Success      200  {array}  entity.StatusTransition
+// @Failure      400  {object} Problem "Неверный формат ID"
+// @Failure      403  {object} Problem "Недостаточно прав"
+// @Failure      404  {object} Problem "Задача не найдена"
+// @Router       /v1/tasks/{id}/transitions [get]
+func (h *TaskHandler) ListTransitions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Log.Warn("Invalid task ID format", "id", id, "error", err)
+		WriteProblem(w, r, entity.InvalidID("task"))
+		return
+	}
+
+	transitions, err := h.taskUseCase.NextStatuses(r.Context(), id)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transitions)
+}
+
 // validateTask проверяет валидность данных задачи.
 func validateTask(task *entity.Task) error {
+	var errs entity.FieldErrors
 	if task.Title == "" {
-		return errors.New("title is required")
-	}
-	if len(task.Title) > 255 {
-		return errors.New("title must be less than 255 characters")
+		errs.Add("title", "is required")
+	} else if len(task.Title) > 255 {
+		errs.Add("title", "must be less than 255 characters")
 	}
 	if task.Status == "" {
-		return errors.New("status is required")
+		errs.Add("status", "is required")
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", usecase.ErrInvalidTask, errs)
 	}
 	return nil
 }
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix начинает секрет каждого ключа API и отличает ключи от JWT.
+const APIKeyPrefix = "tsk_"
+
+// APIKeyHintLength — число начальных символов секрета, которые хранятся открыто,
+// чтобы ключ можно было узнать в списке.
+const APIKeyHintLength = len(APIKeyPrefix) + 6
+
+// APIKeySubjectPrefix начинает идентификатор автора запросов, выполненных с ключом API.
+const APIKeySubjectPrefix = "api-key:"
+
+// APIKey — ключ API для неинтерактивного доступа: CI, периодических задач и других сервисов.
+// Хранится только хэш секрета.
+type APIKey struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Hint — начало секрета, по которому ключ можно узнать.
+	Hint   string   `json:"hint"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt — срок действия ключа; nil — бессрочный ключ.
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IssuedAPIKey — только что выпущенный ключ вместе с секретом. Секрет возвращается
+// один раз и больше нигде не хранится.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+func (k *APIKey) Validate() error {
+	var errs FieldErrors
+	if k.Name == "" {
+		errs.Add("name", "cannot be empty")
+	}
+	if len(k.Name) > 100 {
+		errs.Add("name", "must be less than 100 characters")
+	}
+	if len(k.Scopes) == 0 {
+		errs.Add("scopes", "at least one scope is required")
+	}
+	for _, scope := range k.Scopes {
+		if !slices.Contains(Scopes, scope) {
+			errs.Add("scopes", "unknown scope %q", scope)
+		}
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		errs.Add("expires_at", "must be in the future")
+	}
+	return errs.Err()
+}
+
+// Subject возвращает идентификатор автора запросов, выполненных с ключом.
+func (k *APIKey) Subject() string {
+	return APIKeySubjectPrefix + k.ID.String()
+}
+
+// Active сообщает, действует ли ключ в момент now.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
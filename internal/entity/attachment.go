@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxAttachmentSize — ограничение размера вложения, если оно не задано в конфигурации.
+const DefaultMaxAttachmentSize = 25 << 20
+
+// Attachment — метаданные файла, прикрепленного к задаче. Содержимое файла
+// хранится в BlobStore под ключом StorageKey.
+type Attachment struct {
+	ID       uuid.UUID `json:"id"`
+	TaskID   uuid.UUID `json:"task_id"`
+	FileName string    `json:"file_name"`
+	// ContentType определяется по содержимому файла, а не по заголовкам клиента.
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// Checksum — SHA-256 содержимого в шестнадцатеричном виде.
+	Checksum   string    `json:"checksum"`
+	StorageKey string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SanitizeFileName оставляет от имени файла, переданного клиентом, только базовое имя
+// без управляющих символов.
+func SanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	return strings.TrimSpace(name)
+}
+
+func (a *Attachment) Validate() error {
+	var errs FieldErrors
+	if a.FileName == "" {
+		errs.Add("file_name", "cannot be empty")
+	}
+	if len(a.FileName) > 255 {
+		errs.Add("file_name", "must be at most 255 characters")
+	}
+	return errs.Err()
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "github.com/google/uuid"
+
+// TaskRef ссылается на задачу и, при необходимости, на её ожидаемую версию.
+type TaskRef struct {
+	ID      uuid.UUID `json:"id"`
+	Version int64     `json:"version,omitempty"`
+}
+
+// BatchItemResult — результат одной операции пакета. Index соответствует позиции во входном списке.
+type BatchItemResult struct {
+	Index int       `json:"index"`
+	ID    uuid.UUID `json:"id"`
+	Task  *Task     `json:"task,omitempty"`
+	Error string    `json:"error,omitempty"`
+	// Code — стабильный код ошибки операции, если ошибка доменная.
+	Code string `json:"code,omitempty"`
+	// Fields — ошибки по полям, если операция не прошла проверку.
+	Fields FieldErrors `json:"fields,omitempty"`
+}
+
+// Fail отмечает операцию как неудачную с ошибкой err.
+func (r *BatchItemResult) Fail(err error) {
+	r.Error = err.Error()
+	if domainErr, ok := AsError(err); ok {
+		r.Code = domainErr.Code
+	}
+	r.Fields = Fields(err)
+}
+
+// BatchResult — итог пакетной операции. Committed равен false, если атомарный
+// пакет был отменен из-за ошибки хотя бы в одной операции.
+type BatchResult struct {
+	Committed bool              `json:"committed"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Items     []BatchItemResult `json:"items"`
+}
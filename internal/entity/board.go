@@ -0,0 +1,36 @@
+package entity
+
+import "github.com/google/uuid"
+
+// BoardPosition задает место задачи на доске: колонку и соседей по ней. Задача встает
+// сразу после AfterID и перед BeforeID; если соседи не заданы, она встает в конец колонки.
+type BoardPosition struct {
+	// Status — колонка доски; пустое значение оставляет задачу в текущей колонке.
+	Status string `json:"status,omitempty"`
+	// AfterID — задача колонки, за которой встает перемещаемая задача.
+	AfterID *uuid.UUID `json:"after_id,omitempty"`
+	// BeforeID — задача колонки, перед которой встает перемещаемая задача.
+	BeforeID *uuid.UUID `json:"before_id,omitempty"`
+}
+
+// BoardParams описывает параметры выборки доски.
+type BoardParams struct {
+	// ProjectID ограничивает доску задачами проекта.
+	ProjectID *uuid.UUID
+	// Limit — число задач в каждой колонке.
+	Limit int
+}
+
+// BoardColumn — колонка доски: задачи одного статуса в порядке рангов. Продолжение
+// колонки выдает список задач с фильтром по статусу, сортировкой rank и курсором NextCursor.
+type BoardColumn struct {
+	Status     string `json:"status"`
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Board — доска задач с колонками в порядке статусов процесса работы.
+type Board struct {
+	Columns []BoardColumn `json:"columns"`
+}
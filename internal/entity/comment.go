@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCommentLength — максимальная длина текста комментария в символах.
+const MaxCommentLength = 10000
+
+// Comment — комментарий к задаче. Ответы образуют один уровень вложенности:
+// ответить можно только на комментарий верхнего уровня.
+type Comment struct {
+	ID     uuid.UUID `json:"id"`
+	TaskID uuid.UUID `json:"task_id"`
+	// ParentID указывает комментарий, на который дан ответ; nil у комментариев верхнего уровня.
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	Body     string     `json:"body"`
+	// CreatedBy — автор комментария; пустая строка, если комментарий оставлен без аутентификации.
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// EditedAt заполнен у комментариев, текст которых меняли после создания.
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	// Replies — ответы на комментарий верхнего уровня в порядке создания.
+	Replies []Comment `json:"replies,omitempty"`
+}
+
+func (c *Comment) Validate() error {
+	var errs FieldErrors
+	if strings.TrimSpace(c.Body) == "" {
+		errs.Add("body", "cannot be empty")
+	}
+	if len([]rune(c.Body)) > MaxCommentLength {
+		errs.Add("body", "must be at most %d characters", MaxCommentLength)
+	}
+	return errs.Err()
+}
+
+// CommentCursor указывает последний комментарий верхнего уровня на странице.
+type CommentCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// CommentListParams описывает параметры выборки комментариев задачи.
+type CommentListParams struct {
+	// Cursor — непрозрачный курсор, полученный клиентом с предыдущей страницей.
+	Cursor string `json:"cursor,omitempty"`
+	// After — декодированный Cursor, его заполняет usecase перед обращением к репозиторию.
+	After *CommentCursor `json:"-"`
+	Limit int            `json:"limit"`
+}
+
+// CommentPage — страница комментариев верхнего уровня вместе с ответами на них.
+type CommentPage struct {
+	Comments   []Comment `json:"comments"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+	HasMore    bool      `json:"has_more"`
+}
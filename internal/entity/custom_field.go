@@ -0,0 +1,225 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы значений пользовательских полей.
+const (
+	CustomFieldString = "string"
+	CustomFieldNumber = "number"
+	// CustomFieldEnum принимает одно из значений Options.
+	CustomFieldEnum = "enum"
+	// CustomFieldDate принимает дату в формате YYYY-MM-DD.
+	CustomFieldDate = "date"
+	// CustomFieldUser принимает идентификатор пользователя.
+	CustomFieldUser = "user"
+)
+
+// Состояния построения индекса значений пользовательского поля.
+const (
+	// CustomFieldIndexPending — индекс ожидает построения в фоне; до его готовности
+	// сортировка и сравнения по полю работают, но без индекса.
+	CustomFieldIndexPending = "pending"
+	CustomFieldIndexReady   = "ready"
+	// CustomFieldIndexFailed — индекс построить не удалось; причина записана в журнал.
+	CustomFieldIndexFailed = "failed"
+)
+
+const (
+	// MaxCustomFieldStringLength — максимальная длина значения поля типа string в символах.
+	MaxCustomFieldStringLength = 1000
+	// CustomFieldDateLayout — формат значений полей типа date.
+	CustomFieldDateLayout = "2006-01-02"
+)
+
+// customFieldKeyPattern ограничивает ключ поля символами, безопасными в выражениях индексов.
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// CustomField — схема пользовательского поля задач. Поля определяются администратором
+// для всего сервиса, значения хранятся в Task.CustomFields по ключу Key.
+type CustomField struct {
+	ID uuid.UUID `json:"id"`
+	// Key — имя поля в custom_fields задачи, в фильтрах и сортировке; после создания не меняется.
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// Type — тип значения; после создания не меняется.
+	Type string `json:"type"`
+	// Options — допустимые значения поля типа enum.
+	Options []string `json:"options,omitempty"`
+	// Required требует значение поля у каждой создаваемой и обновляемой задачи.
+	Required bool `json:"required"`
+	// IndexStatus — состояние построения индекса значений поля: pending, ready или failed.
+	IndexStatus string    `json:"index_status" enums:"pending,ready,failed"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ValidCustomFieldKey проверяет формат ключа пользовательского поля.
+func ValidCustomFieldKey(key string) bool {
+	return customFieldKeyPattern.MatchString(key)
+}
+
+func (f *CustomField) Validate() error {
+	var errs FieldErrors
+	if !ValidCustomFieldKey(f.Key) {
+		errs.Add("key", "must start with a lowercase letter and contain only lowercase letters, digits and underscores (up to 63 characters)")
+	}
+	if strings.TrimSpace(f.Name) == "" {
+		errs.Add("name", "cannot be empty")
+	}
+	if len(f.Name) > 100 {
+		errs.Add("name", "must be less than 100 characters")
+	}
+	switch f.Type {
+	case CustomFieldString, CustomFieldNumber, CustomFieldDate, CustomFieldUser:
+		if len(f.Options) > 0 {
+			errs.Add("options", "are only allowed for enum fields")
+		}
+	case CustomFieldEnum:
+		if len(f.Options) == 0 {
+			errs.Add("options", "enum field must have options")
+		}
+		seen := make(map[string]bool, len(f.Options))
+		for _, option := range f.Options {
+			if option == "" {
+				errs.Add("options", "enum options cannot be empty")
+			} else if seen[option] {
+				errs.Add("options", "duplicate enum option %q", option)
+			}
+			seen[option] = true
+		}
+	default:
+		errs.Add("type", "must be one of string, number, enum, date, user")
+	}
+	return errs.Err()
+}
+
+// NormalizeValue проверяет значение поля и возвращает его в том виде, в котором оно хранится.
+func (f CustomField) NormalizeValue(raw json.RawMessage) (json.RawMessage, error) {
+	if f.Type == CustomFieldNumber {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var number json.Number
+		if err := decoder.Decode(&number); err != nil {
+			return nil, fmt.Errorf("%s must be a number", f.Key)
+		}
+		return f.ParseValue(number.String())
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("%s must be a string", f.Key)
+	}
+	return f.ParseValue(s)
+}
+
+// ParseValue разбирает значение поля из строки, например из параметра запроса.
+func (f CustomField) ParseValue(s string) (json.RawMessage, error) {
+	switch f.Type {
+	case CustomFieldNumber:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, fmt.Errorf("%s must be a number", f.Key)
+		}
+		return json.Marshal(n)
+	case CustomFieldEnum:
+		for _, option := range f.Options {
+			if s == option {
+				return json.Marshal(s)
+			}
+		}
+		return nil, fmt.Errorf("%s must be one of %s", f.Key, strings.Join(f.Options, ", "))
+	case CustomFieldDate:
+		if _, err := time.Parse(CustomFieldDateLayout, s); err != nil {
+			return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", f.Key)
+		}
+	case CustomFieldUser:
+		if s == "" || len(s) > MaxUserIDLength {
+			return nil, fmt.Errorf("%s must be a user ID of 1 to %d characters", f.Key, MaxUserIDLength)
+		}
+	default:
+		if len([]rune(s)) > MaxCustomFieldStringLength {
+			return nil, fmt.Errorf("%s must be at most %d characters", f.Key, MaxCustomFieldStringLength)
+		}
+	}
+	return json.Marshal(s)
+}
+
+// NormalizeCustomFields проверяет значения пользовательских полей задачи по схемам fields
+// и возвращает их в том виде, в котором они хранятся. Значение null означает отсутствие значения.
+func NormalizeCustomFields(fields []CustomField, values map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	schema := make(map[string]CustomField, len(fields))
+	for _, field := range fields {
+		schema[field.Key] = field
+	}
+
+	var errs FieldErrors
+	normalized := make(map[string]json.RawMessage, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		raw := values[key]
+		field, ok := schema[key]
+		if !ok {
+			errs.Add("custom_fields."+key, "unknown custom field")
+			continue
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		value, err := field.NormalizeValue(raw)
+		if err != nil {
+			errs.Add("custom_fields."+key, "%v", err)
+			continue
+		}
+		normalized[key] = value
+	}
+
+	for _, field := range fields {
+		name := "custom_fields." + field.Key
+		if _, ok := normalized[field.Key]; field.Required && !ok && !errs.Has(name) {
+			errs.Add(name, "is required")
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// Операции сравнения в фильтрах по пользовательским полям.
+const (
+	CustomFieldEq  = "eq"
+	CustomFieldGt  = "gt"
+	CustomFieldGte = "gte"
+	CustomFieldLt  = "lt"
+	CustomFieldLte = "lte"
+)
+
+// CustomFieldFilter отбирает задачи, значение поля Key которых находится в отношении Op с Value.
+type CustomFieldFilter struct {
+	Key   string `json:"key"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+	// Typed — Value, приведенное к типу поля; его заполняет usecase.
+	Typed json.RawMessage `json:"-"`
+}
+
+// ValidCustomFieldOp проверяет, что операция сравнения поддерживается.
+func ValidCustomFieldOp(op string) bool {
+	switch op {
+	case CustomFieldEq, CustomFieldGt, CustomFieldGte, CustomFieldLt, CustomFieldLte:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxGraphDepth ограничивает число шагов от задачи при построении графа зависимостей.
+const MaxGraphDepth = 10
+
+// TaskDependency — связь «BlockerID блокирует BlockedID»: работу над BlockedID
+// нельзя начать, пока BlockerID не выполнена.
+type TaskDependency struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TaskGraph — задачи, связанные с задачей зависимостями, и связи между ними.
+type TaskGraph struct {
+	Nodes []Task           `json:"nodes"`
+	Edges []TaskDependency `json:"edges"`
+}
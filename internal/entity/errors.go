@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind — категория доменной ошибки. По категории транспорт выбирает HTTP-статус
+// и код gRPC, поэтому новые ошибки не требуют правок обработчиков.
+type ErrorKind string
+
+const (
+	// KindNotFound — запрошенный объект не существует.
+	KindNotFound ErrorKind = "not_found"
+	// KindInvalidID — идентификатор в запросе имеет неверный формат.
+	KindInvalidID ErrorKind = "invalid_id"
+	// KindInvalidArgument — неверные параметры запроса: курсор, сортировка, фильтр.
+	KindInvalidArgument ErrorKind = "invalid_argument"
+	// KindValidation — данные объекта не прошли проверку; подробности по полям в FieldErrors.
+	KindValidation ErrorKind = "validation"
+	// KindFailedPrecondition — операция недопустима в текущем состоянии объекта:
+	// запрещенный переход статуса, цикл зависимостей.
+	KindFailedPrecondition ErrorKind = "failed_precondition"
+	// KindConflict — операция конфликтует с другими объектами: задача заблокирована,
+	// проект не пуст.
+	KindConflict ErrorKind = "conflict"
+	// KindAlreadyExists — объект с такими уникальными значениями уже существует.
+	KindAlreadyExists ErrorKind = "already_exists"
+	// KindVersionConflict — объект изменен другим клиентом после чтения.
+	KindVersionConflict ErrorKind = "version_conflict"
+	// KindForbidden — автору запроса не хватает прав.
+	KindForbidden ErrorKind = "forbidden"
+	// KindUnauthenticated — запрос без действительных учетных данных.
+	KindUnauthenticated ErrorKind = "unauthenticated"
+	// KindTooLarge — содержимое запроса превышает допустимый размер.
+	KindTooLarge ErrorKind = "too_large"
+	// KindUnsupportedMediaType — тип содержимого запроса не поддерживается.
+	KindUnsupportedMediaType ErrorKind = "unsupported_media_type"
+	// KindRateLimited — клиент превысил ограничение частоты запросов.
+	KindRateLimited ErrorKind = "rate_limited"
+)
+
+// Error — доменная ошибка со стабильным кодом, по которому клиенты различают ошибки.
+// Ошибки с одинаковым кодом равны для errors.Is, поэтому репозиторий и сценарии могут
+// уточнять сообщение, не теряя связи с объявленной ошибкой.
+type Error struct {
+	Kind ErrorKind
+	// Code — стабильный машиночитаемый код ошибки, например task_not_found.
+	Code    string
+	Message string
+}
+
+// NewError создает доменную ошибку категории kind.
+func NewError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Общие ошибки запросов, не относящиеся к конкретному объекту.
+var (
+	ErrInvalidID      = NewError(KindInvalidID, "invalid_id", "invalid ID format")
+	ErrInvalidRequest = NewError(KindInvalidArgument, "invalid_request", "invalid request")
+)
+
+// InvalidID возвращает ошибку неверного формата идентификатора объекта name.
+func InvalidID(name string) error {
+	return &Error{Kind: KindInvalidID, Code: ErrInvalidID.Code, Message: fmt.Sprintf("invalid %s ID format", name)}
+}
+
+// InvalidRequest возвращает ошибку неверного запроса с пояснением.
+func InvalidRequest(format string, args ...interface{}) error {
+	return &Error{Kind: KindInvalidArgument, Code: ErrInvalidRequest.Code, Message: fmt.Sprintf(format, args...)}
+}
+
+// AsError возвращает первую доменную ошибку в цепочке err.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	ok := errors.As(err, &domainErr)
+	return domainErr, ok
+}
+
+// FieldError — ошибка значения одного поля.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors — ошибки проверки значений полей. Сценарии оборачивают их вместе с доменной
+// ошибкой (fmt.Errorf("%w: %w", ErrInvalidTask, err)), чтобы клиент получил ошибки по полям.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Add добавляет ошибку поля field.
+func (e *FieldErrors) Add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Has сообщает, есть ли ошибка поля field.
+func (e FieldErrors) Has(field string) bool {
+	for _, fieldErr := range e {
+		if fieldErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Err возвращает ошибки как error или nil, если ошибок нет.
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Fields возвращает ошибки по полям из цепочки err.
+func Fields(err error) FieldErrors {
+	var fields FieldErrors
+	errors.As(err, &fields)
+	return fields
+}
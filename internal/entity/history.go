@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Действия, которые записываются в историю задачи.
+const (
+	HistoryCreate  = "create"
+	HistoryUpdate  = "update"
+	HistoryDelete  = "delete"
+	HistoryRestore = "restore"
+	// HistoryRevert — возврат задачи к одной из предыдущих ревизий.
+	HistoryRevert = "revert"
+)
+
+// FieldChange — значения поля до и после изменения в том виде, в котором их хранит база.
+type FieldChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// TaskHistoryEntry — запись истории задачи об одном изменении.
+type TaskHistoryEntry struct {
+	TaskID uuid.UUID `json:"task_id"`
+	// Revision — порядковый номер изменения задачи, начиная с 1 у создания.
+	Revision int64 `json:"revision"`
+	// Version — версия задачи после изменения.
+	Version int64  `json:"version"`
+	Action  string `json:"action"`
+	// Changes — измененные поля по именам столбцов.
+	Changes map[string]FieldChange `json:"changes"`
+	// Actor и RequestID — автор изменения и ID HTTP- или gRPC-запроса; пусты у изменений,
+	// сделанных самим сервисом.
+	Actor     string `json:"actor,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// RevertedTo — ревизия, к которой вернули задачу; заполнен у записей HistoryRevert.
+	RevertedTo *int64    `json:"reverted_to,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TaskHistoryParams описывает параметры выборки истории задачи.
+type TaskHistoryParams struct {
+	// Before ограничивает выборку ревизиями меньше Before; 0 означает с последней ревизии.
+	Before int64 `json:"before,omitempty"`
+	Limit  int   `json:"limit"`
+}
+
+// TaskHistoryPage — страница истории задачи, новые записи идут первыми.
+type TaskHistoryPage struct {
+	Entries []TaskHistoryEntry `json:"entries"`
+	// NextBefore передается в before, чтобы получить следующую страницу.
+	NextBefore int64 `json:"next_before,omitempty"`
+	HasMore    bool  `json:"has_more"`
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLabelColor назначается метке, если цвет не указан.
+const DefaultLabelColor = "#808080"
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Label — метка, которой отмечают задачи для сортировки и разбора входящих.
+type Label struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Color — цвет метки в формате #RRGGBB.
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (l *Label) Validate() error {
+	var errs FieldErrors
+	if l.Name == "" {
+		errs.Add("name", "cannot be empty")
+	}
+	if len(l.Name) > 64 {
+		errs.Add("name", "must be less than 64 characters")
+	}
+	if !labelColorPattern.MatchString(l.Color) {
+		errs.Add("color", "must be in #RRGGBB format")
+	}
+	return errs.Err()
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "slices"
+
+// MaxUserIDLength — максимальная длина идентификатора пользователя.
+const MaxUserIDLength = 255
+
+// Области доступа. ScopeTasksWrite включает ScopeTasksRead, ScopeAdmin включает все области.
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	ScopeAdmin      = "admin"
+)
+
+// Scopes — допустимые области доступа.
+var Scopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeAdmin}
+
+// DefaultUserScopes получает пользователь, в токене которого области доступа не указаны.
+var DefaultUserScopes = []string{ScopeTasksRead, ScopeTasksWrite}
+
+// Principal — аутентифицированный автор запроса.
+type Principal struct {
+	// Subject — идентификатор пользователя (claim sub токена) или ключа API.
+	Subject string `json:"subject"`
+	// Scopes — области доступа, выданные токену или ключу.
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope сообщает, разрешена ли автору запроса область доступа scope.
+func (p Principal) HasScope(scope string) bool {
+	if slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Scopes, scope) {
+		return true
+	}
+	return scope == ScopeTasksRead && slices.Contains(p.Scopes, ScopeTasksWrite)
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Project объединяет задачи одного продукта или команды.
+type Project struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *Project) Validate() error {
+	var errs FieldErrors
+	if p.Name == "" {
+		errs.Add("name", "cannot be empty")
+	}
+	if len(p.Name) > 255 {
+		errs.Add("name", "must be less than 255 characters")
+	}
+	return errs.Err()
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectRole — роль пользователя в проекте. Каждая следующая роль включает права предыдущих:
+// viewer читает задачи, member создает и изменяет их и удаляет свои, maintainer удаляет
+// и восстанавливает любые задачи, owner управляет проектом и его участниками.
+type ProjectRole string
+
+const (
+	RoleViewer     ProjectRole = "viewer"
+	RoleMember     ProjectRole = "member"
+	RoleMaintainer ProjectRole = "maintainer"
+	RoleOwner      ProjectRole = "owner"
+)
+
+// projectRoleLevels упорядочивает роли по возрастанию прав.
+var projectRoleLevels = map[ProjectRole]int{
+	RoleViewer:     1,
+	RoleMember:     2,
+	RoleMaintainer: 3,
+	RoleOwner:      4,
+}
+
+// IsValid проверяет, что роль известна.
+func (r ProjectRole) IsValid() bool {
+	_, ok := projectRoleLevels[r]
+	return ok
+}
+
+// Includes сообщает, включает ли роль права роли other. Пустая роль не включает ничего.
+func (r ProjectRole) Includes(other ProjectRole) bool {
+	return r.IsValid() && projectRoleLevels[r] >= projectRoleLevels[other]
+}
+
+// Action — операция над задачами или проектом, право на которую проверяет авторизация.
+type Action string
+
+const (
+	ActionView   Action = "view"
+	ActionCreate Action = "create"
+	ActionEdit   Action = "edit"
+	ActionDelete Action = "delete"
+	ActionManage Action = "manage"
+)
+
+// actionRoles задает минимальную роль в проекте для каждой операции.
+var actionRoles = map[Action]ProjectRole{
+	ActionView:   RoleViewer,
+	ActionCreate: RoleMember,
+	ActionEdit:   RoleMember,
+	ActionDelete: RoleMaintainer,
+	ActionManage: RoleOwner,
+}
+
+// RequiredRole возвращает минимальную роль в проекте, с которой разрешена операция.
+func (a Action) RequiredRole() ProjectRole {
+	return actionRoles[a]
+}
+
+// ProjectMember — роль пользователя в проекте.
+type ProjectMember struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	// Subject — идентификатор пользователя или ключа API (см. Principal.Subject).
+	Subject   string      `json:"subject"`
+	Role      ProjectRole `json:"role"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+func (m *ProjectMember) Validate() error {
+	var errs FieldErrors
+	if m.Subject == "" {
+		errs.Add("subject", "cannot be empty")
+	}
+	if len(m.Subject) > MaxUserIDLength {
+		errs.Add("subject", "must be at most %d characters", MaxUserIDLength)
+	}
+	if !m.Role.IsValid() {
+		errs.Add("role", "must be one of viewer, member, maintainer or owner")
+	}
+	return errs.Err()
+}
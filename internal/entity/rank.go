@@ -0,0 +1,189 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ранги задают порядок задач в колонке доски. Ранг — дробная часть числа в системе
+// счисления по основанию 62, записанная цифрами rankDigits; цифры идут в порядке ASCII,
+// поэтому ранги сравниваются как строки побайтово. Между любыми двумя рангами есть
+// промежуточный, поэтому перемещение задачи меняет ранг только у неё самой.
+const rankDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const (
+	rankBase = len(rankDigits)
+	// rankWidth — число цифр, в пределах которых ранг увеличивается при добавлении задачи в
+	// конец колонки, чтобы длина рангов не росла с каждой новой задачей.
+	rankWidth = 4
+	// initialRank — ранг первой задачи в пустой колонке.
+	initialRank = "V"
+	// MaxRankLength — длина ранга, после которой колонка перебалансируется.
+	MaxRankLength = 24
+)
+
+// ValidRank проверяет, что ранг состоит из цифр rankDigits и не оканчивается нулем:
+// между рангами "a" и "a0" не было бы места.
+func ValidRank(rank string) bool {
+	if rank == "" || strings.HasSuffix(rank, "0") {
+		return false
+	}
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RankBetween возвращает ранг строго между lo и hi. Пустой lo означает начало колонки,
+// пустой hi — её конец.
+func RankBetween(lo, hi string) (string, error) {
+	if lo != "" && !ValidRank(lo) {
+		return "", fmt.Errorf("invalid rank %q", lo)
+	}
+	if hi != "" && !ValidRank(hi) {
+		return "", fmt.Errorf("invalid rank %q", hi)
+	}
+	switch {
+	case lo == "" && hi == "":
+		return initialRank, nil
+	case hi == "":
+		return rankAfter(lo), nil
+	case lo == "":
+		return rankBefore(hi), nil
+	case lo >= hi:
+		return "", fmt.Errorf("rank %q must be less than %q", lo, hi)
+	default:
+		return rankMidpoint(lo, hi), nil
+	}
+}
+
+// EvenRanks возвращает n возрастающих рангов одинаковой длины, равномерно распределенных
+// по нижней половине диапазона; верхняя половина остается для новых задач.
+func EvenRanks(n int) []string {
+	width, space := rankWidth, pow62(rankWidth)
+	for space < 4*int64(n+1) {
+		width++
+		space *= int64(rankBase)
+	}
+	step := space / 2 / int64(n+1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		ranks[i] = encodeRank(int64(i+1)*step, width)
+	}
+	return ranks
+}
+
+// rankAfter увеличивает ранг на единицу младшего из rankWidth разрядов.
+func rankAfter(lo string) string {
+	digits := rankDigitsOf(lo)
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < rankBase-1 {
+			digits[i]++
+			return formatRank(digits[:i+1])
+		}
+	}
+	// Все разряды максимальны
+	return rankMidpoint(lo, "")
+}
+
+// rankBefore уменьшает ранг на единицу младшего из rankWidth разрядов.
+func rankBefore(hi string) string {
+	digits := rankDigitsOf(hi)
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] > 0 {
+			digits[i]--
+			for j := i + 1; j < len(digits); j++ {
+				digits[j] = rankBase - 1
+			}
+			if rank := formatRank(digits); rank != "" {
+				return rank
+			}
+			break
+		}
+	}
+	// Ранг вида 0...01 уменьшать некуда
+	return rankMidpoint("", hi)
+}
+
+// rankMidpoint возвращает ранг между lo и hi; пустой hi означает конец диапазона.
+func rankMidpoint(lo, hi string) string {
+	if hi != "" {
+		// Общий префикс сохраняется; недостающие цифры lo считаются нулями
+		n := 0
+		for n < len(hi) {
+			c := byte('0')
+			if n < len(lo) {
+				c = lo[n]
+			}
+			if c != hi[n] {
+				break
+			}
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(lo) {
+				rest = lo[n:]
+			}
+			return hi[:n] + rankMidpoint(rest, hi[n:])
+		}
+	}
+
+	dlo, dhi := 0, rankBase
+	if lo != "" {
+		dlo = strings.IndexByte(rankDigits, lo[0])
+	}
+	if hi != "" {
+		dhi = strings.IndexByte(rankDigits, hi[0])
+	}
+	if dhi-dlo > 1 {
+		return string(rankDigits[(dlo+dhi)/2])
+	}
+	// Первые цифры соседние: укороченный hi уже больше lo
+	if len(hi) > 1 {
+		return hi[:1]
+	}
+	rest := ""
+	if len(lo) > 1 {
+		rest = lo[1:]
+	}
+	return string(rankDigits[dlo]) + rankMidpoint(rest, "")
+}
+
+// rankDigitsOf возвращает цифры ранга, дополненные нулями до rankWidth.
+func rankDigitsOf(rank string) []int {
+	digits := make([]int, max(len(rank), rankWidth))
+	for i := 0; i < len(rank); i++ {
+		digits[i] = strings.IndexByte(rankDigits, rank[i])
+	}
+	return digits
+}
+
+// formatRank записывает цифры ранга без завершающих нулей.
+func formatRank(digits []int) string {
+	var b strings.Builder
+	for _, d := range digits {
+		b.WriteByte(rankDigits[d])
+	}
+	return strings.TrimRight(b.String(), "0")
+}
+
+func encodeRank(value int64, width int) string {
+	digits := make([]int, width)
+	for i := width - 1; i >= 0; i-- {
+		digits[i] = int(value % int64(rankBase))
+		value /= int64(rankBase)
+	}
+	return formatRank(digits)
+}
+
+func pow62(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= int64(rankBase)
+	}
+	return p
+}
@@ -0,0 +1,137 @@
+package entity
+
+import "testing"
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		lo, hi  string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty column", want: initialRank},
+		{name: "append", lo: "V", want: "V001"},
+		{name: "append after max digit", lo: "zzzz", want: "zzzzV"},
+		{name: "prepend", hi: "V", want: "Uzzz"},
+		{name: "prepend before smallest digit", hi: "1", want: "0zzz"},
+		{name: "prepend before leading zeros", hi: "0001", want: "0000V"},
+		{name: "midpoint", lo: "A", hi: "a", want: "N"},
+		{name: "adjacent digits", lo: "a", hi: "b", want: "aV"},
+		{name: "adjacent digits with longer hi", lo: "a", hi: "b5", want: "b"},
+		{name: "hi extends lo", lo: "a", hi: "a1", want: "a0V"},
+		{name: "common prefix", lo: "abc", hi: "abz", want: "abn"},
+		{name: "equal ranks", lo: "a", hi: "a", wantErr: true},
+		{name: "reversed ranks", lo: "b", hi: "a", wantErr: true},
+		{name: "trailing zero", lo: "a0", wantErr: true},
+		{name: "invalid digit", hi: "a-b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RankBetween(tt.lo, tt.hi)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("RankBetween(%q, %q) = %q, want error", tt.lo, tt.hi, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RankBetween(%q, %q): %v", tt.lo, tt.hi, err)
+			}
+			if got != tt.want {
+				t.Errorf("RankBetween(%q, %q) = %q, want %q", tt.lo, tt.hi, got, tt.want)
+			}
+			checkRankBetween(t, tt.lo, tt.hi, got)
+		})
+	}
+}
+
+// checkRankBetween проверяет, что ранг допустим и лежит строго между lo и hi.
+func checkRankBetween(t *testing.T, lo, hi, rank string) {
+	t.Helper()
+	if !ValidRank(rank) {
+		t.Fatalf("rank %q between %q and %q is invalid", rank, lo, hi)
+	}
+	if lo != "" && rank <= lo || hi != "" && rank >= hi {
+		t.Fatalf("rank %q is not between %q and %q", rank, lo, hi)
+	}
+}
+
+func TestRankBetweenRepeatedInserts(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi string
+		// next возвращает новые границы после вставки rank между lo и hi.
+		next func(lo, hi, rank string) (string, string)
+	}{
+		{
+			name: "always after lo",
+			lo:   "V", hi: "W",
+			next: func(lo, hi, rank string) (string, string) { return lo, rank },
+		},
+		{
+			name: "always before hi",
+			lo:   "V", hi: "W",
+			next: func(lo, hi, rank string) (string, string) { return rank, hi },
+		},
+		{
+			name: "always at top",
+			hi:   "1",
+			next: func(lo, hi, rank string) (string, string) { return "", rank },
+		},
+		{
+			name: "always at bottom",
+			lo:   "zzzz",
+			next: func(lo, hi, rank string) (string, string) { return rank, "" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := tt.lo, tt.hi
+			for i := 0; i < 200; i++ {
+				rank, err := RankBetween(lo, hi)
+				if err != nil {
+					t.Fatalf("insert %d: RankBetween(%q, %q): %v", i, lo, hi, err)
+				}
+				checkRankBetween(t, lo, hi, rank)
+				lo, hi = tt.next(lo, hi, rank)
+			}
+		})
+	}
+}
+
+func TestEvenRanks(t *testing.T) {
+	tests := []struct {
+		n        int
+		maxWidth int
+	}{
+		{n: 0},
+		{n: 1, maxWidth: rankWidth},
+		{n: 100, maxWidth: rankWidth},
+		{n: 100000, maxWidth: rankWidth},
+		{n: 4000000, maxWidth: rankWidth + 1},
+	}
+
+	for _, tt := range tests {
+		ranks := EvenRanks(tt.n)
+		if len(ranks) != tt.n {
+			t.Fatalf("EvenRanks(%d) returned %d ranks", tt.n, len(ranks))
+		}
+		for i, rank := range ranks {
+			if !ValidRank(rank) {
+				t.Fatalf("EvenRanks(%d)[%d] = %q is invalid", tt.n, i, rank)
+			}
+			if len(rank) > tt.maxWidth {
+				t.Fatalf("EvenRanks(%d)[%d] = %q is longer than %d", tt.n, i, rank, tt.maxWidth)
+			}
+			if i > 0 && rank <= ranks[i-1] {
+				t.Fatalf("EvenRanks(%d): %q follows %q", tt.n, rank, ranks[i-1])
+			}
+		}
+		// Верхняя половина диапазона остается свободной для новых задач
+		if tt.n > 0 && ranks[tt.n-1] >= initialRank {
+			t.Errorf("EvenRanks(%d) last rank %q is not below %q", tt.n, ranks[tt.n-1], initialRank)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Группы маршрутов, для которых задаются отдельные ограничения частоты запросов.
+const (
+	RateLimitGroupTasks        = "tasks"
+	RateLimitGroupProjects     = "projects"
+	RateLimitGroupLabels       = "labels"
+	RateLimitGroupCustomFields = "custom_fields"
+	RateLimitGroupAPIKeys      = "api_keys"
+	// RateLimitGroupClientIP учитывает все запросы с одного IP-адреса до аутентификации,
+	// в том числе с неверными ключами API и токенами.
+	RateLimitGroupClientIP = "client_ip"
+)
+
+// RateLimit — не более Requests запросов за Window. Лимит восполняется равномерно,
+// поэтому после паузы в Window/Requests снова доступен один запрос.
+type RateLimit struct {
+	Requests int           `mapstructure:"requests"`
+	Window   time.Duration `mapstructure:"window"`
+}
+
+// Enabled сообщает, ограничивает ли лимит запросы. Нулевой лимит означает отсутствие ограничений.
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+// Interval возвращает время, за которое восполняется один запрос.
+func (l RateLimit) Interval() time.Duration {
+	return l.Window / time.Duration(l.Requests)
+}
+
+// RateLimitRule — лимит группы маршрутов и отдельные лимиты её HTTP-методов.
+type RateLimitRule struct {
+	RateLimit `mapstructure:",squash"`
+	// Methods задает лимиты отдельных методов: get, post, put, patch, delete. Ключи
+	// в нижнем регистре, как их читает конфигурация. Методы без своего лимита делят лимит группы.
+	Methods map[string]RateLimit `mapstructure:"methods"`
+}
+
+// RateLimitPolicy задает ограничения частоты запросов одного клиента.
+type RateLimitPolicy struct {
+	// Default применяется к группам маршрутов, для которых нет своего правила.
+	Default RateLimitRule            `mapstructure:"default"`
+	Groups  map[string]RateLimitRule `mapstructure:"groups"`
+}
+
+// DefaultRateLimitPolicy возвращает ограничения по умолчанию: 600 чтений и 120 изменений
+// в минуту на клиента в каждой группе маршрутов, ключи API — 30 запросов в минуту,
+// с одного IP-адреса до аутентификации — 3000 запросов в минуту.
+func DefaultRateLimitPolicy() RateLimitPolicy {
+	writes := RateLimit{Requests: 120, Window: time.Minute}
+	return RateLimitPolicy{
+		Default: RateLimitRule{
+			RateLimit: RateLimit{Requests: 600, Window: time.Minute},
+			Methods: map[string]RateLimit{
+				"post":   writes,
+				"put":    writes,
+				"patch":  writes,
+				"delete": writes,
+			},
+		},
+		Groups: map[string]RateLimitRule{
+			RateLimitGroupAPIKeys:  {RateLimit: RateLimit{Requests: 30, Window: time.Minute}},
+			RateLimitGroupClientIP: {RateLimit: RateLimit{Requests: 3000, Window: time.Minute}},
+		},
+	}
+}
+
+// Validate проверяет, что лимиты не отрицательны и лимит с запросами задает окно.
+func (p RateLimitPolicy) Validate() error {
+	if err := p.Default.validate("default"); err != nil {
+		return err
+	}
+	for group, rule := range p.Groups {
+		if err := rule.validate(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r RateLimitRule) validate(name string) error {
+	if err := r.RateLimit.validate(name); err != nil {
+		return err
+	}
+	for method, limit := range r.Methods {
+		if err := limit.validate(name + "." + method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l RateLimit) validate(name string) error {
+	if l.Requests < 0 || l.Window < 0 {
+		return fmt.Errorf("rate limit %q cannot be negative", name)
+	}
+	if l.Requests > 0 && l.Window == 0 {
+		return fmt.Errorf("rate limit %q must have a window", name)
+	}
+	return nil
+}
+
+// Limit возвращает лимит запросов method к группе маршрутов group и имя счетчика,
+// в котором учитываются такие запросы.
+func (p RateLimitPolicy) Limit(group, method string) (RateLimit, string) {
+	rule, ok := p.Groups[group]
+	if !ok {
+		rule = p.Default
+	}
+	method = strings.ToLower(method)
+	if limit, ok := rule.Methods[method]; ok {
+		return limit, group + ":" + method
+	}
+	return rule.RateLimit, group
+}
+
+// RateLimitResult — решение по запросу и состояние лимита после него.
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     RateLimit
+	Remaining int
+	// Reset — время до полного восполнения лимита.
+	Reset time.Duration
+	// RetryAfter — время до следующего разрешенного запроса, если запрос отклонен.
+	RetryAfter time.Duration
+}
@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxRecurrencePreview — максимальное число экземпляров в предпросмотре серии.
+const MaxRecurrencePreview = 100
+
+// Recurrence — серия повторяющихся задач. Экземпляры серии создаются копированием
+// задачи-шаблона: заголовок, описание, проект, родитель, приоритет и метки переносятся как есть,
+// статус становится начальным статусом процесса, а плановые даты сдвигаются к моменту экземпляра.
+type Recurrence struct {
+	ID         uuid.UUID `json:"id"`
+	TemplateID uuid.UUID `json:"template_id"`
+	// RRule — правило повторения в подмножестве RFC 5545, см. RRule.
+	RRule string `json:"rrule"`
+	// StartsAt — начало серии (DTSTART); задает время суток экземпляров.
+	StartsAt time.Time `json:"starts_at"`
+	// Timezone — часовой пояс IANA, в котором вычисляются экземпляры.
+	Timezone string `json:"timezone"`
+	// Paused приостанавливает создание экземпляров; пропущенные за паузу экземпляры не создаются.
+	Paused bool `json:"paused"`
+	// NextRunAt — момент следующего экземпляра; nil, если серия завершена.
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	// LastRunAt — момент последнего созданного экземпляра.
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Rule разбирает правило серии и возвращает начало серии в её часовом поясе.
+func (r *Recurrence) Rule() (RRule, time.Time, error) {
+	rule, err := ParseRRule(r.RRule)
+	if err != nil {
+		return RRule{}, time.Time{}, err
+	}
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return RRule{}, time.Time{}, fmt.Errorf("unknown timezone %q", r.Timezone)
+	}
+	return rule, r.StartsAt.In(loc), nil
+}
+
+func (r *Recurrence) Validate() error {
+	if r.StartsAt.IsZero() {
+		return FieldErrors{{Field: "starts_at", Message: "cannot be empty"}}
+	}
+	if _, _, err := r.Rule(); err != nil {
+		return FieldErrors{{Field: "rrule", Message: err.Error()}}
+	}
+	return nil
+}
+
+// RecurrencePreview — ближайшие экземпляры серии.
+type RecurrencePreview struct {
+	Occurrences []time.Time `json:"occurrences"`
+}
+
+// OccurrenceTask возвращает экземпляр серии, запланированный на момент at. ID экземпляра
+// однозначно определяется серией и моментом, поэтому повторное создание того же экземпляра
+// отклоняется хранилищем как дубликат.
+func (r *Recurrence) OccurrenceTask(template Task, status string, at time.Time) Task {
+	task := Task{
+		ID:          uuid.NewSHA1(r.ID, []byte(at.UTC().Format(time.RFC3339))),
+		Title:       template.Title,
+		Description: template.Description,
+		Status:      status,
+		ProjectID:   template.ProjectID,
+		ParentID:    template.ParentID,
+		Priority:    template.Priority,
+		// Значения проверяются по текущим схемам полей при создании экземпляра
+		CustomFields: template.CustomFields,
+		CreatedBy:    template.CreatedBy,
+		AssigneeID:   template.AssigneeID,
+	}
+
+	start := at
+	switch {
+	case template.StartAt != nil && template.DueAt != nil:
+		// Сохраняем длительность шаблона
+		due := at.Add(template.DueAt.Sub(*template.StartAt))
+		task.StartAt, task.DueAt = &start, &due
+	case template.DueAt != nil:
+		task.DueAt = &start
+	default:
+		task.StartAt = &start
+	}
+	return task
+}
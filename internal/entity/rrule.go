@@ -0,0 +1,402 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Частоты повторения, поддерживаемые RRule.
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxEmptyPeriods ограничивает перебор периодов без единого экземпляра, чтобы
+// правила, которые никогда не срабатывают (например, BYMONTH=2;BYMONTHDAY=30), не зацикливались.
+const maxEmptyPeriods = 10000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// WeekdayNum — элемент BYDAY: день недели и, для MONTHLY и YEARLY, его номер в месяце.
+// N = 0 означает каждый такой день, отрицательный N отсчитывается от конца месяца.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RRule — правило повторения в подмножестве RFC 5545: FREQ (DAILY, WEEKLY, MONTHLY, YEARLY),
+// INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY и BYMONTH. Время суток экземпляров
+// берется из начала серии; неделя начинается с понедельника.
+type RRule struct {
+	Freq     string
+	Interval int
+	// Count ограничивает число экземпляров серии; 0 — без ограничения.
+	Count int
+	// Until — последний допустимый момент экземпляра; nil — без ограничения.
+	Until      *time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+}
+
+// ParseRRule разбирает значение RRULE, например "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// Префикс "RRULE:" допускается.
+func ParseRRule(s string) (RRule, error) {
+	rule := RRule{Interval: 1}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return RRule{}, fmt.Errorf("rrule cannot be empty")
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !ok || value == "" {
+			return RRule{}, fmt.Errorf("invalid rrule part %q", part)
+		}
+		if seen[name] {
+			return RRule{}, fmt.Errorf("duplicate rrule part %s", name)
+		}
+		seen[name] = true
+		value = strings.ToUpper(strings.TrimSpace(value))
+
+		var err error
+		switch name {
+		case "FREQ":
+			switch value {
+			case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+				rule.Freq = value
+			default:
+				err = fmt.Errorf("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parseRRuleInt(name, value, 1, 1000)
+		case "COUNT":
+			rule.Count, err = parseRRuleInt(name, value, 1, 10000)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRRuleUntil(value)
+			rule.Until = &until
+		case "BYDAY":
+			rule.ByDay, err = parseRRuleByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseRRuleIntList(name, value, -31, 31)
+		case "BYMONTH":
+			rule.ByMonth, err = parseRRuleIntList(name, value, 1, 12)
+		case "WKST":
+			if value != "MO" {
+				err = fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			err = fmt.Errorf("unsupported rrule part %s", name)
+		}
+		if err != nil {
+			return RRule{}, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return RRule{}, fmt.Errorf("FREQ is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return RRule{}, fmt.Errorf("COUNT and UNTIL cannot be used together")
+	}
+	if rule.Freq == FreqWeekly && len(rule.ByMonthDay) > 0 {
+		return RRule{}, fmt.Errorf("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	if rule.Freq == FreqDaily || rule.Freq == FreqWeekly {
+		for _, day := range rule.ByDay {
+			if day.N != 0 {
+				return RRule{}, fmt.Errorf("numbered BYDAY is only allowed with FREQ=MONTHLY or FREQ=YEARLY")
+			}
+		}
+	}
+	return rule, nil
+}
+
+func parseRRuleInt(name, value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%s must be an integer between %d and %d", name, min, max)
+	}
+	return n, nil
+}
+
+func parseRRuleIntList(name, value string, min, max int) ([]int, error) {
+	var list []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := parseRRuleInt(name, item, min, max)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("%s cannot contain 0", name)
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+func parseRRuleByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid BYDAY value %q", item)
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseRRuleUntil принимает UNTIL в форматах 20261231T235959Z и 20261231;
+// дата без времени включает весь день по UTC.
+func parseRRuleUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("UNTIL must be in YYYYMMDD or YYYYMMDDTHHMMSSZ format")
+}
+
+// Occurrences возвращает до n экземпляров серии, начатой в dtstart, строго после after.
+// Экземпляры вычисляются в часовом поясе dtstart.
+func (r RRule) Occurrences(dtstart, after time.Time, n int) []time.Time {
+	var result []time.Time
+	if n <= 0 {
+		return result
+	}
+	r.each(dtstart, func(t time.Time) bool {
+		if t.After(after) {
+			result = append(result, t)
+		}
+		return len(result) < n
+	})
+	return result
+}
+
+// Due возвращает последний экземпляр в интервале (after, now] и первый экземпляр после now.
+// Любой из них равен nil, если такого экземпляра нет.
+func (r RRule) Due(dtstart, after, now time.Time) (last, next *time.Time) {
+	r.each(dtstart, func(t time.Time) bool {
+		if !t.After(after) {
+			return true
+		}
+		if t.After(now) {
+			next = &t
+			return false
+		}
+		last = &t
+		return true
+	})
+	return last, next
+}
+
+// each перебирает экземпляры серии по возрастанию, пока fn возвращает true.
+func (r RRule) each(dtstart time.Time, fn func(t time.Time) bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	emitted := 0
+	for period, empty := 0, 0; empty < maxEmptyPeriods; period++ {
+		candidates := r.expand(dtstart, period*interval)
+		found := false
+		for _, t := range candidates {
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return
+			}
+			found = true
+			emitted++
+			if r.Count > 0 && emitted > r.Count {
+				return
+			}
+			if !fn(t) {
+				return
+			}
+		}
+		if found {
+			empty = 0
+		} else {
+			empty++
+		}
+	}
+}
+
+// expand возвращает отсортированных кандидатов периода, отстоящего от начала серии на offset единиц FREQ.
+func (r RRule) expand(dtstart time.Time, offset int) []time.Time {
+	loc := dtstart.Location()
+	year, month, day := dtstart.Date()
+	hour, min, sec := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		t := time.Date(y, m, d, hour, min, sec, 0, loc)
+		if h, mi, s := t.Clock(); h == hour && mi == min && s == sec {
+			return t
+		}
+		// Такого времени нет из-за перевода часов: по RFC 5545 оно отсчитывается
+		// со смещением, действовавшим до перевода
+		_, offset := t.Add(-24 * time.Hour).Zone()
+		return time.Date(y, m, d, hour, min, sec, 0, time.UTC).Add(-time.Duration(offset) * time.Second).In(loc)
+	}
+
+	var candidates []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		t := at(year, month, day+offset)
+		if r.matchesMonth(t.Month()) && r.matchesMonthDay(t) && r.matchesWeekday(t.Weekday()) {
+			candidates = append(candidates, t)
+		}
+	case FreqWeekly:
+		// Понедельник недели начала серии
+		monday := day - (int(dtstart.Weekday())+6)%7 + offset*7
+		weekdays := []time.Weekday{dtstart.Weekday()}
+		if len(r.ByDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, d := range r.ByDay {
+				weekdays = append(weekdays, d.Weekday)
+			}
+		}
+		for _, weekday := range weekdays {
+			t := at(year, month, monday+(int(weekday)+6)%7)
+			if r.matchesMonth(t.Month()) {
+				candidates = append(candidates, t)
+			}
+		}
+	case FreqMonthly:
+		first := time.Date(year, month+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		if r.matchesMonth(first.Month()) {
+			for _, d := range r.monthDays(first.Year(), first.Month(), day) {
+				candidates = append(candidates, at(first.Year(), first.Month(), d))
+			}
+		}
+	case FreqYearly:
+		months := []time.Month{month}
+		if len(r.ByMonth) > 0 {
+			months = months[:0]
+			for _, m := range r.ByMonth {
+				months = append(months, time.Month(m))
+			}
+		}
+		for _, m := range months {
+			for _, d := range r.monthDays(year+offset, m, day) {
+				candidates = append(candidates, at(year+offset, m, d))
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	// Повторяющиеся значения BYDAY и BYMONTH не должны давать повторяющиеся экземпляры
+	unique := candidates[:0]
+	for i, t := range candidates {
+		if i == 0 || !t.Equal(candidates[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// monthDays возвращает дни месяца, подходящие под BYMONTHDAY и BYDAY; без них — день начала серии,
+// если он есть в месяце. При заданных BYMONTHDAY и BYDAY день должен подходить под оба.
+func (r RRule) monthDays(year int, month time.Month, startDay int) []int {
+	daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if startDay <= daysInMonth {
+			return []int{startDay}
+		}
+		return nil
+	}
+
+	var days []int
+	for d := 1; d <= daysInMonth; d++ {
+		t := time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+		if (len(r.ByMonthDay) == 0 || r.matchesMonthDay(t)) && (len(r.ByDay) == 0 || r.matchesNthWeekday(t, daysInMonth)) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+func (r RRule) matchesMonth(month time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == month {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RRule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range r.ByMonthDay {
+		if d == t.Day() || (d < 0 && daysInMonth+1+d == t.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RRule) matchesWeekday(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNthWeekday проверяет BYDAY с учетом номера дня недели в месяце.
+func (r RRule) matchesNthWeekday(t time.Time, daysInMonth int) bool {
+	for _, d := range r.ByDay {
+		if d.Weekday != t.Weekday() {
+			continue
+		}
+		switch {
+		case d.N == 0:
+			return true
+		case d.N > 0 && (t.Day()-1)/7+1 == d.N:
+			return true
+		case d.N < 0 && (daysInMonth-t.Day())/7+1 == -d.N:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestRRuleOccurrences(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	const layout = "2006-01-02 15:04 MST"
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		want    []string
+	}{
+		{
+			name:    "daily across spring forward",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 3, 7, 9, 0, 0, 0, newYork),
+			want:    []string{"2026-03-07 09:00 EST", "2026-03-08 09:00 EDT", "2026-03-09 09:00 EDT"},
+		},
+		{
+			name:    "daily inside the skipped hour",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 3, 7, 2, 30, 0, 0, newYork),
+			want:    []string{"2026-03-07 02:30 EST", "2026-03-08 03:30 EDT", "2026-03-09 02:30 EDT"},
+		},
+		{
+			name:    "weekly inside the skipped hour",
+			rule:    "FREQ=WEEKLY",
+			dtstart: time.Date(2026, 3, 22, 2, 30, 0, 0, berlin),
+			want:    []string{"2026-03-22 02:30 CET", "2026-03-29 03:30 CEST", "2026-04-05 02:30 CEST"},
+		},
+		{
+			name:    "weekly across fall back",
+			rule:    "FREQ=WEEKLY",
+			dtstart: time.Date(2026, 10, 25, 9, 0, 0, 0, newYork),
+			want:    []string{"2026-10-25 09:00 EDT", "2026-11-01 09:00 EST", "2026-11-08 09:00 EST"},
+		},
+		{
+			name:    "monthly across DST",
+			rule:    "FREQ=MONTHLY;COUNT=3",
+			dtstart: time.Date(2026, 2, 15, 18, 0, 0, 0, newYork),
+			want:    []string{"2026-02-15 18:00 EST", "2026-03-15 18:00 EDT", "2026-04-15 18:00 EDT"},
+		},
+		{
+			name:    "monthly on the 31st skips short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2026-01-31 10:00 UTC", "2026-03-31 10:00 UTC", "2026-05-31 10:00 UTC", "2026-07-31 10:00 UTC"},
+		},
+		{
+			name:    "monthly on the 30th skips February",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=30",
+			dtstart: time.Date(2026, 1, 30, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2026-01-30 10:00 UTC", "2026-03-30 10:00 UTC", "2026-04-30 10:00 UTC"},
+		},
+		{
+			name:    "last day of month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2026-01-31 10:00 UTC", "2026-02-28 10:00 UTC", "2026-03-31 10:00 UTC", "2026-04-30 10:00 UTC"},
+		},
+		{
+			name:    "last day of month in leap year",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			dtstart: time.Date(2028, 1, 31, 10, 0, 0, 0, time.UTC),
+			want:    []string{"2028-01-31 10:00 UTC", "2028-02-29 10:00 UTC", "2028-03-31 10:00 UTC"},
+		},
+		{
+			name:    "last Friday of month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: time.Date(2026, 1, 1, 17, 0, 0, 0, time.UTC),
+			want:    []string{"2026-01-30 17:00 UTC", "2026-02-27 17:00 UTC", "2026-03-27 17:00 UTC"},
+		},
+		{
+			name:    "yearly on February 29",
+			rule:    "FREQ=YEARLY",
+			dtstart: time.Date(2028, 2, 29, 8, 0, 0, 0, time.UTC),
+			want:    []string{"2028-02-29 08:00 UTC", "2032-02-29 08:00 UTC", "2036-02-29 08:00 UTC"},
+		},
+		{
+			name:    "weekly across month end",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,FR",
+			dtstart: time.Date(2026, 1, 26, 9, 0, 0, 0, time.UTC),
+			want:    []string{"2026-01-26 09:00 UTC", "2026-01-30 09:00 UTC", "2026-02-02 09:00 UTC", "2026-02-06 09:00 UTC"},
+		},
+		{
+			name:    "until on the last day of month",
+			rule:    "FREQ=DAILY;UNTIL=20260228T235959Z",
+			dtstart: time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC),
+			want:    []string{"2026-02-26 12:00 UTC", "2026-02-27 12:00 UTC", "2026-02-28 12:00 UTC"},
+		},
+		{
+			name:    "never matching rule",
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q): %v", tt.rule, err)
+			}
+			want := len(tt.want)
+			if want == 0 {
+				want = 1
+			}
+
+			var got []string
+			for _, occurrence := range rule.Occurrences(tt.dtstart, tt.dtstart.Add(-time.Second), want) {
+				got = append(got, occurrence.Format(layout))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Occurrences = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
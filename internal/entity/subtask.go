@@ -0,0 +1,52 @@
+package entity
+
+import "github.com/google/uuid"
+
+// MaxTaskDepth — максимальное число уровней в иерархии задач, включая задачу верхнего уровня.
+const MaxTaskDepth = 5
+
+// TaskPlacement описывает положение, которое задача займет под новым родителем.
+type TaskPlacement struct {
+	// Parent — новый родитель; nil, если задача становится задачей верхнего уровня.
+	Parent *Task
+	// Ancestors — ID родителя и всех его предков, начиная с родителя.
+	Ancestors []uuid.UUID
+	// SubtreeHeight — число уровней в поддереве задачи, включая её саму.
+	SubtreeHeight int
+}
+
+// Depth возвращает глубину самого нижнего уровня поддерева после перемещения.
+func (p TaskPlacement) Depth() int {
+	return len(p.Ancestors) + p.SubtreeHeight
+}
+
+// HasAncestor проверяет, окажется ли задача id предком самой себя.
+func (p TaskPlacement) HasAncestor(id uuid.UUID) bool {
+	for _, a := range p.Ancestors {
+		if a == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskNode — задача поддерева с глубиной относительно его корня.
+type TaskNode struct {
+	Task
+	Depth int `json:"depth"`
+}
+
+// TaskProgress — доля выполненных задач в поддереве.
+type TaskProgress struct {
+	Total     int     `json:"total"`
+	Completed int     `json:"completed"`
+	Percent   float64 `json:"percent"`
+}
+
+// TaskSubtree — задача со всеми вложенными задачами в порядке обхода в глубину
+// и прогрессом их выполнения.
+type TaskSubtree struct {
+	Task        Task         `json:"task"`
+	Progress    TaskProgress `json:"progress"`
+	Descendants []TaskNode   `json:"descendants"`
+}
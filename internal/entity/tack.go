@@ -1,12 +1,21 @@
 package entity
 
 import (
-	"fmt"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Уровни приоритета задачи. Чем больше значение, тем важнее задача.
+const (
+	PriorityNone   = 0
+	PriorityLow    = 1
+	PriorityMedium = 2
+	PriorityHigh   = 3
+	PriorityUrgent = 4
+)
+
 type Task struct {
 	ID          uuid.UUID `json:"id"`
 	Title       string    `json:"title"`
@@ -14,22 +23,53 @@ type Task struct {
 	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Version увеличивается при каждом изменении задачи и используется для оптимистичной блокировки.
+	Version int64 `json:"version"`
+	// DeletedAt заполнен у задач, перемещенных в корзину.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// ProjectID указывает проект задачи; nil у задач вне проектов.
+	ProjectID *uuid.UUID `json:"project_id,omitempty"`
+	// ParentID указывает родительскую задачу; nil у задач верхнего уровня.
+	// Меняется только перемещением задачи.
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	// StartAt и DueAt — плановые даты начала и завершения задачи; nil, если не заданы.
+	StartAt *time.Time `json:"start_at,omitempty"`
+	DueAt   *time.Time `json:"due_at,omitempty"`
+	// Priority — уровень приоритета от PriorityNone до PriorityUrgent.
+	Priority int `json:"priority"`
+	// Labels — назначенные задаче метки. Заполняется при чтении; назначаются
+	// и снимаются метки отдельными операциями.
+	Labels []Label `json:"labels,omitempty"`
+	// CustomFields — значения пользовательских полей по ключам их схем (см. CustomField).
+	CustomFields map[string]json.RawMessage `json:"custom_fields,omitempty"`
+	// Rank — положение задачи в колонке доски её статуса (см. RankBetween). Назначается
+	// хранилищем при создании и смене статуса; меняется перемещением по доске.
+	Rank string `json:"rank"`
+	// CreatedBy — пользователь, создавший задачу; заполняется из аутентифицированного автора
+	// запроса и не меняется. Пусто у задач, созданных без аутентификации.
+	CreatedBy string `json:"created_by,omitempty"`
+	// AssigneeID — исполнитель задачи; пусто, если исполнитель не назначен.
+	AssigneeID string `json:"assignee_id,omitempty"`
 }
 
+// Validate проверяет обязательные поля задачи. Допустимость статуса определяется Workflow.
+// Возвращает FieldErrors со всеми найденными ошибками.
 func (t *Task) Validate() error {
+	var errs FieldErrors
 	if t.Title == "" {
-		return fmt.Errorf("title cannot be empty")
+		errs.Add("title", "cannot be empty")
+	}
+	if t.Status == "" {
+		errs.Add("status", "cannot be empty")
+	}
+	if t.Priority < PriorityNone || t.Priority > PriorityUrgent {
+		errs.Add("priority", "must be between %d and %d", PriorityNone, PriorityUrgent)
 	}
-	validStatuses := []string{"todo", "in_progress", "done"}
-	isValid := false
-	for _, s := range validStatuses {
-		if t.Status == s {
-			isValid = true
-			break
-		}
+	if t.StartAt != nil && t.DueAt != nil && t.DueAt.Before(*t.StartAt) {
+		errs.Add("due_at", "cannot be before start_at")
 	}
-	if !isValid {
-		return fmt.Errorf("status must be one of: todo, in_progress, done")
+	if len(t.AssigneeID) > MaxUserIDLength {
+		errs.Add("assignee_id", "must be at most %d characters", MaxUserIDLength)
 	}
-	return nil
+	return errs.Err()
 }
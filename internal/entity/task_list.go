@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Поля, по которым можно сортировать список задач.
+const (
+	TaskSortCreatedAt = "created_at"
+	TaskSortUpdatedAt = "updated_at"
+	TaskSortTitle     = "title"
+	// TaskSortDueAt упорядочивает по сроку; задачи без срока идут последними.
+	TaskSortDueAt = "due_at"
+	// TaskSortPriority упорядочивает по убыванию приоритета, а при равном приоритете — по сроку.
+	TaskSortPriority = "priority"
+	// TaskSortRank упорядочивает задачи так, как они стоят в колонках доски.
+	TaskSortRank = "rank"
+	// TaskSortCustomFieldPrefix предшествует ключу пользовательского поля, например cf.severity.
+	// Задачи без значения поля идут первыми при сортировке по возрастанию.
+	TaskSortCustomFieldPrefix = "cf."
+)
+
+// TaskFilter описывает условия отбора задач. Пустые поля не ограничивают выборку.
+type TaskFilter struct {
+	ProjectID     *uuid.UUID `json:"project_id,omitempty"`
+	Statuses      []string   `json:"statuses,omitempty"`
+	CreatedFrom   *time.Time `json:"created_from,omitempty"`
+	CreatedTo     *time.Time `json:"created_to,omitempty"`
+	UpdatedFrom   *time.Time `json:"updated_from,omitempty"`
+	UpdatedTo     *time.Time `json:"updated_to,omitempty"`
+	TitleContains string     `json:"title_contains,omitempty"`
+	DueFrom       *time.Time `json:"due_from,omitempty"`
+	DueTo         *time.Time `json:"due_to,omitempty"`
+	// Overdue отбирает невыполненные задачи с истекшим сроком.
+	Overdue bool `json:"overdue,omitempty"`
+	// DueToday отбирает задачи со сроком в текущие сутки.
+	DueToday bool `json:"due_today,omitempty"`
+	// DueWithinDays отбирает задачи, срок которых наступит в ближайшие N суток.
+	DueWithinDays int `json:"due_within_days,omitempty"`
+	// LabelsAny отбирает задачи, отмеченные хотя бы одной из меток, LabelsAll — всеми
+	// метками сразу. Метки указываются по имени.
+	LabelsAny []string `json:"labels_any,omitempty"`
+	LabelsAll []string `json:"labels_all,omitempty"`
+	// AssigneeID и CreatedBy отбирают задачи исполнителя и автора.
+	AssigneeID string `json:"assignee_id,omitempty"`
+	CreatedBy  string `json:"created_by,omitempty"`
+	// CustomFields отбирает задачи по значениям пользовательских полей; условия объединяются через И.
+	CustomFields []CustomFieldFilter `json:"custom_fields,omitempty"`
+	// ExcludeStatuses исключает задачи в перечисленных статусах; его заполняет usecase.
+	ExcludeStatuses []string `json:"-"`
+	// Deleted переключает выборку на задачи в корзине.
+	Deleted bool `json:"deleted,omitempty"`
+	// VisibleTo оставляет задачи вне проектов и задачи проектов, в которых у пользователя
+	// есть роль; его заполняет usecase. Входит в ключ кэша списков.
+	VisibleTo string `json:"visible_to,omitempty"`
+}
+
+// IsRelative проверяет, зависит ли фильтр от текущего времени.
+func (f TaskFilter) IsRelative() bool {
+	return f.Overdue || f.DueToday || f.DueWithinDays > 0
+}
+
+// TaskSort задает порядок выдачи. Для одинаковых значений поля порядок определяется ID.
+type TaskSort struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// IsValid проверяет, что сортировка выполняется по поддерживаемому полю.
+func (s TaskSort) IsValid() bool {
+	switch s.Field {
+	case TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortTitle, TaskSortDueAt, TaskSortPriority, TaskSortRank:
+		return true
+	}
+	_, ok := s.CustomFieldKey()
+	return ok
+}
+
+// CustomFieldKey возвращает ключ пользовательского поля, если сортировка выполняется по нему.
+func (s TaskSort) CustomFieldKey() (string, bool) {
+	key, ok := strings.CutPrefix(s.Field, TaskSortCustomFieldPrefix)
+	if !ok || !ValidCustomFieldKey(key) {
+		return "", false
+	}
+	return key, true
+}
+
+// TaskCursor указывает позицию в упорядоченном списке задач для keyset-пагинации.
+// Value хранит значение поля сортировки последней задачи на странице.
+type TaskCursor struct {
+	Sort  TaskSort  `json:"sort"`
+	Value string    `json:"value"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// TaskListParams описывает параметры выборки списка задач.
+type TaskListParams struct {
+	Filter TaskFilter `json:"filter"`
+	Sort   TaskSort   `json:"sort"`
+	// Cursor — непрозрачный курсор, полученный клиентом с предыдущей страницей.
+	Cursor string `json:"cursor,omitempty"`
+	// After — декодированный Cursor, его заполняет usecase перед обращением к репозиторию.
+	After *TaskCursor `json:"-"`
+	Limit int         `json:"limit"`
+}
+
+// TaskPage — страница списка задач.
+type TaskPage struct {
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/google/uuid"
+
+// TaskSearchParams описывает полнотекстовый поиск по названию и описанию задач.
+type TaskSearchParams struct {
+	// Query — поисковая строка в синтаксисе websearch: слова, "фразы", OR и -исключения.
+	Query     string
+	ProjectID *uuid.UUID
+	Statuses  []string
+	Limit     int
+	Offset    int
+	// VisibleTo ограничивает поиск так же, как TaskFilter.VisibleTo; его заполняет usecase.
+	VisibleTo string
+}
+
+// TaskSearchResult — найденная задача с оценкой релевантности и подсвеченными фрагментами.
+// Фрагменты — HTML: текст задачи в них экранирован, совпадения обернуты в <b>...</b>.
+type TaskSearchResult struct {
+	Task                 Task    `json:"task"`
+	Rank                 float64 `json:"rank"`
+	TitleHighlight       string  `json:"title_highlight"`
+	DescriptionHighlight string  `json:"description_highlight"`
+}
@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Условия, которые можно назначить переходу в статус.
+const (
+	GuardDescriptionRequired = "description_required"
+)
+
+var guardChecks = map[string]func(task Task) bool{
+	GuardDescriptionRequired: func(task Task) bool {
+		return strings.TrimSpace(task.Description) != ""
+	},
+}
+
+// Workflow описывает допустимые статусы задач и переходы между ними.
+type Workflow struct {
+	Statuses []string `mapstructure:"statuses"`
+	// Transitions перечисляет для каждого статуса статусы, в которые из него можно перейти.
+	Transitions map[string][]string `mapstructure:"transitions"`
+	// Guards перечисляет для статуса условия, которым задача должна удовлетворять, чтобы в него перейти.
+	Guards map[string][]string `mapstructure:"guards"`
+	// Completed перечисляет статусы, в которых задача считается выполненной.
+	Completed []string `mapstructure:"completed"`
+	// RequiresUnblocked перечисляет статусы, в которые нельзя перевести задачу,
+	// пока не выполнены блокирующие её задачи.
+	RequiresUnblocked []string `mapstructure:"requires_unblocked"`
+}
+
+// StatusTransition описывает возможный переход задачи в статус.
+type StatusTransition struct {
+	Status       string   `json:"status"`
+	Allowed      bool     `json:"allowed"`
+	FailedGuards []string `json:"failed_guards,omitempty"`
+}
+
+// DefaultWorkflow возвращает процесс по умолчанию: todo, in_progress и done с любыми переходами.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Statuses: []string{"todo", "in_progress", "done"},
+		Transitions: map[string][]string{
+			"todo":        {"in_progress", "done"},
+			"in_progress": {"todo", "done"},
+			"done":        {"todo", "in_progress"},
+		},
+		Completed:         []string{"done"},
+		RequiresUnblocked: []string{"in_progress", "done"},
+	}
+}
+
+// Validate проверяет, что переходы и условия ссылаются на объявленные статусы и известные условия.
+func (w Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("workflow must declare at least one status")
+	}
+	for from, targets := range w.Transitions {
+		if !w.HasStatus(from) {
+			return fmt.Errorf("workflow transition from unknown status %q", from)
+		}
+		for _, to := range targets {
+			if !w.HasStatus(to) {
+				return fmt.Errorf("workflow transition from %q to unknown status %q", from, to)
+			}
+		}
+	}
+	for _, status := range w.Completed {
+		if !w.HasStatus(status) {
+			return fmt.Errorf("workflow completed status %q is not declared", status)
+		}
+	}
+	for _, status := range w.RequiresUnblocked {
+		if !w.HasStatus(status) {
+			return fmt.Errorf("workflow status %q requiring unblocked tasks is not declared", status)
+		}
+	}
+	for status, guards := range w.Guards {
+		if !w.HasStatus(status) {
+			return fmt.Errorf("workflow guard for unknown status %q", status)
+		}
+		for _, guard := range guards {
+			if _, ok := guardChecks[guard]; !ok {
+				return fmt.Errorf("workflow guard %q for status %q is not supported", guard, status)
+			}
+		}
+	}
+	return nil
+}
+
+// InitialStatus возвращает первый объявленный статус; его получают задачи, создаваемые сервисом.
+func (w Workflow) InitialStatus() string {
+	return w.Statuses[0]
+}
+
+// HasStatus проверяет, объявлен ли статус в процессе.
+func (w Workflow) HasStatus(status string) bool {
+	for _, s := range w.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCompleted проверяет, считается ли задача в этом статусе выполненной.
+func (w Workflow) IsCompleted(status string) bool {
+	for _, s := range w.Completed {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlockable проверяет, нужно ли выполнить блокирующие задачи перед переходом в статус.
+func (w Workflow) IsBlockable(status string) bool {
+	for _, s := range w.RequiresUnblocked {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransition проверяет, разрешен ли переход. Сохранение текущего статуса разрешено всегда.
+func (w Workflow) CanTransition(from, to string) bool {
+	if from == to {
+		return true
+	}
+	for _, s := range w.Transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// FailedGuards возвращает условия перехода в статус to, которым задача не удовлетворяет.
+func (w Workflow) FailedGuards(task Task, to string) []string {
+	var failed []string
+	for _, guard := range w.Guards[to] {
+		if check, ok := guardChecks[guard]; ok && !check(task) {
+			failed = append(failed, guard)
+		}
+	}
+	return failed
+}
+
+// NextStatuses перечисляет статусы, в которые задача может перейти из текущего,
+// с результатом проверки условий для каждого из них.
+func (w Workflow) NextStatuses(task Task) []StatusTransition {
+	transitions := make([]StatusTransition, 0, len(w.Transitions[task.Status]))
+	for _, to := range w.Transitions[task.Status] {
+		failed := w.FailedGuards(task, to)
+		transitions = append(transitions, StatusTransition{
+			Status:       to,
+			Allowed:      len(failed) == 0,
+			FailedGuards: failed,
+		})
+	}
+	return transitions
+}
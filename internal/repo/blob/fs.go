@@ -0,0 +1,123 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// FSStore хранит содержимое вложений в файлах внутри каталога root.
+type FSStore struct {
+	root   string
+	logger *logrus.Logger
+}
+
+func NewFSStore(root string) (*FSStore, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve blob directory: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &FSStore{
+		root:   root,
+		logger: logger.Log,
+	}, nil
+}
+
+// path переводит ключ в путь к файлу, не позволяя выйти за пределы root.
+func (s *FSStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return path, nil
+}
+
+// Put пишет содержимое во временный файл рядом с целевым и переименовывает его
+// только после успешной записи, поэтому читатели не видят недописанных файлов.
+func (s *FSStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, contextReader{ctx: ctx, r: r}); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"method": "Put",
+			"key":    key,
+		}).WithError(err).Error("Failed to store blob")
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	return nil
+}
+
+func (s *FSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	return file, nil
+}
+
+func (s *FSStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		s.logger.WithFields(logrus.Fields{
+			"method": "Delete",
+			"key":    key,
+		}).WithError(err).Error("Failed to delete blob")
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	// Пустые каталоги задач больше не нужны; непустой каталог os.Remove не тронет
+	os.Remove(filepath.Dir(path))
+	return nil
+}
+
+// contextReader прерывает чтение после отмены контекста, например когда клиент
+// оборвал загрузку.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
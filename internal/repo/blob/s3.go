@@ -0,0 +1,410 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// s3PartSize — размер части multipart-загрузки. В памяти одновременно находится
+// не больше одной части на загрузку; S3 требует не меньше 5 МиБ для всех частей, кроме последней.
+const s3PartSize = 8 << 20
+
+// emptyPayloadHash — SHA-256 пустого тела запроса.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config описывает подключение к S3-совместимому хранилищу.
+type S3Config struct {
+	// Endpoint — адрес хранилища со схемой, например http://localhost:9000 для MinIO.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Store хранит содержимое вложений в бакете S3-совместимого хранилища.
+// Запросы подписываются AWS Signature V4, объекты адресуются в path-style,
+// поэтому хранилище работает и с MinIO без настройки DNS.
+type S3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	logger   *logrus.Logger
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is not set")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &S3Store{
+		cfg:      cfg,
+		endpoint: endpoint,
+		// Без общего таймаута: скачивание больших файлов ограничивает контекст запроса
+		client: &http.Client{},
+		logger: logger.Log,
+	}, nil
+}
+
+// EnsureBucket проверяет доступность хранилища и создает бакет, если его нет.
+func (s *S3Store) EnsureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, nil, emptyPayloadHash, nil)
+	if err != nil {
+		return fmt.Errorf("failed to check S3 bucket: %w", err)
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode != http.StatusNotFound:
+		return fmt.Errorf("failed to check S3 bucket: unexpected status %d", resp.StatusCode)
+	}
+
+	var body []byte
+	if s.cfg.Region != "us-east-1" {
+		body = []byte(`<CreateBucketConfiguration><LocationConstraint>` + s.cfg.Region + `</LocationConstraint></CreateBucketConfiguration>`)
+	}
+	resp, err = s.do(ctx, http.MethodPut, "", nil, body, payloadHash(body), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 bucket: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError("create bucket", resp)
+	}
+	return nil
+}
+
+// Put загружает небольшие файлы одним запросом, а файлы больше s3PartSize —
+// multipart-загрузкой по частям. При ошибке незавершенная загрузка отменяется.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	buf := make([]byte, s3PartSize)
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return s.putObject(ctx, key, buf[:n], contentType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	uploadID, err := s.createMultipartUpload(ctx, key, contentType)
+	if err != nil {
+		return err
+	}
+
+	var parts []completedPart
+	for partNumber, last := 1, false; ; partNumber++ {
+		etag, err := s.uploadPart(ctx, key, uploadID, partNumber, buf[:n])
+		if err != nil {
+			s.abortMultipartUpload(key, uploadID)
+			return err
+		}
+		parts = append(parts, completedPart{PartNumber: partNumber, ETag: etag})
+		if last {
+			break
+		}
+
+		n, err = io.ReadFull(r, buf)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		// Неполная часть может быть только последней
+		last = errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !last {
+			s.abortMultipartUpload(key, uploadID)
+			return fmt.Errorf("failed to read blob: %w", err)
+		}
+	}
+
+	if err := s.completeMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		s.abortMultipartUpload(key, uploadID)
+		return err
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, emptyPayloadHash, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, responseError("get object", resp)
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, emptyPayloadHash, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+	defer resp.Body.Close()
+	// S3 отвечает 204 и на удаление несуществующего объекта
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		s.logger.WithFields(logrus.Fields{
+			"method": "Delete",
+			"key":    key,
+			"status": resp.StatusCode,
+		}).Error("Failed to delete blob")
+		return responseError("delete object", resp)
+	}
+	return nil
+}
+
+func (s *S3Store) putObject(ctx context.Context, key string, data []byte, contentType string) error {
+	headers := http.Header{"Content-Type": {contentType}}
+	resp, err := s.do(ctx, http.MethodPut, key, nil, data, payloadHash(data), headers)
+	if err != nil {
+		return fmt.Errorf("failed to put blob: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError("put object", resp)
+	}
+	return nil
+}
+
+func (s *S3Store) createMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	headers := http.Header{"Content-Type": {contentType}}
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, emptyPayloadHash, headers)
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError("create multipart upload", resp)
+	}
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil || result.UploadID == "" {
+		return "", fmt.Errorf("failed to decode multipart upload response: %v", err)
+	}
+	return result.UploadID, nil
+}
+
+func (s *S3Store) uploadPart(ctx context.Context, key, uploadID string, partNumber int, data []byte) (string, error) {
+	query := url.Values{
+		"partNumber": {strconv.Itoa(partNumber)},
+		"uploadId":   {uploadID},
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, query, data, payloadHash(data), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError("upload part", resp)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (s *S3Store) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return fmt.Errorf("failed to encode multipart upload parts: %w", err)
+	}
+
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body, payloadHash(body), nil)
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError("complete multipart upload", resp)
+	}
+
+	// S3 может ответить 200 и сообщить об ошибке в теле ответа
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode multipart upload result: %w", err)
+	}
+	if result.XMLName.Local == "Error" {
+		return fmt.Errorf("complete multipart upload: %s: %s", result.Code, result.Message)
+	}
+	return nil
+}
+
+// abortMultipartUpload отменяет загрузку, чтобы хранилище освободило загруженные части.
+// Выполняется с отдельным контекстом: контекст запроса к этому моменту может быть отменен.
+func (s *S3Store) abortMultipartUpload(key, uploadID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, emptyPayloadHash, nil)
+	if err == nil {
+		resp.Body.Close()
+	}
+	if err != nil || resp.StatusCode != http.StatusNoContent {
+		s.logger.WithFields(logrus.Fields{
+			"method":    "abortMultipartUpload",
+			"key":       key,
+			"upload_id": uploadID,
+		}).WithError(err).Error("Failed to abort multipart upload")
+	}
+}
+
+// do выполняет подписанный запрос к бакету или к объекту key.
+func (s *S3Store) do(ctx context.Context, method, key string, query url.Values, body []byte, bodyHash string, headers http.Header) (*http.Response, error) {
+	u := *s.endpoint
+	path := "/" + s.cfg.Bucket
+	if key != "" {
+		path += "/" + key
+	}
+	u.Path = strings.TrimSuffix(s.endpoint.Path, "/") + path
+	u.RawPath = strings.TrimSuffix(s.endpoint.EscapedPath(), "/") + uriEncode(path, false)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	s.sign(req, bodyHash, time.Now().UTC())
+
+	return s.client.Do(req)
+}
+
+// sign добавляет к запросу заголовки AWS Signature V4.
+func (s *S3Store) sign(req *http.Request, bodyHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", bodyHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + bodyHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		bodyHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		payloadHash([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func payloadHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalQuery кодирует параметры запроса так, как этого требует подпись V4:
+// ключи отсортированы, пробелы кодируются как %20, параметры без значения передаются как "key=".
+func canonicalQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode кодирует все символы, кроме незарезервированных по RFC 3986;
+// '/' кодируется только при encodeSlash.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// responseError превращает ответ S3 с ошибкой в error с кодом из XML-тела.
+func responseError(op string, resp *http.Response) error {
+	var result struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err := xml.Unmarshal(data, &result); err != nil || result.Code == "" {
+		return fmt.Errorf("%s: unexpected status %d", op, resp.StatusCode)
+	}
+	return fmt.Errorf("%s: %d %s: %s", op, resp.StatusCode, result.Code, result.Message)
+}
@@ -0,0 +1,365 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// minRefreshInterval ограничивает внеплановую загрузку ключей при токене с неизвестным kid,
+// чтобы поток таких токенов не превращался в поток запросов к провайдеру.
+const minRefreshInterval = time.Minute
+
+// maxKeySetSize ограничивает размер загружаемого набора ключей.
+const maxKeySetSize = 1 << 20
+
+// Config описывает проверку токенов доступа.
+type Config struct {
+	// Source — путь к файлу JWKS или его URL со схемой http или https.
+	Source string
+	// Issuer и Audience — ожидаемые значения claims iss и aud; пустое значение не проверяется.
+	Issuer   string
+	Audience string
+	// RefreshInterval — период перезагрузки ключей по URL; файл читается один раз.
+	RefreshInterval time.Duration
+}
+
+// Verifier проверяет подписанные JWT по набору открытых ключей JWKS (RFC 7517).
+// Поддерживаются ключи RSA, EC (P-256, P-384, P-521) и Ed25519.
+type Verifier struct {
+	cfg    Config
+	parser *jwt.Parser
+	client *http.Client
+	logger *logrus.Logger
+
+	// loadMu сериализует загрузки ключей, mu защищает загруженный набор
+	loadMu   sync.Mutex
+	mu       sync.RWMutex
+	keys     map[string]jwk
+	loadedAt time.Time
+}
+
+// jwk — открытый ключ из набора вместе с ограничениями на его использование.
+type jwk struct {
+	key crypto.PublicKey
+	// alg — алгоритм, для которого выпущен ключ; пусто, если не указан.
+	alg string
+}
+
+// NewVerifier загружает набор ключей и возвращает Verifier. Ошибка загрузки при запуске
+// возвращается, чтобы сервис не стартовал с неработающей аутентификацией.
+func NewVerifier(ctx context.Context, cfg Config) (*Verifier, error) {
+	if cfg.Source == "" {
+		return nil, fmt.Errorf("JWKS source is not set")
+	}
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	v := &Verifier{
+		cfg:    cfg,
+		parser: jwt.NewParser(opts...),
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logger.Log,
+	}
+	if err := v.load(ctx); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// claims — проверяемые claims токена. Scope — области доступа через пробел (RFC 8693).
+type claims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope"`
+}
+
+// Verify проверяет подпись, срок действия, издателя и аудиторию токена и возвращает
+// пользователя из claim sub. Без claim scope пользователь получает entity.DefaultUserScopes,
+// неизвестные области доступа в scope игнорируются.
+func (v *Verifier) Verify(ctx context.Context, token string) (entity.Principal, error) {
+	var claims claims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return v.keyFor(ctx, t)
+	})
+	if err != nil {
+		return entity.Principal{}, fmt.Errorf("%w: %v", usecase.ErrUnauthenticated, err)
+	}
+	if claims.Subject == "" || len(claims.Subject) > entity.MaxUserIDLength {
+		return entity.Principal{}, fmt.Errorf("%w: token has no valid subject", usecase.ErrUnauthenticated)
+	}
+	if strings.HasPrefix(claims.Subject, entity.APIKeySubjectPrefix) {
+		return entity.Principal{}, fmt.Errorf("%w: subject is reserved for API keys", usecase.ErrUnauthenticated)
+	}
+
+	principal := entity.Principal{Subject: claims.Subject, Scopes: entity.DefaultUserScopes}
+	if claims.Scope != "" {
+		principal.Scopes = nil
+		for _, scope := range strings.Fields(claims.Scope) {
+			if slices.Contains(entity.Scopes, scope) {
+				principal.Scopes = append(principal.Scopes, scope)
+			}
+		}
+	}
+	return principal, nil
+}
+
+// keyFor выбирает ключ по kid токена и проверяет, что он подходит к алгоритму подписи.
+func (v *Verifier) keyFor(ctx context.Context, t *jwt.Token) (crypto.PublicKey, error) {
+	v.refresh(ctx, false)
+	kid, _ := t.Header["kid"].(string)
+	k, ok := v.lookup(kid)
+	if !ok {
+		// Провайдер мог сменить ключи раньше плановой перезагрузки
+		v.refresh(ctx, true)
+		k, ok = v.lookup(kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	alg := t.Method.Alg()
+	if k.alg != "" && k.alg != alg {
+		return nil, fmt.Errorf("key %q is not issued for %s", kid, alg)
+	}
+	switch key := k.key.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS") {
+			return key, nil
+		}
+	case *ecdsa.PublicKey:
+		if ecAlgorithms[key.Curve] == alg {
+			return key, nil
+		}
+	case ed25519.PublicKey:
+		if alg == "EdDSA" {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("key %q does not match algorithm %s", kid, alg)
+}
+
+// ecAlgorithms сопоставляет кривые EC с алгоритмами подписи (RFC 7518, раздел 3.4).
+var ecAlgorithms = map[elliptic.Curve]string{
+	elliptic.P256(): "ES256",
+	elliptic.P384(): "ES384",
+	elliptic.P521(): "ES512",
+}
+
+// lookup возвращает ключ по kid. Токен без kid принимается, только если ключ в наборе один.
+func (v *Verifier) lookup(kid string) (jwk, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			return k, true
+		}
+	}
+	k, ok := v.keys[kid]
+	return k, ok
+}
+
+// refresh перезагружает ключи по URL по расписанию или, если urgent, не чаще
+// minRefreshInterval. Одновременные запросы ждут одной загрузки.
+func (v *Verifier) refresh(ctx context.Context, urgent bool) {
+	if !isURL(v.cfg.Source) {
+		return
+	}
+	v.loadMu.Lock()
+	defer v.loadMu.Unlock()
+
+	v.mu.RLock()
+	age := time.Since(v.loadedAt)
+	v.mu.RUnlock()
+	if urgent && age < minRefreshInterval {
+		return
+	}
+	if !urgent && (v.cfg.RefreshInterval <= 0 || age < v.cfg.RefreshInterval) {
+		return
+	}
+	if err := v.load(ctx); err != nil {
+		v.logger.WithError(err).Warn("Failed to refresh JWKS")
+	}
+}
+
+// load загружает набор ключей из файла или по URL и заменяет текущий. При ошибке
+// текущий набор сохраняется, а следующая попытка откладывается до нового срока перезагрузки.
+func (v *Verifier) load(ctx context.Context) error {
+	data, err := v.fetch(ctx)
+	if err == nil {
+		var keys map[string]jwk
+		if keys, err = parseKeySet(data); err == nil {
+			v.mu.Lock()
+			v.keys, v.loadedAt = keys, time.Now()
+			v.mu.Unlock()
+			return nil
+		}
+	}
+
+	v.mu.Lock()
+	v.loadedAt = time.Now()
+	v.mu.Unlock()
+	return fmt.Errorf("failed to load JWKS from %s: %w", v.cfg.Source, err)
+}
+
+func (v *Verifier) fetch(ctx context.Context) ([]byte, error) {
+	if !isURL(v.cfg.Source) {
+		return os.ReadFile(v.cfg.Source)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxKeySetSize))
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+// parseKeySet разбирает JWKS. Ключи шифрования и ключи неподдерживаемых типов пропускаются.
+func parseKeySet(data []byte) (map[string]jwk, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("malformed key set: %w", err)
+	}
+
+	keys := make(map[string]jwk, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = rsaKey(k.N, k.E)
+		case "EC":
+			key, err = ecKey(k.Crv, k.X, k.Y)
+		case "OKP":
+			key, err = ed25519Key(k.Crv, k.X)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = jwk{key: key, alg: k.Alg}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no signing keys")
+	}
+	return keys, nil
+}
+
+func rsaKey(n, e string) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil || len(exponent) == 0 || len(exponent) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+	key := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}
+	if key.N.BitLen() < 2048 {
+		return nil, errors.New("RSA keys shorter than 2048 bits are not accepted")
+	}
+	return key, nil
+}
+
+func ecKey(crv, x, y string) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", crv)
+	}
+	xb, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	yb, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+	key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(xb), Y: new(big.Int).SetBytes(yb)}
+	if _, err := key.ECDH(); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return key, nil
+}
+
+func ed25519Key(crv, x string) (ed25519.PublicKey, error) {
+	if crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported curve %q", crv)
+	}
+	key, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid Ed25519 key")
+	}
+	return ed25519.PublicKey(key), nil
+}
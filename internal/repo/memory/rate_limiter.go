@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+)
+
+// sweepInterval — как часто из памяти удаляются восполненные счетчики.
+const sweepInterval = time.Minute
+
+// RateLimiter хранит счетчики ограничения частоты запросов в памяти процесса по тому же
+// алгоритму GCRA, что и redis.RateLimiter. Лимиты действуют отдельно в каждой реплике.
+type RateLimiter struct {
+	mu sync.Mutex
+	// tats хранит для каждого ключа теоретическое время прибытия следующего запроса.
+	tats      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		tats:      make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *RateLimiter) Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	interval := limit.Interval()
+	tat, ok := l.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(interval)
+	allowAt := newTat.Add(-limit.Window)
+	if now.Before(allowAt) {
+		return entity.RateLimitResult{
+			Limit:      limit,
+			Reset:      tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}, nil
+	}
+
+	l.tats[key] = newTat
+	return entity.RateLimitResult{
+		Allowed:   true,
+		Limit:     limit,
+		Remaining: int(now.Sub(allowAt) / interval),
+		Reset:     newTat.Sub(now),
+	}, nil
+}
+
+// sweep удаляет счетчики, лимит которых уже полностью восполнен.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	for key, tat := range l.tats {
+		if !tat.After(now) {
+			delete(l.tats, key)
+		}
+	}
+	l.lastSweep = now
+}
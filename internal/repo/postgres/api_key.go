@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// apiKeyTouchInterval — насколько может отставать записанное время использования ключа.
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// apiKeyColumns — список столбцов ключа API в порядке, который ожидает scanAPIKey.
+// Хэш секрета из хранилища не читается.
+const apiKeyColumns = `id, name, hint, scopes, expires_at, last_used_at, revoked_at, created_by, created_at`
+
+func scanAPIKey(row pgx.Row, key *entity.APIKey) error {
+	return row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Hint,
+		&key.Scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedBy,
+		&key.CreatedAt,
+	)
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key entity.APIKey, secretHash []byte) (entity.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO api_keys (id, name, hint, secret_hash, scopes, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + apiKeyColumns
+
+	err := scanAPIKey(r.db.QueryRow(ctx, query,
+		key.ID,
+		key.Name,
+		key.Hint,
+		secretHash,
+		key.Scopes,
+		key.ExpiresAt,
+		key.CreatedBy,
+		key.CreatedAt,
+	), &key)
+
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "Create",
+			"name":   key.Name,
+		}).WithError(err).Error("Failed to create API key")
+		return entity.APIKey{}, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]entity.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at DESC, id`)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "List",
+		}).WithError(err).Error("Failed to list API keys")
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []entity.APIKey
+	for rows.Next() {
+		var key entity.APIKey
+		if err := scanAPIKey(rows, &key); err != nil {
+			return nil, fmt.Errorf("failed to scan API key row: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, secretHash []byte) (entity.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var key entity.APIKey
+	err := scanAPIKey(r.db.QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE secret_hash = $1`, secretHash), &key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.APIKey{}, usecase.ErrAPIKeyNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method": "GetByHash",
+		}).WithError(err).Error("Failed to get API key")
+		return entity.APIKey{}, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Revoke",
+			"api_key_id": id,
+		}).WithError(err).Warn("Invalid API key ID format")
+		return entity.InvalidID("API key")
+	}
+
+	// Время первого отзыва сохраняется
+	result, err := r.db.Exec(ctx, `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $2) WHERE id = $1`, parsedID, at)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Revoke",
+			"api_key_id": id,
+		}).WithError(err).Error("Failed to revoke API key")
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE api_keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)`
+
+	if _, err := r.db.Exec(ctx, query, id, at, at.Add(-apiKeyTouchInterval)); err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Touch",
+			"api_key_id": id.String(),
+		}).WithError(err).Error("Failed to record API key usage")
+		return fmt.Errorf("failed to record API key usage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type AttachmentRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// attachmentColumns — список столбцов вложения в порядке, который ожидает scanAttachment.
+const attachmentColumns = `id, task_id, file_name, content_type, size, checksum, storage_key, created_at`
+
+func scanAttachment(row pgx.Row, attachment *entity.Attachment) error {
+	return row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+}
+
+func NewAttachmentRepository(db *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO attachments (id, task_id, file_name, content_type, size, checksum, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + attachmentColumns
+
+	err := scanAttachment(r.db.QueryRow(ctx, query,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.StorageKey,
+		attachment.CreatedAt,
+	), &attachment)
+
+	if err != nil {
+		// Задача удалена из корзины, пока загружался файл
+		if isForeignKeyViolation(err) {
+			return entity.Attachment{}, usecase.ErrTaskNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":  "Create",
+			"task_id": attachment.TaskID.String(),
+		}).WithError(err).Error("Failed to create attachment")
+		return entity.Attachment{}, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) Get(ctx context.Context, taskID, id uuid.UUID) (entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1 AND task_id = $2`
+
+	var attachment entity.Attachment
+	if err := scanAttachment(r.db.QueryRow(ctx, query, id, taskID), &attachment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Attachment{}, usecase.ErrAttachmentNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":        "Get",
+			"attachment_id": id.String(),
+		}).WithError(err).Error("Failed to get attachment")
+		return entity.Attachment{}, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) List(ctx context.Context, taskID uuid.UUID) ([]entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE task_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "List",
+			"task_id": taskID.String(),
+		}).WithError(err).Error("Failed to list attachments")
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []entity.Attachment
+	for rows.Next() {
+		var attachment entity.Attachment
+		if err := scanAttachment(rows, &attachment); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment row: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return attachments, nil
+}
+
+// Delete удаляет метаданные вложения. Триггер на таблице attachments ставит ключ
+// файла в attachment_blob_deletions в той же транзакции.
+func (r *AttachmentRepository) Delete(ctx context.Context, taskID, id uuid.UUID) (entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `DELETE FROM attachments WHERE id = $1 AND task_id = $2 RETURNING ` + attachmentColumns
+
+	var attachment entity.Attachment
+	if err := scanAttachment(r.db.QueryRow(ctx, query, id, taskID), &attachment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Attachment{}, usecase.ErrAttachmentNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":        "Delete",
+			"attachment_id": id.String(),
+		}).WithError(err).Error("Failed to delete attachment")
+		return entity.Attachment{}, fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) PendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `
+		SELECT storage_key FROM attachment_blob_deletions
+		ORDER BY deleted_at
+		LIMIT $1`, limit)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "PendingBlobDeletions",
+		}).WithError(err).Error("Failed to list pending blob deletions")
+		return nil, fmt.Errorf("failed to list pending blob deletions: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan blob deletion row: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *AttachmentRepository) ForgetBlobDeletions(ctx context.Context, keys []string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM attachment_blob_deletions WHERE storage_key = ANY($1)`, keys); err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "ForgetBlobDeletions",
+			"count":  len(keys),
+		}).WithError(err).Error("Failed to forget blob deletions")
+		return fmt.Errorf("failed to forget blob deletions: %w", err)
+	}
+	return nil
+}
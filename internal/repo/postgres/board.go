@@ -0,0 +1,289 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// rankLockKey — первый ключ advisory-блокировки колонки доски; второй ключ — хеш статуса.
+// Блокировка сериализует назначение рангов в колонке, поэтому ранги в ней не повторяются.
+const rankLockKey = 7_243_002
+
+// rebalanceTimeout ограничивает перебалансировку одной колонки.
+const rebalanceTimeout = time.Minute
+
+// lockColumn блокирует колонку status до конца транзакции tx.
+func lockColumn(ctx context.Context, tx pgx.Tx, status string) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, rankLockKey, status); err != nil {
+		return fmt.Errorf("failed to lock board column: %w", err)
+	}
+	return nil
+}
+
+// appendRank блокирует колонку status и возвращает ранг для задачи в её конце.
+// Ранги задач в корзине учитываются, чтобы восстановленная задача не совпала по рангу с другой.
+func appendRank(ctx context.Context, tx pgx.Tx, status string) (string, error) {
+	if err := lockColumn(ctx, tx, status); err != nil {
+		return "", err
+	}
+	var last string
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE status = $1`, status).Scan(&last); err != nil {
+		return "", fmt.Errorf("failed to get last rank: %w", err)
+	}
+	return entity.RankBetween(last, "")
+}
+
+// appendRanks назначает задачам без ранга места в конце колонок их статусов в порядке списка.
+func appendRanks(ctx context.Context, tx pgx.Tx, tasks []entity.Task) error {
+	last := make(map[string]string)
+	for _, task := range tasks {
+		if task.Rank == "" {
+			last[task.Status] = ""
+		}
+	}
+	// Колонки блокируются в одном порядке, чтобы встречные пакеты не ждали друг друга
+	statuses := make([]string, 0, len(last))
+	for status := range last {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		if err := lockColumn(ctx, tx, status); err != nil {
+			return err
+		}
+		var rank string
+		if err := tx.QueryRow(ctx, `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE status = $1`, status).Scan(&rank); err != nil {
+			return fmt.Errorf("failed to get last rank: %w", err)
+		}
+		last[status] = rank
+	}
+
+	for i := range tasks {
+		if tasks[i].Rank != "" {
+			continue
+		}
+		rank, err := entity.RankBetween(last[tasks[i].Status], "")
+		if err != nil {
+			return err
+		}
+		tasks[i].Rank = rank
+		last[tasks[i].Status] = rank
+	}
+	return nil
+}
+
+func (r *TaskRepository) RankFunc(ctx context.Context, id string, version int64, position entity.BoardPosition,
+	fn func(task *entity.Task) error) (entity.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "RankFunc",
+			"task_id": id,
+		}).WithError(err).Warn("Invalid task ID format")
+		return entity.Task{}, entity.InvalidID("task")
+	}
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Колонка блокируется после задачи, как и при смене статуса в updateLocked
+	task, err := updateLocked(ctx, tx, parsedID, version, func(task *entity.Task) error {
+		status, current := task.Status, task.Rank
+		if err := fn(task); err != nil {
+			return err
+		}
+		if err := lockColumn(ctx, tx, task.Status); err != nil {
+			return err
+		}
+		lo, hi, err := rankBounds(ctx, tx, *task, position)
+		if err != nil {
+			return err
+		}
+		rank, err := entity.RankBetween(lo, hi)
+		if err != nil {
+			return fmt.Errorf("%w: %v", usecase.ErrInvalidPosition, err)
+		}
+		// updateLocked отправит в конец колонки задачу, сменившую статус с прежним рангом
+		if task.Status != status && rank == current {
+			if rank, err = entity.RankBetween(lo, rank); err != nil {
+				return fmt.Errorf("%w: %v", usecase.ErrInvalidPosition, err)
+			}
+		}
+		task.Rank = rank
+		return nil
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "RankFunc",
+			"task_id": id,
+		}).WithError(err).Warn("Failed to move task on board")
+		return entity.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return task, nil
+}
+
+// rankBounds возвращает ранги соседей, между которыми встает задача. Незаданный сосед
+// заменяется ближайшей задачей колонки с другой стороны от заданного; пустой ранг означает
+// край колонки.
+func rankBounds(ctx context.Context, tx pgx.Tx, task entity.Task, position entity.BoardPosition) (string, string, error) {
+	neighbourRank := func(id *uuid.UUID) (string, error) {
+		if id == nil {
+			return "", nil
+		}
+		if *id == task.ID {
+			return "", fmt.Errorf("%w: task cannot be placed next to itself", usecase.ErrInvalidPosition)
+		}
+		var rank string
+		err := tx.QueryRow(ctx, `SELECT rank FROM tasks WHERE id = $1 AND status = $2 AND deleted_at IS NULL`,
+			*id, task.Status).Scan(&rank)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("%w: task %s is not in column %q", usecase.ErrInvalidPosition, id, task.Status)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get neighbour rank: %w", err)
+		}
+		return rank, nil
+	}
+
+	lo, err := neighbourRank(position.AfterID)
+	if err != nil {
+		return "", "", err
+	}
+	hi, err := neighbourRank(position.BeforeID)
+	if err != nil {
+		return "", "", err
+	}
+
+	var query string
+	var args []interface{}
+	switch {
+	case position.AfterID != nil && position.BeforeID != nil:
+		return lo, hi, nil
+	case position.AfterID != nil:
+		query = `SELECT COALESCE(MIN(rank), '') FROM tasks WHERE status = $1 AND id <> $2 AND rank > $3`
+		args = []interface{}{task.Status, task.ID, lo}
+	case position.BeforeID != nil:
+		query = `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE status = $1 AND id <> $2 AND rank < $3`
+		args = []interface{}{task.Status, task.ID, hi}
+	default:
+		query = `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE status = $1 AND id <> $2`
+		args = []interface{}{task.Status, task.ID}
+	}
+
+	var rank string
+	if err := tx.QueryRow(ctx, query, args...).Scan(&rank); err != nil {
+		return "", "", fmt.Errorf("failed to get neighbour rank: %w", err)
+	}
+	if position.AfterID != nil {
+		return lo, rank, nil
+	}
+	return rank, hi, nil
+}
+
+// LongRankColumns возвращает статусы колонок, в которых есть ранги длиннее maxLength.
+func (r *TaskRepository) LongRankColumns(ctx context.Context, maxLength int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Условие совпадает с частичным индексом idx_tasks_long_rank
+	rows, err := r.db.Query(ctx, `SELECT DISTINCT status FROM tasks WHERE length(rank) > $1`, maxLength)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "LongRankColumns",
+		}).WithError(err).Error("Failed to find board columns to rebalance")
+		return nil, fmt.Errorf("failed to find board columns to rebalance: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []string
+	for rows.Next() {
+		var status string
+		if err := rows.Scan(&status); err != nil {
+			return nil, fmt.Errorf("failed to scan status row: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return statuses, nil
+}
+
+func (r *TaskRepository) RebalanceRanks(ctx context.Context, status string, maxLength int) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, rebalanceTimeout)
+	defer cancel()
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Строки блокируются до колонки, в том же порядке, что и при смене статуса задачи
+	if _, err := tx.Exec(ctx, `SELECT id FROM tasks WHERE status = $1 ORDER BY id FOR UPDATE`, status); err != nil {
+		return 0, fmt.Errorf("failed to lock board column tasks: %w", err)
+	}
+	if err := lockColumn(ctx, tx, status); err != nil {
+		return 0, err
+	}
+
+	// Другая реплика могла перебалансировать колонку, пока мы ждали блокировку
+	var needed bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tasks WHERE status = $1 AND length(rank) > $2)`,
+		status, maxLength).Scan(&needed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check board column ranks: %w", err)
+	}
+	if !needed {
+		return 0, nil
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id FROM tasks WHERE status = $1 ORDER BY rank, id`, status)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list board column tasks: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan task row: %w", err)
+	}
+
+	// Ранг не отслеживается историей, а версия не меняется: порядок задач остается прежним
+	result, err := tx.Exec(ctx, `
+		UPDATE tasks SET rank = ranked.rank
+		FROM unnest($1::uuid[], $2::text[]) AS ranked (id, rank)
+		WHERE tasks.id = ranked.id`, ids, entity.EvenRanks(len(ids)))
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "RebalanceRanks",
+			"status": status,
+		}).WithError(err).Error("Failed to rebalance board column")
+		return 0, fmt.Errorf("failed to rebalance board column: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type CommentRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// commentColumns — список столбцов комментария в порядке, который ожидает scanComment.
+const commentColumns = `id, task_id, parent_id, body, created_by, created_at, edited_at`
+
+func scanComment(row pgx.Row, comment *entity.Comment) error {
+	return row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.ParentID,
+		&comment.Body,
+		&comment.CreatedBy,
+		&comment.CreatedAt,
+		&comment.EditedAt,
+	)
+}
+
+func NewCommentRepository(db *pgxpool.Pool) *CommentRepository {
+	return &CommentRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+func (r *CommentRepository) Create(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO comments (id, task_id, parent_id, body, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + commentColumns
+
+	err := scanComment(r.db.QueryRow(ctx, query,
+		comment.ID,
+		comment.TaskID,
+		comment.ParentID,
+		comment.Body,
+		comment.CreatedBy,
+		comment.CreatedAt,
+	), &comment)
+
+	if err != nil {
+		// Задача или родительский комментарий удалены после проверки в usecase
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			if pgErr.ConstraintName == "comments_parent_id_fkey" {
+				return entity.Comment{}, usecase.ErrCommentNotFound
+			}
+			return entity.Comment{}, usecase.ErrTaskNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":  "Create",
+			"task_id": comment.TaskID.String(),
+		}).WithError(err).Error("Failed to create comment")
+		return entity.Comment{}, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *CommentRepository) Get(ctx context.Context, taskID, id uuid.UUID) (entity.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE id = $1 AND task_id = $2`
+
+	var comment entity.Comment
+	if err := scanComment(r.db.QueryRow(ctx, query, id, taskID), &comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Comment{}, usecase.ErrCommentNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Get",
+			"comment_id": id.String(),
+		}).WithError(err).Error("Failed to get comment")
+		return entity.Comment{}, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *CommentRepository) Update(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE comments
+		SET body = $3, edited_at = $4
+		WHERE id = $1 AND task_id = $2
+		RETURNING ` + commentColumns
+
+	if err := scanComment(r.db.QueryRow(ctx, query,
+		comment.ID,
+		comment.TaskID,
+		comment.Body,
+		comment.EditedAt,
+	), &comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Comment{}, usecase.ErrCommentNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Update",
+			"comment_id": comment.ID.String(),
+		}).WithError(err).Error("Failed to update comment")
+		return entity.Comment{}, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *CommentRepository) Delete(ctx context.Context, taskID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Ответы на комментарий удаляются каскадно
+	result, err := r.db.Exec(ctx, `DELETE FROM comments WHERE id = $1 AND task_id = $2`, id, taskID)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Delete",
+			"comment_id": id.String(),
+		}).WithError(err).Error("Failed to delete comment")
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// List возвращает до params.Limit комментариев верхнего уровня в порядке создания,
+// начиная после params.After.
+func (r *CommentRepository) List(ctx context.Context, taskID uuid.UUID, params entity.CommentListParams) ([]entity.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE task_id = $1 AND parent_id IS NULL`
+	args := []interface{}{taskID}
+	if params.After != nil {
+		args = append(args, params.After.CreatedAt, params.After.ID)
+		query += ` AND (created_at, id) > ($2, $3)`
+	}
+	args = append(args, params.Limit)
+	query += fmt.Sprintf(` ORDER BY created_at, id LIMIT $%d`, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "List",
+			"task_id": taskID.String(),
+		}).WithError(err).Error("Failed to list comments")
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	defer rows.Close()
+
+	return collectComments(rows)
+}
+
+// Replies загружает ответы сразу на все перечисленные комментарии одним запросом.
+func (r *CommentRepository) Replies(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID][]entity.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE parent_id = ANY($1) ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, parentIDs)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "Replies",
+			"count":  len(parentIDs),
+		}).WithError(err).Error("Failed to load comment replies")
+		return nil, fmt.Errorf("failed to load comment replies: %w", err)
+	}
+	defer rows.Close()
+
+	replies, err := collectComments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byParent := make(map[uuid.UUID][]entity.Comment, len(parentIDs))
+	for _, reply := range replies {
+		byParent[*reply.ParentID] = append(byParent[*reply.ParentID], reply)
+	}
+	return byParent, nil
+}
+
+func collectComments(rows pgx.Rows) ([]entity.Comment, error) {
+	var comments []entity.Comment
+	for rows.Next() {
+		var comment entity.Comment
+		if err := scanComment(rows, &comment); err != nil {
+			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return comments, nil
+}
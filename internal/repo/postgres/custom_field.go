@@ -0,0 +1,345 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// customFieldIndexTimeout ограничивает построение индекса поля и удаление его значений у задач,
+// которые затрагивают всю таблицу tasks.
+const customFieldIndexTimeout = 10 * time.Minute
+
+type CustomFieldRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// customFieldColumns — список столбцов пользовательского поля в порядке, который ожидает scanCustomField.
+const customFieldColumns = `id, key, name, type, options, required, index_status, created_at, updated_at`
+
+func scanCustomField(row pgx.Row, field *entity.CustomField) error {
+	err := row.Scan(
+		&field.ID,
+		&field.Key,
+		&field.Name,
+		&field.Type,
+		&field.Options,
+		&field.Required,
+		&field.IndexStatus,
+		&field.CreatedAt,
+		&field.UpdatedAt,
+	)
+	if len(field.Options) == 0 {
+		field.Options = nil
+	}
+	return err
+}
+
+// customFieldIndex возвращает имя индекса значений поля. Имя строится по ID, а не по ключу,
+// чтобы не превышать ограничение PostgreSQL на длину идентификатора.
+func customFieldIndex(id uuid.UUID) string {
+	return "idx_tasks_cf_" + strings.ReplaceAll(id.String(), "-", "")
+}
+
+// customFieldOptions возвращает варианты поля для записи в столбец NOT NULL.
+func customFieldOptions(field entity.CustomField) []string {
+	if field.Options == nil {
+		return []string{}
+	}
+	return field.Options
+}
+
+func NewCustomFieldRepository(db *pgxpool.Pool) *CustomFieldRepository {
+	return &CustomFieldRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+// Create сохраняет схему поля. Индекс значений поля строится позже в фоне (BuildIndex),
+// поэтому поле создается со статусом индекса pending.
+func (r *CustomFieldRepository) Create(ctx context.Context, field entity.CustomField) (entity.CustomField, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO custom_fields (id, key, name, type, options, required, index_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 'pending', $7, $8)
+		RETURNING ` + customFieldColumns
+
+	err := scanCustomField(r.db.QueryRow(ctx, query,
+		field.ID,
+		field.Key,
+		field.Name,
+		field.Type,
+		customFieldOptions(field),
+		field.Required,
+		field.CreatedAt,
+		field.UpdatedAt,
+	), &field)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.CustomField{}, usecase.ErrCustomFieldExists
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method": "Create",
+			"key":    field.Key,
+		}).WithError(err).Error("Failed to create custom field")
+		return entity.CustomField{}, fmt.Errorf("failed to create custom field: %w", err)
+	}
+
+	return field, nil
+}
+
+// ClaimPendingIndexes берет в аренду до leaseUntil не более limit полей, индексы которых
+// ожидают построения. FOR UPDATE SKIP LOCKED и аренда не дают двум репликам строить
+// один индекс; после истечения аренды прерванное построение возобновляется.
+func (r *CustomFieldRepository) ClaimPendingIndexes(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.CustomField, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE custom_fields SET index_locked_until = $2
+		WHERE id IN (
+			SELECT id FROM custom_fields
+			WHERE index_status = 'pending'
+				AND (index_locked_until IS NULL OR index_locked_until < $1)
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + customFieldColumns
+
+	rows, err := r.db.Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "ClaimPendingIndexes",
+		}).WithError(err).Error("Failed to claim pending custom field indexes")
+		return nil, fmt.Errorf("failed to claim pending custom field indexes: %w", err)
+	}
+	defer rows.Close()
+
+	var fields []entity.CustomField
+	for rows.Next() {
+		var field entity.CustomField
+		if err := scanCustomField(rows, &field); err != nil {
+			return nil, fmt.Errorf("failed to scan custom field row: %w", err)
+		}
+		fields = append(fields, field)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return fields, nil
+}
+
+// BuildIndex строит индекс по выражению значения поля, который используют сортировка
+// и сравнения в списке задач, и записывает результат в статус индекса поля. Если построение
+// прервано отменой ctx, например при остановке сервиса, поле снова ожидает построения.
+// Если поле удалили во время построения, построенный индекс удаляется.
+func (r *CustomFieldRepository) BuildIndex(ctx context.Context, field entity.CustomField) error {
+	log := r.logger.WithFields(logrus.Fields{
+		"method":          "BuildIndex",
+		"custom_field_id": field.ID.String(),
+		"key":             field.Key,
+	})
+
+	// CONCURRENTLY не блокирует запись задач, но не может выполняться в транзакции.
+	// Выражение совпадает с customFieldExpr, ключ проверен entity.ValidCustomFieldKey.
+	indexCtx, cancel := context.WithTimeout(ctx, customFieldIndexTimeout)
+	defer cancel()
+
+	index := customFieldIndex(field.ID)
+	_, buildErr := r.db.Exec(indexCtx, `CREATE INDEX CONCURRENTLY IF NOT EXISTS `+index+
+		` ON tasks (`+customFieldExpr(field.Key)+`, id) WHERE deleted_at IS NULL`)
+	status := entity.CustomFieldIndexReady
+	if buildErr != nil {
+		status = entity.CustomFieldIndexFailed
+		if ctx.Err() != nil {
+			status = entity.CustomFieldIndexPending
+		} else {
+			log.WithError(buildErr).Error("Failed to build custom field index")
+		}
+		// Прерванное построение оставляет невалидный индекс
+		r.dropIndex(index, log)
+	}
+
+	// Статус записывается и после отмены ctx, иначе поле осталось бы в аренде до её истечения
+	statusCtx, cancelStatus := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelStatus()
+
+	tag, err := r.db.Exec(statusCtx, `
+		UPDATE custom_fields SET index_status = $2, index_locked_until = NULL
+		WHERE id = $1`, field.ID, status)
+	if err != nil {
+		log.WithError(err).Error("Failed to save custom field index status")
+		return fmt.Errorf("failed to save custom field index status: %w", err)
+	}
+	if tag.RowsAffected() == 0 && buildErr == nil {
+		log.Info("Custom field was deleted while its index was built")
+		r.dropIndex(index, log)
+	}
+
+	if buildErr != nil {
+		return fmt.Errorf("failed to build custom field index: %w", buildErr)
+	}
+	return nil
+}
+
+// dropIndex удаляет индекс поля. Ошибка только записывается в журнал: оставшийся индекс
+// не мешает работе и лишь занимает место.
+func (r *CustomFieldRepository) dropIndex(index string, log *logrus.Entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), customFieldIndexTimeout)
+	defer cancel()
+
+	if _, err := r.db.Exec(ctx, `DROP INDEX CONCURRENTLY IF EXISTS `+index); err != nil {
+		log.WithError(err).Warn("Failed to drop custom field index")
+	}
+}
+
+func (r *CustomFieldRepository) List(ctx context.Context) ([]entity.CustomField, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `SELECT `+customFieldColumns+` FROM custom_fields ORDER BY key`)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "List",
+		}).WithError(err).Error("Failed to list custom fields")
+		return nil, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+	defer rows.Close()
+
+	var fields []entity.CustomField
+	for rows.Next() {
+		var field entity.CustomField
+		if err := scanCustomField(rows, &field); err != nil {
+			return nil, fmt.Errorf("failed to scan custom field row: %w", err)
+		}
+		fields = append(fields, field)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) Get(ctx context.Context, id uuid.UUID) (entity.CustomField, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var field entity.CustomField
+	err := scanCustomField(r.db.QueryRow(ctx, `SELECT `+customFieldColumns+` FROM custom_fields WHERE id = $1`, id), &field)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.CustomField{}, usecase.ErrCustomFieldNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":          "Get",
+			"custom_field_id": id.String(),
+		}).WithError(err).Error("Failed to get custom field")
+		return entity.CustomField{}, fmt.Errorf("failed to get custom field: %w", err)
+	}
+
+	return field, nil
+}
+
+func (r *CustomFieldRepository) Update(ctx context.Context, field entity.CustomField) (entity.CustomField, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE custom_fields
+		SET name = $2, options = $3, required = $4, updated_at = $5
+		WHERE id = $1
+		RETURNING ` + customFieldColumns
+
+	err := scanCustomField(r.db.QueryRow(ctx, query,
+		field.ID,
+		field.Name,
+		customFieldOptions(field),
+		field.Required,
+		field.UpdatedAt,
+	), &field)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.CustomField{}, usecase.ErrCustomFieldNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":          "Update",
+			"custom_field_id": field.ID.String(),
+		}).WithError(err).Error("Failed to update custom field")
+		return entity.CustomField{}, fmt.Errorf("failed to update custom field: %w", err)
+	}
+
+	return field, nil
+}
+
+// Delete удаляет схему поля и его значения у задач в одной транзакции, после чего удаляет
+// индекс поля. Удаление значений не записывается в историю задач: это следствие изменения
+// схемы, а не правка задачи, и событие на каждую задачу со значением поля засорило бы историю.
+func (r *CustomFieldRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, customFieldIndexTimeout)
+	defer cancel()
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var key string
+	err = tx.QueryRow(ctx, `DELETE FROM custom_fields WHERE id = $1 RETURNING key`, id).Scan(&key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecase.ErrCustomFieldNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":          "Delete",
+			"custom_field_id": id.String(),
+		}).WithError(err).Error("Failed to delete custom field")
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT set_config('task_history.skip', 'on', true)`); err != nil {
+		return fmt.Errorf("failed to disable task history: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE tasks
+		SET custom_fields = custom_fields - $1::text, version = version + 1, updated_at = $2
+		WHERE custom_fields ? $1::text`, key, time.Now())
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "Delete",
+			"key":    key,
+		}).WithError(err).Error("Failed to remove custom field values")
+		return fmt.Errorf("failed to remove custom field values: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.dropIndex(customFieldIndex(id), r.logger.WithFields(logrus.Fields{
+		"method": "Delete",
+		"key":    key,
+	}))
+
+	return nil
+}
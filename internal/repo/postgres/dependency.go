@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// dependencyLockKey — ключ advisory-блокировки, сериализующей добавление зависимостей.
+// Без неё две встречные связи, добавленные одновременно, могли бы образовать цикл.
+const dependencyLockKey = 7_243_002
+
+func (r *TaskRepository) AddDependency(ctx context.Context, dep entity.TaskDependency) (entity.TaskDependency, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return entity.TaskDependency{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, dependencyLockKey); err != nil {
+		return entity.TaskDependency{}, fmt.Errorf("failed to lock dependency graph: %w", err)
+	}
+
+	var found int
+	err = tx.QueryRow(ctx, `SELECT count(*) FROM tasks WHERE id IN ($1, $2) AND deleted_at IS NULL`,
+		dep.BlockerID, dep.BlockedID).Scan(&found)
+	if err != nil {
+		return entity.TaskDependency{}, fmt.Errorf("failed to check tasks: %w", err)
+	}
+	if found != 2 {
+		return entity.TaskDependency{}, usecase.ErrTaskNotFound
+	}
+
+	// Новая связь замыкает цикл, если блокирующая задача уже достижима из блокируемой
+	var cycle bool
+	err = tx.QueryRow(ctx, `
+		WITH RECURSIVE reachable AS (
+			SELECT blocked_id AS id FROM task_dependencies WHERE blocker_id = $1
+			UNION
+			SELECT d.blocked_id FROM task_dependencies d JOIN reachable r ON d.blocker_id = r.id
+		)
+		SELECT EXISTS (SELECT 1 FROM reachable WHERE id = $2)`,
+		dep.BlockedID, dep.BlockerID).Scan(&cycle)
+	if err != nil {
+		return entity.TaskDependency{}, fmt.Errorf("failed to check dependency cycle: %w", err)
+	}
+	if cycle {
+		return entity.TaskDependency{}, usecase.ErrDependencyCycle
+	}
+
+	// Повторное добавление существующей связи ничего не меняет
+	err = tx.QueryRow(ctx, `
+		INSERT INTO task_dependencies (blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO UPDATE SET blocker_id = EXCLUDED.blocker_id
+		RETURNING created_at`,
+		dep.BlockerID, dep.BlockedID, dep.CreatedAt).Scan(&dep.CreatedAt)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "AddDependency",
+			"blocker_id": dep.BlockerID.String(),
+			"blocked_id": dep.BlockedID.String(),
+		}).WithError(err).Error("Failed to add dependency")
+		return entity.TaskDependency{}, fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entity.TaskDependency{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return dep, nil
+}
+
+func (r *TaskRepository) RemoveDependency(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.Exec(ctx, `DELETE FROM task_dependencies WHERE blocker_id = $1 AND blocked_id = $2`,
+		blockerID, blockedID)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "RemoveDependency",
+			"blocker_id": blockerID.String(),
+			"blocked_id": blockedID.String(),
+		}).WithError(err).Error("Failed to remove dependency")
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrDependencyNotFound
+	}
+	return nil
+}
+
+func (r *TaskRepository) OpenBlockers(ctx context.Context, id uuid.UUID, completed []string) ([]uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if completed == nil {
+		completed = []string{} // NULL в ANY исключил бы все строки
+	}
+
+	// Задачи в корзине работу не блокируют
+	rows, err := r.db.Query(ctx, `
+		SELECT t.id FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocker_id
+		WHERE d.blocked_id = $1 AND t.deleted_at IS NULL AND NOT (t.status = ANY($2))
+		ORDER BY t.id`, id, completed)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "OpenBlockers",
+			"task_id": id.String(),
+		}).WithError(err).Error("Failed to list open blockers")
+		return nil, fmt.Errorf("failed to list open blockers: %w", err)
+	}
+	defer rows.Close()
+
+	var blockers []uuid.UUID
+	for rows.Next() {
+		var blocker uuid.UUID
+		if err := rows.Scan(&blocker); err != nil {
+			return nil, fmt.Errorf("failed to scan blocker: %w", err)
+		}
+		blockers = append(blockers, blocker)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return blockers, nil
+}
+
+func (r *TaskRepository) DependencyGraph(ctx context.Context, id uuid.UUID, depth int) (entity.TaskGraph, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Обходим граф в обе стороны: к блокирующим задачам и к задачам, которые блокирует данная
+	rows, err := r.db.Query(ctx, `
+		WITH RECURSIVE upstream AS (
+			SELECT blocker_id, blocked_id, created_at, 1 AS depth FROM task_dependencies WHERE blocked_id = $1
+			UNION
+			SELECT d.blocker_id, d.blocked_id, d.created_at, u.depth + 1
+			FROM task_dependencies d JOIN upstream u ON d.blocked_id = u.blocker_id
+			WHERE u.depth < $2
+		), downstream AS (
+			SELECT blocker_id, blocked_id, created_at, 1 AS depth FROM task_dependencies WHERE blocker_id = $1
+			UNION
+			SELECT d.blocker_id, d.blocked_id, d.created_at, dn.depth + 1
+			FROM task_dependencies d JOIN downstream dn ON d.blocker_id = dn.blocked_id
+			WHERE dn.depth < $2
+		)
+		SELECT DISTINCT blocker_id, blocked_id, created_at FROM upstream
+		UNION
+		SELECT DISTINCT blocker_id, blocked_id, created_at FROM downstream`, id, depth)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "DependencyGraph",
+			"task_id": id.String(),
+		}).WithError(err).Error("Failed to load dependency graph")
+		return entity.TaskGraph{}, fmt.Errorf("failed to load dependency graph: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []entity.TaskDependency
+	ids := []uuid.UUID{id}
+	seen := map[uuid.UUID]bool{id: true}
+	for rows.Next() {
+		var edge entity.TaskDependency
+		if err := rows.Scan(&edge.BlockerID, &edge.BlockedID, &edge.CreatedAt); err != nil {
+			return entity.TaskGraph{}, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		edges = append(edges, edge)
+		for _, node := range []uuid.UUID{edge.BlockerID, edge.BlockedID} {
+			if !seen[node] {
+				seen[node] = true
+				ids = append(ids, node)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return entity.TaskGraph{}, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	rows.Close()
+
+	nodeRows, err := r.db.Query(ctx, `SELECT `+taskColumns+` FROM tasks
+		WHERE id = ANY($1) AND deleted_at IS NULL
+		ORDER BY created_at, id`, ids)
+	if err != nil {
+		return entity.TaskGraph{}, fmt.Errorf("failed to load graph tasks: %w", err)
+	}
+	defer nodeRows.Close()
+
+	graph := entity.TaskGraph{Nodes: []entity.Task{}, Edges: []entity.TaskDependency{}}
+	alive := make(map[uuid.UUID]bool, len(ids))
+	for nodeRows.Next() {
+		var task entity.Task
+		if err := scanTask(nodeRows, &task); err != nil {
+			return entity.TaskGraph{}, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		alive[task.ID] = true
+		graph.Nodes = append(graph.Nodes, task)
+	}
+	if err := nodeRows.Err(); err != nil {
+		return entity.TaskGraph{}, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	if !alive[id] {
+		return entity.TaskGraph{}, usecase.ErrTaskNotFound
+	}
+
+	// Связи с задачами из корзины в граф не попадают
+	for _, edge := range edges {
+		if alive[edge.BlockerID] && alive[edge.BlockedID] {
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+	return graph, nil
+}
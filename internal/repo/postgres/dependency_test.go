@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestTaskRepositoryAddDependencyCycle(t *testing.T) {
+	repo := NewTaskRepository(testPool(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Связи добавляются по порядку, каждая проверяется на графе из уже добавленных
+	tests := []struct {
+		name             string
+		blocker, blocked int
+		wantErr          error
+	}{
+		{name: "first edge", blocker: 0, blocked: 1},
+		{name: "chain", blocker: 1, blocked: 2},
+		{name: "closes three-task cycle", blocker: 2, blocked: 0, wantErr: usecase.ErrDependencyCycle},
+		{name: "reverses an edge", blocker: 1, blocked: 0, wantErr: usecase.ErrDependencyCycle},
+		{name: "transitive shortcut", blocker: 0, blocked: 2},
+		{name: "duplicate edge", blocker: 0, blocked: 1},
+		{name: "diamond", blocker: 3, blocked: 2},
+		{name: "closes cycle through diamond", blocker: 2, blocked: 3, wantErr: usecase.ErrDependencyCycle},
+		{name: "extends chain", blocker: 2, blocked: 4},
+		{name: "closes long cycle", blocker: 4, blocked: 0, wantErr: usecase.ErrDependencyCycle},
+		{name: "unrelated tasks", blocker: 3, blocked: 1},
+	}
+
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		task, err := repo.Create(ctx, entity.Task{ID: uuid.New(), Title: "Dependency check", Status: "todo"})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		ids[i] = task.ID
+	}
+	t.Cleanup(func() {
+		repo.db.Exec(context.Background(), `DELETE FROM tasks WHERE id = ANY($1)`, ids)
+	})
+
+	for _, tt := range tests {
+		_, err := repo.AddDependency(ctx, entity.TaskDependency{
+			BlockerID: ids[tt.blocker],
+			BlockedID: ids[tt.blocked],
+			CreatedAt: time.Now(),
+		})
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: AddDependency(%d blocks %d) error = %v, want %v", tt.name, tt.blocker, tt.blocked, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// HistoryRepository читает историю задач. Записи в task_history добавляет триггер
+// при каждом изменении строки tasks.
+type HistoryRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+func NewHistoryRepository(db *pgxpool.Pool) *HistoryRepository {
+	return &HistoryRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+// beginAudited начинает транзакцию и передает триггеру истории задач Audit из ctx.
+// Параметры заданы только на время транзакции, поэтому не переходят к следующему
+// пользователю соединения из пула.
+func beginAudited(ctx context.Context, db *pgxpool.Pool) (pgx.Tx, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	audit := usecase.AuditFromContext(ctx)
+	revertedTo := ""
+	if audit.RevertedTo != 0 {
+		revertedTo = strconv.FormatInt(audit.RevertedTo, 10)
+	}
+	_, err = tx.Exec(ctx, `
+		SELECT set_config('task_history.actor', $1, true),
+			set_config('task_history.request_id', $2, true),
+			set_config('task_history.reverted_to', $3, true)`,
+		audit.Actor, audit.RequestID, revertedTo)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("failed to set audit parameters: %w", err)
+	}
+	return tx, nil
+}
+
+func (r *HistoryRepository) List(ctx context.Context, taskID uuid.UUID, params entity.TaskHistoryParams) ([]entity.TaskHistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT task_id, revision, version, action, changes, actor, request_id, reverted_to, created_at
+		FROM task_history
+		WHERE task_id = $1 AND ($2::bigint = 0 OR revision < $2)
+		ORDER BY revision DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, query, taskID, params.Before, params.Limit)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "List",
+			"task_id": taskID.String(),
+		}).WithError(err).Error("Failed to list task history")
+		return nil, fmt.Errorf("failed to list task history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []entity.TaskHistoryEntry
+	for rows.Next() {
+		var entry entity.TaskHistoryEntry
+		var actor, requestID *string
+		if err := rows.Scan(
+			&entry.TaskID,
+			&entry.Revision,
+			&entry.Version,
+			&entry.Action,
+			&entry.Changes,
+			&actor,
+			&requestID,
+			&entry.RevertedTo,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan history row: %w", err)
+		}
+		if actor != nil {
+			entry.Actor = *actor
+		}
+		if requestID != nil {
+			entry.RequestID = *requestID
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *HistoryRepository) Snapshot(ctx context.Context, taskID uuid.UUID, revision int64) (entity.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Снимок содержит только отслеживаемые поля, остальные jsonb_populate_record берет из текущей строки
+	query := `
+		SELECT ` + taskColumns + ` FROM (
+			SELECT (jsonb_populate_record(t, h.snapshot)).*
+			FROM tasks t
+			JOIN task_history h ON h.task_id = t.id
+			WHERE t.id = $1 AND h.revision = $2
+		) AS revision`
+
+	var task entity.Task
+	if err := scanTask(r.db.QueryRow(ctx, query, taskID, revision), &task); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Task{}, usecase.ErrRevisionNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":   "Snapshot",
+			"task_id":  taskID.String(),
+			"revision": revision,
+		}).WithError(err).Error("Failed to get task revision")
+		return entity.Task{}, fmt.Errorf("failed to get task revision: %w", err)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type LabelRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// labelColumns — список столбцов метки в порядке, который ожидает scanLabel.
+const labelColumns = `id, name, color, created_at`
+
+func scanLabel(row pgx.Row, label *entity.Label) error {
+	return row.Scan(
+		&label.ID,
+		&label.Name,
+		&label.Color,
+		&label.CreatedAt,
+	)
+}
+
+func NewLabelRepository(db *pgxpool.Pool) *LabelRepository {
+	return &LabelRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+func (r *LabelRepository) Create(ctx context.Context, label entity.Label) (entity.Label, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO labels (id, name, color, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + labelColumns
+
+	err := scanLabel(r.db.QueryRow(ctx, query,
+		label.ID,
+		label.Name,
+		label.Color,
+		label.CreatedAt,
+	), &label)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.Label{}, usecase.ErrLabelExists
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method": "Create",
+			"name":   label.Name,
+		}).WithError(err).Error("Failed to create label")
+		return entity.Label{}, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	return label, nil
+}
+
+func (r *LabelRepository) List(ctx context.Context) ([]entity.Label, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `SELECT `+labelColumns+` FROM labels ORDER BY name, id`)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "List",
+		}).WithError(err).Error("Failed to list labels")
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []entity.Label
+	for rows.Next() {
+		var label entity.Label
+		if err := scanLabel(rows, &label); err != nil {
+			return nil, fmt.Errorf("failed to scan label row: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (r *LabelRepository) Update(ctx context.Context, label entity.Label) (entity.Label, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE labels
+		SET name = $2, color = $3
+		WHERE id = $1
+		RETURNING ` + labelColumns
+
+	if err := scanLabel(r.db.QueryRow(ctx, query, label.ID, label.Name, label.Color), &label); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Label{}, usecase.ErrLabelNotFound
+		}
+		if isUniqueViolation(err) {
+			return entity.Label{}, usecase.ErrLabelExists
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":   "Update",
+			"label_id": label.ID.String(),
+		}).WithError(err).Error("Failed to update label")
+		return entity.Label{}, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	return label, nil
+}
+
+func (r *LabelRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":   "Delete",
+			"label_id": id,
+		}).WithError(err).Warn("Invalid label ID format")
+		return entity.InvalidID("label")
+	}
+
+	// Связи с задачами удаляются каскадно
+	result, err := r.db.Exec(ctx, `DELETE FROM labels WHERE id = $1`, parsedID)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":   "Delete",
+			"label_id": id,
+		}).WithError(err).Error("Failed to delete label")
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrLabelNotFound
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) Attach(ctx context.Context, taskID, labelID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Повторное назначение метки ничего не меняет; задачи в корзине не изменяются
+	query := `
+		WITH task AS (
+			SELECT id FROM tasks WHERE id = $1 AND deleted_at IS NULL
+		), attached AS (
+			INSERT INTO task_labels (task_id, label_id)
+			SELECT id, $2 FROM task
+			ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM task)`
+
+	var found bool
+	if err := r.db.QueryRow(ctx, query, taskID, labelID).Scan(&found); err != nil {
+		if isForeignKeyViolation(err) {
+			return usecase.ErrLabelNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":   "Attach",
+			"task_id":  taskID.String(),
+			"label_id": labelID.String(),
+		}).WithError(err).Error("Failed to attach label")
+		return fmt.Errorf("failed to attach label: %w", err)
+	}
+	if !found {
+		return usecase.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) Detach(ctx context.Context, taskID, labelID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.Exec(ctx, `DELETE FROM task_labels WHERE task_id = $1 AND label_id = $2`, taskID, labelID)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":   "Detach",
+			"task_id":  taskID.String(),
+			"label_id": labelID.String(),
+		}).WithError(err).Error("Failed to detach label")
+		return fmt.Errorf("failed to detach label: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrLabelNotAttached
+	}
+
+	return nil
+}
+
+// ByTasks загружает метки сразу всех перечисленных задач одним запросом.
+func (r *LabelRepository) ByTasks(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]entity.Label, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT tl.task_id, l.id, l.name, l.color, l.created_at
+		FROM task_labels tl
+		JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id = ANY($1)
+		ORDER BY l.name, l.id`
+
+	rows, err := r.db.Query(ctx, query, taskIDs)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "ByTasks",
+			"count":  len(taskIDs),
+		}).WithError(err).Error("Failed to load task labels")
+		return nil, fmt.Errorf("failed to load task labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := make(map[uuid.UUID][]entity.Label, len(taskIDs))
+	for rows.Next() {
+		var taskID uuid.UUID
+		var label entity.Label
+		if err := rows.Scan(&taskID, &label.ID, &label.Name, &label.Color, &label.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task label row: %w", err)
+		}
+		labels[taskID] = append(labels[taskID], label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// projectColumns — список столбцов проекта в порядке, который ожидает scanProject.
+const projectColumns = `id, name, description, created_at, updated_at`
+
+func scanProject(row pgx.Row, project *entity.Project) error {
+	return row.Scan(
+		&project.ID,
+		&project.Name,
+		&project.Description,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+}
+
+func NewProjectRepository(db *pgxpool.Pool) *ProjectRepository {
+	return &ProjectRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, project entity.Project, owner string) (entity.Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO projects (id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + projectColumns
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return entity.Project{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = scanProject(tx.QueryRow(ctx, query,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.CreatedAt,
+		project.UpdatedAt,
+	), &project)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.Project{}, usecase.ErrProjectExists
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method": "Create",
+			"name":   project.Name,
+		}).WithError(err).Error("Failed to create project")
+		return entity.Project{}, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	if owner != "" {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO project_members (project_id, subject, role, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $4)`, project.ID, owner, entity.RoleOwner, project.CreatedAt)
+		if err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"method":     "Create",
+				"project_id": project.ID.String(),
+			}).WithError(err).Error("Failed to add project owner")
+			return entity.Project{}, fmt.Errorf("failed to add project owner: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entity.Project{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) Get(ctx context.Context, id string) (entity.Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Get",
+			"project_id": id,
+		}).WithError(err).Warn("Invalid project ID format")
+		return entity.Project{}, entity.InvalidID("project")
+	}
+
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = $1`
+
+	var project entity.Project
+	if err := scanProject(r.db.QueryRow(ctx, query, parsedID), &project); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Project{}, usecase.ErrProjectNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Get",
+			"project_id": id,
+		}).WithError(err).Error("Failed to get project")
+		return entity.Project{}, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) List(ctx context.Context, visibleTo string) ([]entity.Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + projectColumns + ` FROM projects`
+	var args []interface{}
+	if visibleTo != "" {
+		query += ` WHERE id IN (SELECT project_id FROM project_members WHERE subject = $1)`
+		args = append(args, visibleTo)
+	}
+	query += ` ORDER BY name, id`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "List",
+		}).WithError(err).Error("Failed to list projects")
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []entity.Project
+	for rows.Next() {
+		var project entity.Project
+		if err := scanProject(rows, &project); err != nil {
+			return nil, fmt.Errorf("failed to scan project row: %w", err)
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return projects, nil
+}
+
+func (r *ProjectRepository) Update(ctx context.Context, project entity.Project) (entity.Project, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE projects
+		SET name = $2, description = $3, updated_at = $4
+		WHERE id = $1
+		RETURNING ` + projectColumns
+
+	err := scanProject(r.db.QueryRow(ctx, query,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.UpdatedAt,
+	), &project)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Project{}, usecase.ErrProjectNotFound
+		}
+		if isUniqueViolation(err) {
+			return entity.Project{}, usecase.ErrProjectExists
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Update",
+			"project_id": project.ID.String(),
+		}).WithError(err).Error("Failed to update project")
+		return entity.Project{}, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Delete",
+			"project_id": id,
+		}).WithError(err).Warn("Invalid project ID format")
+		return entity.InvalidID("project")
+	}
+
+	result, err := r.db.Exec(ctx, `DELETE FROM projects WHERE id = $1`, parsedID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return usecase.ErrProjectNotEmpty
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Delete",
+			"project_id": id,
+		}).WithError(err).Error("Failed to delete project")
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func (r *ProjectRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var found bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM projects WHERE id = $1)`, id).Scan(&found); err != nil {
+		return false, fmt.Errorf("failed to check project existence: %w", err)
+	}
+	return found, nil
+}
+
+// isUniqueViolation проверяет, нарушает ли запрос ограничение уникальности.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// isForeignKeyViolation проверяет, нарушает ли запрос внешний ключ.
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectMemberRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// projectMemberColumns — список столбцов участника проекта в порядке, который ожидает scanProjectMember.
+const projectMemberColumns = `project_id, subject, role, created_at, updated_at`
+
+func scanProjectMember(row pgx.Row, member *entity.ProjectMember) error {
+	return row.Scan(
+		&member.ProjectID,
+		&member.Subject,
+		&member.Role,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+}
+
+func NewProjectMemberRepository(db *pgxpool.Pool) *ProjectMemberRepository {
+	return &ProjectMemberRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+func (r *ProjectMemberRepository) Role(ctx context.Context, projectID uuid.UUID, subject string) (entity.ProjectRole, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var role entity.ProjectRole
+	err := r.db.QueryRow(ctx, `SELECT role FROM project_members WHERE project_id = $1 AND subject = $2`,
+		projectID, subject).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Role",
+			"project_id": projectID.String(),
+		}).WithError(err).Error("Failed to get project role")
+		return "", fmt.Errorf("failed to get project role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (r *ProjectMemberRepository) List(ctx context.Context, projectID uuid.UUID) ([]entity.ProjectMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Query(ctx, `SELECT `+projectMemberColumns+` FROM project_members
+		WHERE project_id = $1 ORDER BY subject`, projectID)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "List",
+			"project_id": projectID.String(),
+		}).WithError(err).Error("Failed to list project members")
+		return nil, fmt.Errorf("failed to list project members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []entity.ProjectMember
+	for rows.Next() {
+		var member entity.ProjectMember
+		if err := scanProjectMember(rows, &member); err != nil {
+			return nil, fmt.Errorf("failed to scan project member row: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *ProjectMemberRepository) Set(ctx context.Context, member entity.ProjectMember) (entity.ProjectMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return entity.ProjectMember{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if member.Role != entity.RoleOwner {
+		if err := r.keepOwner(ctx, tx, member.ProjectID, member.Subject); err != nil {
+			return entity.ProjectMember{}, err
+		}
+	}
+
+	query := `
+		INSERT INTO project_members (project_id, subject, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (project_id, subject) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+		RETURNING ` + projectMemberColumns
+
+	err = scanProjectMember(tx.QueryRow(ctx, query,
+		member.ProjectID,
+		member.Subject,
+		member.Role,
+		member.CreatedAt,
+		member.UpdatedAt,
+	), &member)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return entity.ProjectMember{}, usecase.ErrProjectNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Set",
+			"project_id": member.ProjectID.String(),
+		}).WithError(err).Error("Failed to set project member")
+		return entity.ProjectMember{}, fmt.Errorf("failed to set project member: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entity.ProjectMember{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return member, nil
+}
+
+func (r *ProjectMemberRepository) Remove(ctx context.Context, projectID uuid.UUID, subject string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.keepOwner(ctx, tx, projectID, subject); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM project_members WHERE project_id = $1 AND subject = $2`, projectID, subject)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":     "Remove",
+			"project_id": projectID.String(),
+		}).WithError(err).Error("Failed to remove project member")
+		return fmt.Errorf("failed to remove project member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrProjectMemberNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// keepOwner блокирует владельцев проекта и возвращает ErrLastProjectOwner, если subject —
+// единственный владелец и теряет эту роль.
+func (r *ProjectMemberRepository) keepOwner(ctx context.Context, tx pgx.Tx, projectID uuid.UUID, subject string) error {
+	rows, err := tx.Query(ctx, `SELECT subject FROM project_members
+		WHERE project_id = $1 AND role = $2 FOR UPDATE`, projectID, entity.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to lock project owners: %w", err)
+	}
+	defer rows.Close()
+
+	var owners []string
+	for rows.Next() {
+		var owner string
+		if err := rows.Scan(&owner); err != nil {
+			return fmt.Errorf("failed to scan project owner: %w", err)
+		}
+		owners = append(owners, owner)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	if len(owners) == 1 && owners[0] == subject {
+		return usecase.ErrLastProjectOwner
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type RecurrenceRepository struct {
+	db     *pgxpool.Pool
+	logger *logrus.Logger
+}
+
+// recurrenceColumns — список столбцов серии в порядке, который ожидает scanRecurrence.
+const recurrenceColumns = `id, template_id, rrule, starts_at, timezone, paused, next_run_at, last_run_at, created_at, updated_at`
+
+func scanRecurrence(row pgx.Row, recurrence *entity.Recurrence) error {
+	return row.Scan(
+		&recurrence.ID,
+		&recurrence.TemplateID,
+		&recurrence.RRule,
+		&recurrence.StartsAt,
+		&recurrence.Timezone,
+		&recurrence.Paused,
+		&recurrence.NextRunAt,
+		&recurrence.LastRunAt,
+		&recurrence.CreatedAt,
+		&recurrence.UpdatedAt,
+	)
+}
+
+func NewRecurrenceRepository(db *pgxpool.Pool) *RecurrenceRepository {
+	return &RecurrenceRepository{
+		db:     db,
+		logger: logger.Log,
+	}
+}
+
+// Upsert создает серию задачи-шаблона или заменяет её правило. У существующей серии
+// сохраняются ID, признак паузы и время создания, а аренда сбрасывается.
+func (r *RecurrenceRepository) Upsert(ctx context.Context, recurrence entity.Recurrence) (entity.Recurrence, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO task_recurrences (id, template_id, rrule, starts_at, timezone, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (template_id) DO UPDATE
+		SET rrule = EXCLUDED.rrule,
+			starts_at = EXCLUDED.starts_at,
+			timezone = EXCLUDED.timezone,
+			next_run_at = EXCLUDED.next_run_at,
+			locked_until = NULL,
+			updated_at = EXCLUDED.updated_at
+		RETURNING ` + recurrenceColumns
+
+	err := scanRecurrence(r.db.QueryRow(ctx, query,
+		recurrence.ID,
+		recurrence.TemplateID,
+		recurrence.RRule,
+		recurrence.StartsAt,
+		recurrence.Timezone,
+		recurrence.NextRunAt,
+		recurrence.UpdatedAt,
+	), &recurrence)
+
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return entity.Recurrence{}, usecase.ErrTaskNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":      "Upsert",
+			"template_id": recurrence.TemplateID.String(),
+		}).WithError(err).Error("Failed to save recurrence")
+		return entity.Recurrence{}, fmt.Errorf("failed to save recurrence: %w", err)
+	}
+
+	return recurrence, nil
+}
+
+func (r *RecurrenceRepository) GetByTemplate(ctx context.Context, templateID uuid.UUID) (entity.Recurrence, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + recurrenceColumns + ` FROM task_recurrences WHERE template_id = $1`
+
+	var recurrence entity.Recurrence
+	if err := scanRecurrence(r.db.QueryRow(ctx, query, templateID), &recurrence); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Recurrence{}, usecase.ErrRecurrenceNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":      "GetByTemplate",
+			"template_id": templateID.String(),
+		}).WithError(err).Error("Failed to get recurrence")
+		return entity.Recurrence{}, fmt.Errorf("failed to get recurrence: %w", err)
+	}
+
+	return recurrence, nil
+}
+
+func (r *RecurrenceRepository) DeleteByTemplate(ctx context.Context, templateID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.Exec(ctx, `DELETE FROM task_recurrences WHERE template_id = $1`, templateID)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":      "DeleteByTemplate",
+			"template_id": templateID.String(),
+		}).WithError(err).Error("Failed to delete recurrence")
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return usecase.ErrRecurrenceNotFound
+	}
+
+	return nil
+}
+
+// Pause приостанавливает серию; время следующего экземпляра не меняется.
+func (r *RecurrenceRepository) Pause(ctx context.Context, templateID uuid.UUID) (entity.Recurrence, error) {
+	return r.setPaused(ctx, "Pause", `
+		UPDATE task_recurrences
+		SET paused = true, locked_until = NULL, updated_at = $2
+		WHERE template_id = $1
+		RETURNING `+recurrenceColumns, templateID, time.Now().UTC())
+}
+
+// Resume снимает паузу и переносит серию на nextRunAt.
+func (r *RecurrenceRepository) Resume(ctx context.Context, templateID uuid.UUID, nextRunAt *time.Time) (entity.Recurrence, error) {
+	return r.setPaused(ctx, "Resume", `
+		UPDATE task_recurrences
+		SET paused = false, next_run_at = $3, locked_until = NULL, updated_at = $2
+		WHERE template_id = $1
+		RETURNING `+recurrenceColumns, templateID, time.Now().UTC(), nextRunAt)
+}
+
+func (r *RecurrenceRepository) setPaused(ctx context.Context, method, query string, templateID uuid.UUID, args ...interface{}) (entity.Recurrence, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var recurrence entity.Recurrence
+	if err := scanRecurrence(r.db.QueryRow(ctx, query, append([]interface{}{templateID}, args...)...), &recurrence); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Recurrence{}, usecase.ErrRecurrenceNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":      method,
+			"template_id": templateID.String(),
+		}).WithError(err).Error("Failed to update recurrence")
+		return entity.Recurrence{}, fmt.Errorf("failed to update recurrence: %w", err)
+	}
+
+	return recurrence, nil
+}
+
+// ClaimDue берет в аренду до limit активных серий, экземпляры которых наступили к now.
+// Серии шаблонов из корзины пропускаются до восстановления шаблона.
+// FOR UPDATE SKIP LOCKED и аренда до leaseUntil не дают двум репликам взять одну серию.
+func (r *RecurrenceRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.Recurrence, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE task_recurrences SET locked_until = $2
+		WHERE id IN (
+			SELECT id FROM task_recurrences
+			WHERE NOT paused AND next_run_at <= $1
+				AND (locked_until IS NULL OR locked_until < $1)
+				AND EXISTS (
+					SELECT 1 FROM tasks t
+					WHERE t.id = task_recurrences.template_id AND t.deleted_at IS NULL
+				)
+			ORDER BY next_run_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + recurrenceColumns
+
+	rows, err := r.db.Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "ClaimDue",
+		}).WithError(err).Error("Failed to claim due recurrences")
+		return nil, fmt.Errorf("failed to claim due recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var recurrences []entity.Recurrence
+	for rows.Next() {
+		var recurrence entity.Recurrence
+		if err := scanRecurrence(rows, &recurrence); err != nil {
+			return nil, fmt.Errorf("failed to scan recurrence row: %w", err)
+		}
+		recurrences = append(recurrences, recurrence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	return recurrences, nil
+}
+
+// Advance записывает созданный экземпляр и время следующего и снимает аренду.
+// Если аренда истекла или серию изменили после ClaimDue, ничего не меняет и возвращает false.
+func (r *RecurrenceRepository) Advance(ctx context.Context, claimed entity.Recurrence, leaseUntil time.Time, lastRunAt, nextRunAt *time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.Exec(ctx, `
+		UPDATE task_recurrences
+		SET last_run_at = COALESCE($4, last_run_at), next_run_at = $5, locked_until = NULL
+		WHERE id = $1 AND locked_until = $2 AND updated_at = $3`,
+		claimed.ID, leaseUntil, claimed.UpdatedAt, lastRunAt, nextRunAt,
+	)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":        "Advance",
+			"recurrence_id": claimed.ID.String(),
+		}).WithError(err).Error("Failed to advance recurrence")
+		return false, fmt.Errorf("failed to advance recurrence: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+// hierarchyLockKey — ключ advisory-блокировки, сериализующей перемещения задач.
+// Без неё два встречных перемещения могли бы образовать цикл.
+const hierarchyLockKey = 7_243_001
+
+// querier — общее подмножество методов пула соединений и транзакции.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+func (r *TaskRepository) Children(ctx context.Context, id uuid.UUID) ([]entity.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + taskColumns + ` FROM tasks
+		WHERE parent_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "Children",
+			"task_id": id.String(),
+		}).WithError(err).Error("Failed to list child tasks")
+		return nil, fmt.Errorf("failed to list child tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []entity.Task
+	for rows.Next() {
+		var task entity.Task
+		if err := scanTask(rows, &task); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *TaskRepository) Subtree(ctx context.Context, id uuid.UUID) ([]entity.TaskNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Путь из времени создания и ID задает обход в глубину с братьями в порядке создания.
+	// Удаленные задачи и их поддеревья не выводятся
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id, 0 AS depth, ARRAY[to_char(created_at, 'YYYYMMDDHH24MISSUS') || id::text] AS path
+			FROM tasks
+			WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, s.depth + 1, s.path || (to_char(t.created_at, 'YYYYMMDDHH24MISSUS') || t.id::text)
+			FROM tasks t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE t.deleted_at IS NULL AND s.depth < $2
+		)
+		SELECT ` + taskColumns + `, subtree.depth
+		FROM subtree JOIN tasks USING (id)
+		ORDER BY subtree.path`
+
+	rows, err := r.db.Query(ctx, query, id, entity.MaxTaskDepth)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "Subtree",
+			"task_id": id.String(),
+		}).WithError(err).Error("Failed to load task subtree")
+		return nil, fmt.Errorf("failed to load task subtree: %w", err)
+	}
+	defer rows.Close()
+
+	var nodes []entity.TaskNode
+	for rows.Next() {
+		var node entity.TaskNode
+		if err := rows.Scan(append(taskFields(&node.Task), &node.Depth)...); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, usecase.ErrTaskNotFound
+	}
+
+	return nodes, nil
+}
+
+func (r *TaskRepository) Placement(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (entity.TaskPlacement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return placementOf(ctx, r.db, id, parentID)
+}
+
+func (r *TaskRepository) MoveFunc(ctx context.Context, id string, version int64, parentID *uuid.UUID,
+	fn func(task *entity.Task, placement entity.TaskPlacement) error) (entity.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "MoveFunc",
+			"task_id": id,
+		}).WithError(err).Warn("Invalid task ID format")
+		return entity.Task{}, entity.InvalidID("task")
+	}
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, hierarchyLockKey); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to lock task hierarchy: %w", err)
+	}
+
+	task, err := updateLocked(ctx, tx, parsedID, version, func(task *entity.Task) error {
+		placement, err := placementOf(ctx, tx, task.ID, parentID)
+		if err != nil {
+			return err
+		}
+		return fn(task, placement)
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "MoveFunc",
+			"task_id": id,
+		}).WithError(err).Warn("Failed to move task")
+		return entity.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return task, nil
+}
+
+// placementOf собирает родителя, его предков и высоту поддерева задачи id.
+func placementOf(ctx context.Context, q querier, id uuid.UUID, parentID *uuid.UUID) (entity.TaskPlacement, error) {
+	var placement entity.TaskPlacement
+
+	// Предки ограничены по глубине, чтобы запрос завершался даже при поврежденной иерархии
+	height := `
+		WITH RECURSIVE subtree AS (
+			SELECT id, 1 AS depth FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT t.id, s.depth + 1 FROM tasks t JOIN subtree s ON t.parent_id = s.id
+			WHERE s.depth <= $2
+		)
+		SELECT COALESCE(MAX(depth), 1) FROM subtree`
+	if err := q.QueryRow(ctx, height, id, entity.MaxTaskDepth).Scan(&placement.SubtreeHeight); err != nil {
+		return placement, fmt.Errorf("failed to measure task subtree: %w", err)
+	}
+
+	if parentID == nil {
+		return placement, nil
+	}
+
+	// Блокировка родителя не дает одновременно сменить его проект (см. usecase.ErrProjectChange)
+	var parent entity.Task
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR SHARE`
+	if err := scanTask(q.QueryRow(ctx, query, *parentID), &parent); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return placement, usecase.ErrParentNotFound
+		}
+		return placement, fmt.Errorf("failed to get parent task: %w", err)
+	}
+	placement.Parent = &parent
+
+	ancestors := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 1 AS depth FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT t.id, t.parent_id, a.depth + 1 FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+			WHERE a.depth <= $2
+		)
+		SELECT id FROM ancestors ORDER BY depth`
+	rows, err := q.Query(ctx, ancestors, *parentID, entity.MaxTaskDepth)
+	if err != nil {
+		return placement, fmt.Errorf("failed to load task ancestors: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ancestor uuid.UUID
+		if err := rows.Scan(&ancestor); err != nil {
+			return placement, fmt.Errorf("failed to scan ancestor: %w", err)
+		}
+		placement.Ancestors = append(placement.Ancestors, ancestor)
+	}
+	if err := rows.Err(); err != nil {
+		return placement, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return placement, nil
+}
@@ -2,57 +2,71 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
 	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
-var (
-	ErrTaskNotFound = errors.New("task not found")
-	ErrInvalidUUID  = errors.New("invalid UUID format")
-)
-
 type TaskRepository struct {
 	db     *pgxpool.Pool
 	logger *logrus.Logger
 }
 
-type CacheRepository struct {
-	db *pgxpool.Pool
-}
+// taskColumns — список столбцов задачи в порядке, который ожидает scanTask.
+const taskColumns = `id, title, description, status, created_at, updated_at, version, deleted_at, project_id, parent_id, start_at, due_at, priority, custom_fields, rank, created_by, assignee_id`
 
-func NewTaskRepository(db *pgxpool.Pool) *TaskRepository {
-	return &TaskRepository{
-		db:     db,
-		logger: logger.Log,
+// taskFields возвращает указатели на поля задачи в порядке taskColumns.
+func taskFields(task *entity.Task) []interface{} {
+	return []interface{}{
+		&task.ID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.Version,
+		&task.DeletedAt,
+		&task.ProjectID,
+		&task.ParentID,
+		&task.StartAt,
+		&task.DueAt,
+		&task.Priority,
+		&task.CustomFields,
+		&task.Rank,
+		&task.CreatedBy,
+		&task.AssigneeID,
 	}
 }
 
-// Добавьте этот метод в CacheRepository
-func (r *CacheRepository) GetTasks(ctx context.Context) ([]entity.Task, error) {
-	// Реализация метода или временный заглушка
-	return nil, nil
+func scanTask(row pgx.Row, task *entity.Task) error {
+	return row.Scan(taskFields(task)...)
 }
 
-func (r *CacheRepository) SetTasks(ctx context.Context, tasks []entity.Task, ttl time.Duration) error {
-	// Реализация метода или временный заглушка
-	return nil
-}
-
-func (r *CacheRepository) Invalidate(ctx context.Context) error {
-	// Реализация метода или временный заглушка
-	return nil
+// customFields возвращает значения пользовательских полей задачи для записи в столбец
+// custom_fields; отсутствие значений хранится как пустой объект.
+func customFields(task entity.Task) map[string]json.RawMessage {
+	if task.CustomFields == nil {
+		return map[string]json.RawMessage{}
+	}
+	return task.CustomFields
 }
 
-func NewCacheRepository(db *pgxpool.Pool) *CacheRepository {
-	return &CacheRepository{db: db}
+func NewTaskRepository(db *pgxpool.Pool) *TaskRepository {
+	return &TaskRepository{
+		db:     db,
+		logger: logger.Log,
+	}
 }
 
 func (r *TaskRepository) Create(ctx context.Context, task entity.Task) (entity.Task, error) {
@@ -60,21 +74,50 @@ func (r *TaskRepository) Create(ctx context.Context, task entity.Task) (entity.T
 	defer cancel()
 
 	query := `
-		INSERT INTO tasks (id, title, description, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, title, description, status, created_at, updated_at`
+		INSERT INTO tasks (id, title, description, status, created_at, updated_at, project_id, parent_id, start_at, due_at, priority, custom_fields, rank,
+			created_by, assignee_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING ` + taskColumns
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if task.Rank == "" {
+		if task.Rank, err = appendRank(ctx, tx, task.Status); err != nil {
+			return entity.Task{}, err
+		}
+	}
 
 	now := time.Now()
-	err := r.db.QueryRow(ctx, query,
+	err = scanTask(tx.QueryRow(ctx, query,
 		task.ID,
 		task.Title,
 		task.Description,
 		task.Status,
 		now,
 		now,
-	).Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt)
+		task.ProjectID,
+		task.ParentID,
+		task.StartAt,
+		task.DueAt,
+		task.Priority,
+		customFields(task),
+		task.Rank,
+		task.CreatedBy,
+		task.AssigneeID,
+	), &task)
 
 	if err != nil {
+		if fkErr := foreignKeyError(err); fkErr != nil {
+			return entity.Task{}, fkErr
+		}
+		// ID задан вызывающим, например детерминированный ID экземпляра повторяющейся задачи
+		if isUniqueViolation(err) {
+			return entity.Task{}, usecase.ErrTaskExists
+		}
 		r.logger.WithFields(logrus.Fields{
 			"method":  "Create",
 			"task_id": task.ID.String(),
@@ -83,6 +126,10 @@ func (r *TaskRepository) Create(ctx context.Context, task entity.Task) (entity.T
 		return entity.Task{}, fmt.Errorf("failed to create task: %w", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return task, nil
 }
 
@@ -96,22 +143,13 @@ func (r *TaskRepository) Get(ctx context.Context, id string) (entity.Task, error
 			"method":  "Get",
 			"task_id": id,
 		}).WithError(err).Warn("Invalid task ID format")
-		return entity.Task{}, ErrInvalidUUID
+		return entity.Task{}, entity.InvalidID("task")
 	}
 
-	query := `
-		SELECT id, title, description, status, created_at, updated_at
-		FROM tasks WHERE id = $1`
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL`
 
 	var task entity.Task
-	err = r.db.QueryRow(ctx, query, parsedID).Scan(
-		&task.ID,
-		&task.Title,
-		&task.Description,
-		&task.Status,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-	)
+	err = scanTask(r.db.QueryRow(ctx, query, parsedID), &task)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -119,7 +157,7 @@ func (r *TaskRepository) Get(ctx context.Context, id string) (entity.Task, error
 				"method":  "Get",
 				"task_id": id,
 			}).Warn("Task not found")
-			return entity.Task{}, ErrTaskNotFound
+			return entity.Task{}, usecase.ErrTaskNotFound
 		}
 		r.logger.WithFields(logrus.Fields{
 			"method":  "Get",
@@ -131,15 +169,166 @@ func (r *TaskRepository) Get(ctx context.Context, id string) (entity.Task, error
 	return task, nil
 }
 
-func (r *TaskRepository) List(ctx context.Context) ([]entity.Task, error) {
+// sortKeys сопоставляет поля сортировки с выражениями ключа и типами их значений в курсоре.
+// Ключ из нескольких выражений хранится в курсоре через "|" (см. usecase.sortValue).
+// Выражения с COALESCE и отрицанием совпадают с индексами, чтобы keyset-пагинация
+// обходилась одним сравнением строк и без NULL.
+var sortKeys = map[string]sortKey{
+	entity.TaskSortCreatedAt: {exprs: []string{"created_at"}, casts: []string{"timestamp"}},
+	entity.TaskSortUpdatedAt: {exprs: []string{"updated_at"}, casts: []string{"timestamp"}},
+	entity.TaskSortTitle:     {exprs: []string{"title"}, casts: []string{"text"}},
+	entity.TaskSortDueAt: {
+		exprs: []string{"COALESCE(due_at, 'infinity'::timestamp)"},
+		casts: []string{"timestamp"},
+	},
+	entity.TaskSortPriority: {
+		exprs: []string{"(-priority)", "COALESCE(due_at, 'infinity'::timestamp)"},
+		casts: []string{"int", "timestamp"},
+	},
+	entity.TaskSortRank: {exprs: []string{"rank"}, casts: []string{"text"}},
+}
+
+// customFieldOps сопоставляет операции фильтров по пользовательским полям с операторами сравнения jsonb.
+var customFieldOps = map[string]string{
+	entity.CustomFieldGt:  ">",
+	entity.CustomFieldGte: ">=",
+	entity.CustomFieldLt:  "<",
+	entity.CustomFieldLte: "<=",
+}
+
+type sortKey struct {
+	exprs []string
+	casts []string
+}
+
+// customFieldExpr возвращает выражение значения пользовательского поля key. Оно совпадает
+// с выражением индекса поля (см. CustomFieldRepository.Create); отсутствующее значение
+// заменяется на JSON null, который меньше значений любого типа.
+// Ключ подставляется в запрос напрямую, поэтому должен пройти entity.ValidCustomFieldKey.
+func customFieldExpr(key string) string {
+	return "(COALESCE(custom_fields -> '" + key + "', 'null'::jsonb))"
+}
+
+// sortKeyFor возвращает ключ сортировки для поля; неизвестные поля сортируются по created_at.
+func sortKeyFor(sort entity.TaskSort) sortKey {
+	if key, ok := sort.CustomFieldKey(); ok {
+		return sortKey{exprs: []string{customFieldExpr(key)}, casts: []string{"jsonb"}}
+	}
+	if key, ok := sortKeys[sort.Field]; ok {
+		return key
+	}
+	return sortKeys[entity.TaskSortCreatedAt]
+}
+
+func (r *TaskRepository) List(ctx context.Context, params entity.TaskListParams) ([]entity.Task, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	query := `
-		SELECT id, title, description, status, created_at, updated_at
-		FROM tasks`
+	sortKey := sortKeyFor(params.Sort)
+
+	var conditions []string
+	args := []interface{}{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-	rows, err := r.db.Query(ctx, query)
+	filter := params.Filter
+	if filter.Deleted {
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if filter.ProjectID != nil {
+		conditions = append(conditions, "project_id = "+arg(*filter.ProjectID))
+	}
+	if filter.VisibleTo != "" {
+		conditions = append(conditions, visibleCondition(arg(filter.VisibleTo)))
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status = ANY("+arg(filter.Statuses)+")")
+	}
+	if len(filter.ExcludeStatuses) > 0 {
+		conditions = append(conditions, "status <> ALL("+arg(filter.ExcludeStatuses)+")")
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.UpdatedFrom != nil {
+		conditions = append(conditions, "updated_at >= "+arg(*filter.UpdatedFrom))
+	}
+	if filter.UpdatedTo != nil {
+		conditions = append(conditions, "updated_at < "+arg(*filter.UpdatedTo))
+	}
+	if filter.TitleContains != "" {
+		conditions = append(conditions, "title ILIKE "+arg("%"+escapeLike(filter.TitleContains)+"%"))
+	}
+	if len(filter.LabelsAny) > 0 {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = tasks.id AND l.name = ANY(`+arg(filter.LabelsAny)+`))`)
+	}
+	if len(filter.LabelsAll) > 0 {
+		names := uniqueStrings(filter.LabelsAll)
+		conditions = append(conditions, `(
+			SELECT count(*) FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = tasks.id AND l.name = ANY(`+arg(names)+`)) = `+arg(len(names)))
+	}
+	if filter.AssigneeID != "" {
+		conditions = append(conditions, "assignee_id = "+arg(filter.AssigneeID))
+	}
+	if filter.CreatedBy != "" {
+		conditions = append(conditions, "created_by = "+arg(filter.CreatedBy))
+	}
+	if filter.DueFrom != nil {
+		conditions = append(conditions, "due_at >= "+arg(*filter.DueFrom))
+	}
+	if filter.DueTo != nil {
+		conditions = append(conditions, "due_at < "+arg(*filter.DueTo))
+	}
+	for _, f := range filter.CustomFields {
+		op, ok := customFieldOps[f.Op]
+		if !entity.ValidCustomFieldKey(f.Key) || (!ok && f.Op != entity.CustomFieldEq) {
+			return nil, fmt.Errorf("%w: custom field %q %q", usecase.ErrInvalidFilter, f.Key, f.Op)
+		}
+		if f.Op == entity.CustomFieldEq {
+			value, _ := json.Marshal(map[string]json.RawMessage{f.Key: f.Typed})
+			conditions = append(conditions, "custom_fields @> "+arg(string(value))+"::jsonb")
+			continue
+		}
+		expr := customFieldExpr(f.Key)
+		conditions = append(conditions, expr+" "+op+" "+arg(string(f.Typed))+"::jsonb AND "+expr+" <> 'null'::jsonb")
+	}
+
+	direction, comparison := "ASC", ">"
+	if params.Sort.Desc {
+		direction, comparison = "DESC", "<"
+	}
+	if params.After != nil {
+		values := strings.SplitN(params.After.Value, "|", len(sortKey.exprs))
+		if len(values) != len(sortKey.exprs) {
+			return nil, usecase.ErrInvalidCursor
+		}
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			placeholders[i] = arg(v) + "::" + sortKey.casts[i]
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)",
+			strings.Join(sortKey.exprs, ", "), comparison, strings.Join(placeholders, ", "), arg(params.After.ID)))
+	}
+
+	order := make([]string, 0, len(sortKey.exprs)+1)
+	for _, expr := range append(sortKey.exprs, "id") {
+		order = append(order, expr+" "+direction)
+	}
+
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE ` + strings.Join(conditions, " AND ")
+	query += fmt.Sprintf(" ORDER BY %s LIMIT %s", strings.Join(order, ", "), arg(params.Limit))
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"method": "List",
@@ -151,14 +340,7 @@ func (r *TaskRepository) List(ctx context.Context) ([]entity.Task, error) {
 	var tasks []entity.Task
 	for rows.Next() {
 		var task entity.Task
-		if err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		); err != nil {
+		if err := scanTask(rows, &task); err != nil {
 			r.logger.WithFields(logrus.Fields{
 				"method": "List",
 			}).WithError(err).Error("Failed to scan task row")
@@ -177,31 +359,173 @@ func (r *TaskRepository) List(ctx context.Context) ([]entity.Task, error) {
 	return tasks, nil
 }
 
+func (r *TaskRepository) Search(ctx context.Context, params entity.TaskSearchParams) ([]entity.TaskSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query, args := searchQuery(params)
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "Search",
+			"query":  params.Query,
+		}).WithError(err).Error("Failed to search tasks")
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var results []entity.TaskSearchResult
+	for rows.Next() {
+		var res entity.TaskSearchResult
+		if err := rows.Scan(append(taskFields(&res.Task),
+			&res.Rank,
+			&res.TitleHighlight,
+			&res.DescriptionHighlight,
+		)...); err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"method": "Search",
+			}).WithError(err).Error("Failed to scan search result row")
+			return nil, fmt.Errorf("failed to scan search result row: %w", err)
+		}
+		results = append(results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "Search",
+		}).WithError(err).Error("Error after scanning rows")
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// searchQuery строит запрос полнотекстового поиска. Оценка релевантности называется
+// search_rank, чтобы не совпадать со столбцом rank из taskColumns.
+func searchQuery(params entity.TaskSearchParams) (string, []interface{}) {
+	query := `
+		SELECT ` + taskColumns + `,
+			ts_rank_cd(search_vector, q) AS search_rank,
+			ts_headline('simple', ` + escapeHTML("title") + `, q, 'HighlightAll=true'),
+			ts_headline('simple', ` + escapeHTML("coalesce(description, '')") + `, q, 'MaxFragments=2, MaxWords=30, MinWords=10')
+		FROM tasks, websearch_to_tsquery('simple', $1) AS q
+		WHERE search_vector @@ q AND deleted_at IS NULL`
+	args := []interface{}{params.Query}
+
+	if params.ProjectID != nil {
+		args = append(args, *params.ProjectID)
+		query += fmt.Sprintf(" AND project_id = $%d", len(args))
+	}
+	if len(params.Statuses) > 0 {
+		args = append(args, params.Statuses)
+		query += fmt.Sprintf(" AND status = ANY($%d)", len(args))
+	}
+	if params.VisibleTo != "" {
+		args = append(args, params.VisibleTo)
+		query += " AND " + visibleCondition(fmt.Sprintf("$%d", len(args)))
+	}
+	args = append(args, params.Limit, params.Offset)
+	query += fmt.Sprintf(" ORDER BY search_rank DESC, id LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	return query, args
+}
+
+// escapeHTML экранирует HTML в текстовом SQL-выражении expr. ts_headline размечает
+// совпадения тегами <b>, поэтому текст задачи экранируется до разметки: иначе HTML из
+// названия или описания попал бы в подсветку без изменений.
+func escapeHTML(expr string) string {
+	return `replace(replace(replace(replace(replace(` + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// visibleCondition отбирает задачи вне проектов и задачи проектов, в которых у пользователя
+// из параметра subject есть роль.
+func visibleCondition(subject string) string {
+	return "(project_id IS NULL OR project_id IN (SELECT project_id FROM project_members WHERE subject = " + subject + "))"
+}
+
+// foreignKeyError возвращает доменную ошибку для задачи, ссылающейся на несуществующий
+// проект или родителя, и nil для остальных ошибок.
+func foreignKeyError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23503" {
+		return nil
+	}
+	if pgErr.ConstraintName == "tasks_parent_id_fkey" {
+		return usecase.ErrParentNotFound
+	}
+	return usecase.ErrProjectNotFound
+}
+
+// uniqueStrings возвращает значения без повторов в исходном порядке.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE в пользовательской подстроке.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *TaskRepository) Update(ctx context.Context, task entity.Task) (entity.Task, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	// Нулевая версия означает безусловное обновление
 	query := `
 		UPDATE tasks
-		SET title = $2, description = $3, status = $4, updated_at = $5
-		WHERE id = $1
-		RETURNING id, title, description, status, created_at, updated_at`
+		SET title = $2, description = $3, status = $4, updated_at = $5, project_id = $7,
+			start_at = $8, due_at = $9, priority = $10, custom_fields = $11, assignee_id = $12, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL AND ($6::bigint = 0 OR version = $6)
+		RETURNING ` + taskColumns
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	defer tx.Rollback(ctx)
 
-	err := r.db.QueryRow(ctx, query,
+	expectedVersion := task.Version
+	err = scanTask(tx.QueryRow(ctx, query,
 		task.ID,
 		task.Title,
 		task.Description,
 		task.Status,
 		time.Now(),
-	).Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt)
+		expectedVersion,
+		task.ProjectID,
+		task.StartAt,
+		task.DueAt,
+		task.Priority,
+		customFields(task),
+		task.AssigneeID,
+	), &task)
 
 	if err != nil {
+		if fkErr := foreignKeyError(err); fkErr != nil {
+			return entity.Task{}, fkErr
+		}
 		if errors.Is(err, pgx.ErrNoRows) {
+			if expectedVersion != 0 && r.exists(ctx, task.ID) {
+				r.logger.WithFields(logrus.Fields{
+					"method":  "Update",
+					"task_id": task.ID.String(),
+					"version": expectedVersion,
+				}).Warn("Task version conflict")
+				return entity.Task{}, usecase.ErrVersionConflict
+			}
 			r.logger.WithFields(logrus.Fields{
 				"method":  "Update",
 				"task_id": task.ID.String(),
 			}).Warn("Task not found for update")
-			return entity.Task{}, ErrTaskNotFound
+			return entity.Task{}, usecase.ErrTaskNotFound
 		}
 		r.logger.WithFields(logrus.Fields{
 			"method":  "Update",
@@ -211,10 +535,111 @@ func (r *TaskRepository) Update(ctx context.Context, task entity.Task) (entity.T
 		return entity.Task{}, fmt.Errorf("failed to update task: %w", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return task, nil
 }
 
-func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+func (r *TaskRepository) UpdateFunc(ctx context.Context, id string, version int64, fn func(task *entity.Task) error) (entity.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "UpdateFunc",
+			"task_id": id,
+		}).WithError(err).Warn("Invalid task ID format")
+		return entity.Task{}, entity.InvalidID("task")
+	}
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	task, err := updateLocked(ctx, tx, parsedID, version, fn)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "UpdateFunc",
+			"task_id": id,
+			"version": version,
+		}).WithError(err).Warn("Failed to update task")
+		return entity.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return task, nil
+}
+
+// updateTaskQuery сохраняет изменяемые поля задачи и увеличивает её версию.
+const updateTaskQuery = `
+	UPDATE tasks
+	SET title = $2, description = $3, status = $4, updated_at = $5, project_id = $6, parent_id = $7,
+		start_at = $8, due_at = $9, priority = $10, custom_fields = $11, rank = $12, assignee_id = $13,
+		version = version + 1
+	WHERE id = $1
+	RETURNING ` + taskColumns
+
+// updateLocked блокирует задачу в транзакции tx, проверяет версию, передает задачу в fn
+// и сохраняет результат. Задача, которую fn перевела в другой статус, не назначив ей
+// ранг, встает в конец колонки нового статуса.
+func updateLocked(ctx context.Context, tx pgx.Tx, id uuid.UUID, version int64, fn func(task *entity.Task) error) (entity.Task, error) {
+	var task entity.Task
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	if err := scanTask(tx.QueryRow(ctx, query, id), &task); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Task{}, usecase.ErrTaskNotFound
+		}
+		return entity.Task{}, fmt.Errorf("failed to lock task: %w", err)
+	}
+
+	if version != 0 && task.Version != version {
+		return entity.Task{}, usecase.ErrVersionConflict
+	}
+
+	status, rank := task.Status, task.Rank
+	if err := fn(&task); err != nil {
+		return entity.Task{}, err
+	}
+	if task.Status != status && task.Rank == rank {
+		var err error
+		if task.Rank, err = appendRank(ctx, tx, task.Status); err != nil {
+			return entity.Task{}, err
+		}
+	}
+
+	if err := scanTask(tx.QueryRow(ctx, updateTaskQuery,
+		task.ID,
+		task.Title,
+		task.Description,
+		task.Status,
+		task.UpdatedAt,
+		task.ProjectID,
+		task.ParentID,
+		task.StartAt,
+		task.DueAt,
+		task.Priority,
+		customFields(task),
+		task.Rank,
+		task.AssigneeID,
+	), &task); err != nil {
+		if fkErr := foreignKeyError(err); fkErr != nil {
+			return entity.Task{}, fkErr
+		}
+		return entity.Task{}, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (r *TaskRepository) Delete(ctx context.Context, id string, version int64) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -224,11 +649,21 @@ func (r *TaskRepository) Delete(ctx context.Context, id string) error {
 			"method":  "Delete",
 			"task_id": id,
 		}).WithError(err).Warn("Invalid task ID format")
-		return ErrInvalidUUID
+		return entity.InvalidID("task")
+	}
+
+	// Задача перемещается в корзину; окончательно её удаляет Purge
+	query := `
+		UPDATE tasks
+		SET deleted_at = $3, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL AND ($2::bigint = 0 OR version = $2)`
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback(ctx)
 
-	query := `DELETE FROM tasks WHERE id = $1`
-	result, err := r.db.Exec(ctx, query, parsedID)
+	result, err := tx.Exec(ctx, query, parsedID, version, time.Now())
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
 			"method":  "Delete",
@@ -238,12 +673,116 @@ func (r *TaskRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	if result.RowsAffected() == 0 {
+		if version != 0 && r.exists(ctx, parsedID) {
+			r.logger.WithFields(logrus.Fields{
+				"method":  "Delete",
+				"task_id": id,
+				"version": version,
+			}).Warn("Task version conflict")
+			return usecase.ErrVersionConflict
+		}
 		r.logger.WithFields(logrus.Fields{
 			"method":  "Delete",
 			"task_id": id,
 		}).Warn("Task not found for deletion")
-		return ErrTaskNotFound
+		return usecase.ErrTaskNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
+
+func (r *TaskRepository) Restore(ctx context.Context, id string, fn func(task entity.Task) error) (entity.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "Restore",
+			"task_id": id,
+		}).WithError(err).Warn("Invalid task ID format")
+		return entity.Task{}, entity.InvalidID("task")
+	}
+
+	query := `
+		UPDATE tasks
+		SET deleted_at = NULL, updated_at = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING ` + taskColumns
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var task entity.Task
+	if err := scanTask(tx.QueryRow(ctx, query, parsedID, time.Now()), &task); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.logger.WithFields(logrus.Fields{
+				"method":  "Restore",
+				"task_id": id,
+			}).Warn("Task not found in trash")
+			return entity.Task{}, usecase.ErrTaskNotFound
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method":  "Restore",
+			"task_id": id,
+		}).WithError(err).Error("Failed to restore task")
+		return entity.Task{}, fmt.Errorf("failed to restore task: %w", err)
+	}
+	if err := fn(task); err != nil {
+		return entity.Task{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return task, nil
+}
+
+func (r *TaskRepository) Purge(ctx context.Context, deletedBefore time.Time, batchSize int) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// SKIP LOCKED позволяет нескольким репликам чистить корзину одновременно,
+	// не блокируя друг друга и не удаляя одни и те же строки
+	query := `
+		DELETE FROM tasks
+		WHERE id IN (
+			SELECT id FROM tasks
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.db.Exec(ctx, query, deletedBefore, batchSize)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": "Purge",
+		}).WithError(err).Error("Failed to purge deleted tasks")
+		return 0, fmt.Errorf("failed to purge deleted tasks: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// exists проверяет наличие задачи; используется, чтобы отличить конфликт версий от отсутствия задачи.
+func (r *TaskRepository) exists(ctx context.Context, id uuid.UUID) bool {
+	var found bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&found)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method":  "exists",
+			"task_id": id.String(),
+		}).WithError(err).Error("Failed to check task existence")
+		return false
+	}
+	return found
+}
@@ -0,0 +1,309 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// batchTimeout ограничивает время выполнения одного пакета операций.
+const batchTimeout = 30 * time.Second
+
+func (r *TaskRepository) CreateBatch(ctx context.Context, tasks []entity.Task, atomic bool) ([]entity.BatchItemResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, batchTimeout)
+	defer cancel()
+
+	if atomic {
+		return r.copyTasks(ctx, tasks)
+	}
+
+	ids := make([]uuid.UUID, len(tasks))
+	titles := make([]string, len(tasks))
+	descriptions := make([]string, len(tasks))
+	statuses := make([]string, len(tasks))
+	createdAt := make([]time.Time, len(tasks))
+	updatedAt := make([]time.Time, len(tasks))
+	projectIDs := make([]*uuid.UUID, len(tasks))
+	parentIDs := make([]*uuid.UUID, len(tasks))
+	startAt := make([]*time.Time, len(tasks))
+	dueAt := make([]*time.Time, len(tasks))
+	priorities := make([]int, len(tasks))
+	fields := make([]map[string]json.RawMessage, len(tasks))
+	ranks := make([]string, len(tasks))
+	createdBy := make([]string, len(tasks))
+	assignees := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+		titles[i] = task.Title
+		descriptions[i] = task.Description
+		statuses[i] = task.Status
+		createdAt[i] = task.CreatedAt
+		updatedAt[i] = task.UpdatedAt
+		projectIDs[i] = task.ProjectID
+		parentIDs[i] = task.ParentID
+		startAt[i] = task.StartAt
+		dueAt[i] = task.DueAt
+		priorities[i] = task.Priority
+		fields[i] = customFields(task)
+		createdBy[i] = task.CreatedBy
+		assignees[i] = task.AssigneeID
+	}
+
+	// Задачи с уже существующим ID пропускаются, остальные вставляются одним запросом
+	query := `
+		INSERT INTO tasks (id, title, description, status, created_at, updated_at, project_id, parent_id, start_at, due_at, priority, custom_fields, rank,
+			created_by, assignee_id)
+		SELECT * FROM unnest($1::uuid[], $2::text[], $3::text[], $4::text[], $5::timestamp[], $6::timestamp[], $7::uuid[], $8::uuid[],
+			$9::timestamp[], $10::timestamp[], $11::smallint[], $12::jsonb[], $13::text[], $14::text[], $15::text[])
+		ON CONFLICT (id) DO NOTHING
+		RETURNING ` + taskColumns
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := appendRanks(ctx, tx, tasks); err != nil {
+		return nil, err
+	}
+	for i, task := range tasks {
+		ranks[i] = task.Rank
+	}
+
+	rows, err := tx.Query(ctx, query, ids, titles, descriptions, statuses, createdAt, updatedAt, projectIDs, parentIDs, startAt, dueAt, priorities, fields, ranks, createdBy, assignees)
+	if err != nil {
+		if fkErr := foreignKeyError(err); fkErr != nil {
+			return nil, fkErr
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method": "CreateBatch",
+			"count":  len(tasks),
+		}).WithError(err).Error("Failed to insert tasks")
+		return nil, fmt.Errorf("failed to insert tasks: %w", err)
+	}
+	defer rows.Close()
+
+	created := make(map[uuid.UUID]entity.Task, len(tasks))
+	for rows.Next() {
+		var task entity.Task
+		if err := scanTask(rows, &task); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		created[task.ID] = task
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after scanning rows: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	results := make([]entity.BatchItemResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = entity.BatchItemResult{Index: i, ID: task.ID}
+		if createdTask, ok := created[task.ID]; ok {
+			results[i].Task = &createdTask
+		} else {
+			results[i].Fail(usecase.ErrTaskExists)
+		}
+	}
+	return results, nil
+}
+
+// copyTasks вставляет задачи через COPY в одной транзакции.
+func (r *TaskRepository) copyTasks(ctx context.Context, tasks []entity.Task) ([]entity.BatchItemResult, error) {
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := appendRanks(ctx, tx, tasks); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"tasks"},
+		[]string{"id", "title", "description", "status", "created_at", "updated_at", "project_id", "parent_id", "start_at", "due_at", "priority", "custom_fields", "rank",
+			"created_by", "assignee_id"},
+		pgx.CopyFromSlice(len(tasks), func(i int) ([]interface{}, error) {
+			t := tasks[i]
+			return []interface{}{t.ID, t.Title, t.Description, t.Status, t.CreatedAt, t.UpdatedAt, t.ProjectID, t.ParentID, t.StartAt, t.DueAt, t.Priority, customFields(t), t.Rank,
+				t.CreatedBy, t.AssigneeID}, nil
+		}),
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return r.existingTaskResults(ctx, tasks)
+		}
+		if fkErr := foreignKeyError(err); fkErr != nil {
+			return nil, fkErr
+		}
+		r.logger.WithFields(logrus.Fields{
+			"method": "CreateBatch",
+			"count":  len(tasks),
+		}).WithError(err).Error("Failed to copy tasks")
+		return nil, fmt.Errorf("failed to copy tasks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	results := make([]entity.BatchItemResult, len(tasks))
+	for i := range tasks {
+		task := tasks[i]
+		task.Version = 1
+		results[i] = entity.BatchItemResult{Index: i, ID: task.ID, Task: &task}
+	}
+	return results, nil
+}
+
+// existingTaskResults строит результаты атомарного пакета, отмененного из-за
+// конфликта ID: уже существующие задачи отмечаются ErrTaskExists, остальные ErrNotApplied.
+func (r *TaskRepository) existingTaskResults(ctx context.Context, tasks []entity.Task) ([]entity.BatchItemResult, error) {
+	ids := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT id FROM tasks WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query existing tasks: %w", err)
+	}
+	existing, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan existing tasks: %w", err)
+	}
+
+	results := make([]entity.BatchItemResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = entity.BatchItemResult{Index: i, ID: task.ID}
+		if slices.Contains(existing, task.ID) {
+			results[i].Fail(usecase.ErrTaskExists)
+		} else {
+			results[i].Fail(usecase.ErrNotApplied)
+		}
+	}
+	return results, usecase.ErrBatchAborted
+}
+
+func (r *TaskRepository) UpdateBatch(ctx context.Context, refs []entity.TaskRef, atomic bool, fn func(i int, task *entity.Task) error) ([]entity.BatchItemResult, error) {
+	ids := make([]uuid.UUID, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.ID
+	}
+
+	return r.runBatch(ctx, "UpdateBatch", ids, atomic, func(ctx context.Context, tx pgx.Tx, i int) (*entity.Task, error) {
+		task, err := updateLocked(ctx, tx, refs[i].ID, refs[i].Version, func(task *entity.Task) error {
+			return fn(i, task)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &task, nil
+	})
+}
+
+func (r *TaskRepository) DeleteBatch(ctx context.Context, refs []entity.TaskRef, atomic bool, fn func(i int, task entity.Task) error) ([]entity.BatchItemResult, error) {
+	lockQuery := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	deleteQuery := `UPDATE tasks SET deleted_at = $2, version = version + 1 WHERE id = $1`
+
+	ids := make([]uuid.UUID, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.ID
+	}
+
+	now := time.Now()
+	return r.runBatch(ctx, "DeleteBatch", ids, atomic, func(ctx context.Context, tx pgx.Tx, i int) (*entity.Task, error) {
+		var task entity.Task
+		if err := scanTask(tx.QueryRow(ctx, lockQuery, refs[i].ID), &task); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, usecase.ErrTaskNotFound
+			}
+			return nil, fmt.Errorf("failed to lock task: %w", err)
+		}
+		if refs[i].Version != 0 && task.Version != refs[i].Version {
+			return nil, usecase.ErrVersionConflict
+		}
+		if err := fn(i, task); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, deleteQuery, task.ID, now); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// runBatch выполняет операции пакета в одной транзакции. В атомарном режиме первая
+// ошибка отменяет весь пакет, в остальных случаях каждая операция выполняется в
+// собственной точке сохранения и её ошибка не затрагивает остальные.
+func (r *TaskRepository) runBatch(ctx context.Context, method string, ids []uuid.UUID, atomic bool,
+	fn func(ctx context.Context, tx pgx.Tx, i int) (*entity.Task, error)) ([]entity.BatchItemResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, batchTimeout)
+	defer cancel()
+
+	tx, err := beginAudited(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	results := make([]entity.BatchItemResult, len(ids))
+	for i, id := range ids {
+		results[i] = entity.BatchItemResult{Index: i, ID: id}
+
+		if atomic {
+			task, err := fn(ctx, tx, i)
+			if err != nil {
+				r.logger.WithFields(logrus.Fields{
+					"method":  method,
+					"task_id": id.String(),
+				}).WithError(err).Warn("Batch item failed, aborting batch")
+				for j := range results {
+					results[j] = entity.BatchItemResult{Index: j, ID: ids[j]}
+					results[j].Fail(usecase.ErrNotApplied)
+				}
+				results[i].Fail(err)
+				return results, usecase.ErrBatchAborted
+			}
+			results[i].Task = task
+			continue
+		}
+
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+		task, err := fn(ctx, savepoint, i)
+		if err != nil {
+			savepoint.Rollback(ctx)
+			results[i].Fail(err)
+			continue
+		}
+		if err := savepoint.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		results[i].Task = task
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"method": method,
+			"count":  len(ids),
+		}).WithError(err).Error("Failed to commit batch")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return results, nil
+}
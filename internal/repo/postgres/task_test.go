@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// selectColumnNames возвращает имена выходных столбцов списка SELECT: псевдоним после AS
+// или имя столбца. Для выражений без псевдонима возвращается пустая строка.
+func selectColumnNames(query string) []string {
+	list := query[strings.Index(query, "SELECT")+len("SELECT") : strings.Index(query, "FROM tasks")]
+
+	var names []string
+	depth, start := 0, 0
+	for i := 0; i <= len(list); i++ {
+		if i < len(list) {
+			switch list[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if list[i] != ',' || depth > 0 {
+				continue
+			}
+		}
+		expr := strings.TrimSpace(list[start:i])
+		start = i + 1
+		if idx := strings.LastIndex(expr, " AS "); idx >= 0 {
+			names = append(names, strings.TrimSpace(expr[idx+len(" AS "):]))
+		} else if regexp.MustCompile(`^\w+$`).MatchString(expr) {
+			names = append(names, expr)
+		} else {
+			names = append(names, "")
+		}
+	}
+	return names
+}
+
+func TestSearchQueryOrdersByUniqueColumn(t *testing.T) {
+	projectID := uuid.New()
+	tests := []struct {
+		name   string
+		params entity.TaskSearchParams
+	}{
+		{name: "query only", params: entity.TaskSearchParams{Query: "report", Limit: 20}},
+		{name: "all filters", params: entity.TaskSearchParams{
+			Query:     "report",
+			ProjectID: &projectID,
+			Statuses:  []string{"todo"},
+			VisibleTo: "user-1",
+			Limit:     20,
+			Offset:    40,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := searchQuery(tt.params)
+
+			order := regexp.MustCompile(`ORDER BY (\w+)`).FindStringSubmatch(query)
+			if order == nil {
+				t.Fatalf("query has no ORDER BY: %s", query)
+			}
+			count := 0
+			for _, name := range selectColumnNames(query) {
+				if name == order[1] {
+					count++
+				}
+			}
+			if count != 1 {
+				t.Errorf("ORDER BY %s matches %d output columns, want 1", order[1], count)
+			}
+
+			placeholders := regexp.MustCompile(`\$\d+`).FindAllString(query, -1)
+			if last := placeholders[len(placeholders)-1]; last != "$"+strconv.Itoa(len(args)) {
+				t.Errorf("last placeholder %s, want $%d", last, len(args))
+			}
+		})
+	}
+}
+
+// testPool подключается к базе из TEST_POSTGRES_DSN и применяет миграции. Без переменной
+// тест пропускается.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+	if err := goose.Up(db, "../../../migrations"); err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+
+	pool, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestTaskRepositorySearch(t *testing.T) {
+	repo := NewTaskRepository(testPool(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	word := "searchcheck" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	created, err := repo.Create(ctx, entity.Task{ID: uuid.New(), Title: "Quarterly <i>report</i> " + word, Status: "todo"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() {
+		repo.db.Exec(context.Background(), `DELETE FROM tasks WHERE id = $1`, created.ID)
+	})
+
+	results, err := repo.Search(ctx, entity.TaskSearchParams{Query: word, Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Task.ID != created.ID {
+		t.Fatalf("Search returned %+v, want task %s", results, created.ID)
+	}
+	if results[0].Task.Rank != created.Rank {
+		t.Errorf("board rank = %q, want %q", results[0].Task.Rank, created.Rank)
+	}
+	if results[0].Rank <= 0 {
+		t.Errorf("search rank = %v, want positive", results[0].Rank)
+	}
+	if want := "Quarterly &lt;i&gt;report&lt;/i&gt; <b>" + word + "</b>"; results[0].TitleHighlight != want {
+		t.Errorf("title highlight = %q, want %q", results[0].TitleHighlight, want)
+	}
+}
@@ -23,31 +23,59 @@ func NewCacheRepository(addr, password string, db int) *CacheRepository {
 	return &CacheRepository{client: client}
 }
 
-func (c *CacheRepository) SetTasks(ctx context.Context, tasks []entity.Task, ttl time.Duration) error {
-	data, err := json.Marshal(tasks)
+const taskPageKeyPrefix = "tasks:"
+
+func (c *CacheRepository) SetTaskPage(ctx context.Context, key string, page entity.TaskPage, ttl time.Duration) error {
+	data, err := json.Marshal(page)
 	if err != nil {
 		return err
 	}
-	return c.client.Set(ctx, "tasks", data, ttl).Err()
+	return c.client.Set(ctx, taskPageKeyPrefix+key, data, ttl).Err()
 }
 
-func (c *CacheRepository) GetTasks(ctx context.Context) ([]entity.Task, error) {
-	data, err := c.client.Get(ctx, "tasks").Result()
+func (c *CacheRepository) GetTaskPage(ctx context.Context, key string) (*entity.TaskPage, error) {
+	data, err := c.client.Get(ctx, taskPageKeyPrefix+key).Result()
 	if err == redis.Nil {
 		return nil, nil // Кэш пуст
 	} else if err != nil {
 		return nil, err
 	}
 
-	var tasks []entity.Task
-	if err := json.Unmarshal([]byte(data), &tasks); err != nil {
+	var page entity.TaskPage
+	if err := json.Unmarshal([]byte(data), &page); err != nil {
 		return nil, err
 	}
-	return tasks, nil
+	return &page, nil
 }
 
+// Invalidate удаляет все закэшированные страницы списка задач
 func (c *CacheRepository) Invalidate(ctx context.Context) error {
-	return c.client.Del(ctx, "tasks").Err()
+	return c.deleteByPattern(ctx, taskPageKeyPrefix+"*")
+}
+
+// InvalidateScopes удаляет закэшированные страницы с ключами, начинающимися с перечисленных префиксов
+func (c *CacheRepository) InvalidateScopes(ctx context.Context, scopes ...string) error {
+	for _, scope := range scopes {
+		if err := c.deleteByPattern(ctx, taskPageKeyPrefix+scope+":*"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CacheRepository) deleteByPattern(ctx context.Context, pattern string) error {
+	iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
 }
 
 // Ping проверяет подключение к Redis
@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+const rateLimitKeyPrefix = "ratelimit:"
+
+// rateLimitTimeout ограничивает ожидание Redis, чтобы недоступный Redis не задерживал
+// каждый запрос: по ошибке ограничитель переходит на лимиты в памяти процесса.
+const rateLimitTimeout = 200 * time.Millisecond
+
+// gcraScript расходует запрос по алгоритму GCRA (token bucket с равномерным восполнением).
+// По ключу хранится теоретическое время прибытия следующего запроса в микросекундах;
+// время берется из Redis, поэтому часы реплик сервиса не влияют на лимит.
+// ARGV: интервал восполнения одного запроса и окно в микросекундах.
+// Возвращает: разрешен ли запрос, остаток, время до полного восполнения и до следующего
+// разрешенного запроса в микросекундах.
+var gcraScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local tat = tonumber(redis.call('GET', KEYS[1])) or now
+if tat < now then
+	tat = now
+end
+local new_tat = tat + interval
+local allow_at = new_tat - window
+if now < allow_at then
+	return {0, 0, tat - now, allow_at - now}
+end
+redis.call('SET', KEYS[1], string.format('%d', new_tat), 'PX', math.max(1, math.ceil((new_tat - now) / 1000)))
+return {1, math.floor((now - allow_at) / interval), new_tat - now, 0}
+`)
+
+// RateLimiter хранит счетчики ограничения частоты запросов в Redis и делит лимиты
+// между всеми репликами сервиса.
+type RateLimiter struct {
+	client *redis.Client
+}
+
+func NewRateLimiter(addr, password string, db int) *RateLimiter {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	return &RateLimiter{client: client}
+}
+
+func (l *RateLimiter) Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, rateLimitTimeout)
+	defer cancel()
+
+	values, err := gcraScript.Run(ctx, l.client, []string{rateLimitKeyPrefix + key},
+		limit.Interval().Microseconds(), limit.Window.Microseconds()).Int64Slice()
+	if err != nil {
+		return entity.RateLimitResult{}, err
+	}
+	if len(values) != 4 {
+		return entity.RateLimitResult{}, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+	return entity.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit,
+		Remaining:  int(values[1]),
+		Reset:      time.Duration(values[2]) * time.Microsecond,
+		RetryAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
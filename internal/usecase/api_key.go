@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAPIKeyNotFound = entity.NewError(entity.KindNotFound, "api_key_not_found", "API key not found")
+	ErrInvalidAPIKey  = entity.NewError(entity.KindValidation, "invalid_api_key", "invalid API key")
+)
+
+// apiKeySecretSize — число случайных байт секрета ключа API.
+const apiKeySecretSize = 32
+
+type APIKeyUseCase interface {
+	// Issue выпускает ключ и возвращает его секрет; позже секрет получить нельзя.
+	Issue(ctx context.Context, key entity.APIKey) (entity.IssuedAPIKey, error)
+	// List возвращает все ключи, включая отозванные и просроченные, без секретов.
+	List(ctx context.Context) ([]entity.APIKey, error)
+	// Revoke отзывает ключ; повторный отзыв ничего не меняет.
+	Revoke(ctx context.Context, id string) error
+	// Verify проверяет секрет ключа и отмечает время его использования.
+	Verify(ctx context.Context, secret string) (entity.Principal, error)
+}
+
+type APIKeyUseCaseImpl struct {
+	apiKeyRepo APIKeyRepository
+}
+
+func NewAPIKeyUseCase(apiKeyRepo APIKeyRepository) *APIKeyUseCaseImpl {
+	return &APIKeyUseCaseImpl{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+func (uc *APIKeyUseCaseImpl) Issue(ctx context.Context, key entity.APIKey) (entity.IssuedAPIKey, error) {
+	logger.Log.Info("Issuing API key", "name", key.Name)
+
+	slices.Sort(key.Scopes)
+	key.Scopes = slices.Compact(key.Scopes)
+	if err := key.Validate(); err != nil {
+		logger.Log.WithError(err).Error("API key validation failed")
+		return entity.IssuedAPIKey{}, fmt.Errorf("%w: %w", ErrInvalidAPIKey, err)
+	}
+
+	buf := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return entity.IssuedAPIKey{}, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	secret := entity.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	key.ID = uuid.New()
+	key.Hint = secret[:entity.APIKeyHintLength]
+	key.LastUsedAt = nil
+	key.RevokedAt = nil
+	key.CreatedBy = ""
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		key.CreatedBy = principal.Subject
+	}
+	key.CreatedAt = time.Now()
+
+	createdKey, err := uc.apiKeyRepo.Create(ctx, key, hashAPIKey(secret))
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to create API key")
+		return entity.IssuedAPIKey{}, err
+	}
+
+	logger.Log.Info("API key issued successfully", "api_key_id", createdKey.ID)
+	return entity.IssuedAPIKey{APIKey: createdKey, Key: secret}, nil
+}
+
+func (uc *APIKeyUseCaseImpl) List(ctx context.Context) ([]entity.APIKey, error) {
+	logger.Log.Info("Listing API keys")
+	keys, err := uc.apiKeyRepo.List(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list API keys from repository")
+		return nil, err
+	}
+	if keys == nil {
+		keys = []entity.APIKey{}
+	}
+	return keys, nil
+}
+
+func (uc *APIKeyUseCaseImpl) Revoke(ctx context.Context, id string) error {
+	logger.Log.Info("Revoking API key", "id", id)
+
+	if err := uc.apiKeyRepo.Revoke(ctx, id, time.Now()); err != nil {
+		logger.Log.WithError(err).Error("Failed to revoke API key")
+		return err
+	}
+
+	logger.Log.Info("API key revoked successfully", "id", id)
+	return nil
+}
+
+func (uc *APIKeyUseCaseImpl) Verify(ctx context.Context, secret string) (entity.Principal, error) {
+	if !strings.HasPrefix(secret, entity.APIKeyPrefix) {
+		return entity.Principal{}, fmt.Errorf("%w: not an API key", ErrUnauthenticated)
+	}
+
+	key, err := uc.apiKeyRepo.GetByHash(ctx, hashAPIKey(secret))
+	if err != nil {
+		if errors.Is(err, ErrAPIKeyNotFound) {
+			return entity.Principal{}, fmt.Errorf("%w: unknown API key", ErrUnauthenticated)
+		}
+		return entity.Principal{}, err
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		return entity.Principal{}, fmt.Errorf("%w: API key %s is revoked or expired", ErrUnauthenticated, key.ID)
+	}
+	if err := uc.apiKeyRepo.Touch(ctx, key.ID, now); err != nil {
+		logger.Log.WithError(err).Error("Failed to record API key usage", "api_key_id", key.ID)
+	}
+
+	return entity.Principal{Subject: key.Subject(), Scopes: key.Scopes}, nil
+}
+
+// hashAPIKey возвращает хэш секрета, под которым ключ хранится и ищется.
+func hashAPIKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key entity.APIKey, secretHash []byte) (entity.APIKey, error)
+	List(ctx context.Context) ([]entity.APIKey, error)
+	// GetByHash возвращает ключ по хэшу секрета или ErrAPIKeyNotFound.
+	GetByHash(ctx context.Context, secretHash []byte) (entity.APIKey, error)
+	// Revoke отмечает ключ отозванным в момент at; ErrAPIKeyNotFound, если ключа нет.
+	Revoke(ctx context.Context, id string, at time.Time) error
+	// Touch записывает время использования ключа. Чтобы не писать в базу на каждый
+	// запрос, время может обновляться с задержкой до минуты.
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAttachmentNotFound = entity.NewError(entity.KindNotFound, "attachment_not_found", "attachment not found")
+	ErrInvalidAttachment  = entity.NewError(entity.KindValidation, "invalid_attachment", "invalid attachment")
+	ErrAttachmentTooLarge = entity.NewError(entity.KindTooLarge, "attachment_too_large", "attachment exceeds the size limit")
+)
+
+// sniffLen — сколько первых байт файла нужно http.DetectContentType.
+const sniffLen = 512
+
+// AttachmentUseCase управляет файлами, прикрепленными к задачам. Содержимое
+// передается потоком и не загружается в память целиком.
+type AttachmentUseCase interface {
+	// Upload сохраняет файл, определяя тип содержимого и контрольную сумму по ходу чтения.
+	// Возвращает ErrAttachmentTooLarge, если файл больше допустимого размера.
+	Upload(ctx context.Context, taskID, fileName string, content io.Reader) (entity.Attachment, error)
+	List(ctx context.Context, taskID string) ([]entity.Attachment, error)
+	// Download возвращает метаданные и содержимое вложения; содержимое закрывает вызывающий.
+	Download(ctx context.Context, taskID, id string) (entity.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, taskID, id string) error
+	// CollectGarbage удаляет из хранилища файлы, метаданные которых уже удалены.
+	CollectGarbage(ctx context.Context, limit int) (int, error)
+}
+
+type AttachmentUseCaseImpl struct {
+	attachmentRepo AttachmentRepository
+	authz          *AuthorizationService
+	blobStore      BlobStore
+	maxSize        int64
+}
+
+func NewAttachmentUseCase(attachmentRepo AttachmentRepository, authz *AuthorizationService, blobStore BlobStore, maxSize int64) *AttachmentUseCaseImpl {
+	if maxSize <= 0 {
+		maxSize = entity.DefaultMaxAttachmentSize
+	}
+	return &AttachmentUseCaseImpl{
+		attachmentRepo: attachmentRepo,
+		authz:          authz,
+		blobStore:      blobStore,
+		maxSize:        maxSize,
+	}
+}
+
+func (uc *AttachmentUseCaseImpl) Upload(ctx context.Context, taskID, fileName string, content io.Reader) (entity.Attachment, error) {
+	logger.Log.Info("Starting attachment upload", "task_id", taskID, "file_name", fileName)
+
+	attachment := entity.Attachment{FileName: entity.SanitizeFileName(fileName)}
+	if err := attachment.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Attachment validation failed")
+		return entity.Attachment{}, fmt.Errorf("%w: %w", ErrInvalidAttachment, err)
+	}
+
+	task, err := uc.authz.LoadTask(ctx, entity.ActionEdit, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for attachment")
+		return entity.Attachment{}, err
+	}
+
+	// Тип содержимого определяем по первым байтам файла, не дочитывая его
+	buffered := bufio.NewReaderSize(content, sniffLen)
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logger.Log.WithError(err).Error("Failed to read attachment content")
+		return entity.Attachment{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	hash := sha256.New()
+	counter := &limitedReader{r: buffered, remaining: uc.maxSize}
+
+	attachment.ID = uuid.New()
+	attachment.TaskID = task.ID
+	attachment.ContentType = http.DetectContentType(head)
+	attachment.StorageKey = fmt.Sprintf("tasks/%s/%s", task.ID, attachment.ID)
+	attachment.CreatedAt = time.Now()
+
+	if err := uc.blobStore.Put(ctx, attachment.StorageKey, io.TeeReader(counter, hash), attachment.ContentType); err != nil {
+		if errors.Is(err, ErrAttachmentTooLarge) {
+			logger.Log.Warn("Attachment rejected by size limit", "task_id", taskID, "max_size", uc.maxSize)
+			return entity.Attachment{}, ErrAttachmentTooLarge
+		}
+		logger.Log.WithError(err).Error("Failed to store attachment content")
+		return entity.Attachment{}, err
+	}
+	attachment.Size = counter.read
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	createdAttachment, err := uc.attachmentRepo.Create(ctx, attachment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to create attachment")
+		// Без метаданных файл недоступен, поэтому сразу удаляем его из хранилища
+		if delErr := uc.blobStore.Delete(ctx, attachment.StorageKey); delErr != nil {
+			logger.Log.WithError(delErr).Error("Failed to delete orphaned attachment content")
+		}
+		return entity.Attachment{}, err
+	}
+
+	logger.Log.Info("Attachment uploaded successfully", "attachment_id", createdAttachment.ID, "size", createdAttachment.Size)
+	return createdAttachment, nil
+}
+
+func (uc *AttachmentUseCaseImpl) List(ctx context.Context, taskID string) ([]entity.Attachment, error) {
+	logger.Log.Info("Listing attachments", "task_id", taskID)
+
+	task, err := uc.authz.LoadTask(ctx, entity.ActionView, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for attachments")
+		return nil, err
+	}
+
+	attachments, err := uc.attachmentRepo.List(ctx, task.ID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list attachments from repository")
+		return nil, err
+	}
+	if attachments == nil {
+		attachments = []entity.Attachment{}
+	}
+	return attachments, nil
+}
+
+func (uc *AttachmentUseCaseImpl) Download(ctx context.Context, taskID, id string) (entity.Attachment, io.ReadCloser, error) {
+	logger.Log.Info("Downloading attachment", "task_id", taskID, "id", id)
+
+	attachmentID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.Attachment{}, nil, ErrAttachmentNotFound
+	}
+	task, err := uc.authz.LoadTask(ctx, entity.ActionView, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for attachment")
+		return entity.Attachment{}, nil, err
+	}
+
+	attachment, err := uc.attachmentRepo.Get(ctx, task.ID, attachmentID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get attachment")
+		return entity.Attachment{}, nil, err
+	}
+
+	content, err := uc.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to open attachment content")
+		return entity.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (uc *AttachmentUseCaseImpl) Delete(ctx context.Context, taskID, id string) error {
+	logger.Log.Info("Deleting attachment", "task_id", taskID, "id", id)
+
+	attachmentID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrAttachmentNotFound
+	}
+	task, err := uc.authz.LoadTask(ctx, entity.ActionEdit, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for attachment")
+		return err
+	}
+
+	attachment, err := uc.attachmentRepo.Delete(ctx, task.ID, attachmentID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to delete attachment from repository")
+		return err
+	}
+
+	// Ключ файла уже поставлен в очередь на удаление; если хранилище недоступно,
+	// файл удалит фоновая очистка
+	if _, err := uc.removeBlobs(ctx, []string{attachment.StorageKey}); err != nil {
+		logger.Log.WithError(err).Warn("Failed to delete attachment content, leaving it to garbage collection")
+	}
+
+	logger.Log.Info("Attachment deleted successfully", "id", id)
+	return nil
+}
+
+func (uc *AttachmentUseCaseImpl) CollectGarbage(ctx context.Context, limit int) (int, error) {
+	keys, err := uc.attachmentRepo.PendingBlobDeletions(ctx, limit)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list pending attachment deletions")
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	removed, err := uc.removeBlobs(ctx, keys)
+	if removed > 0 {
+		logger.Log.Info("Attachment content collected", "count", removed)
+	}
+	return removed, err
+}
+
+// removeBlobs удаляет файлы из хранилища и снимает с очереди те, что удалось удалить.
+func (uc *AttachmentUseCaseImpl) removeBlobs(ctx context.Context, keys []string) (int, error) {
+	removed := make([]string, 0, len(keys))
+	var firstErr error
+	for _, key := range keys {
+		if err := uc.blobStore.Delete(ctx, key); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		removed = append(removed, key)
+	}
+
+	if len(removed) > 0 {
+		if err := uc.attachmentRepo.ForgetBlobDeletions(ctx, removed); err != nil {
+			return 0, err
+		}
+	}
+	return len(removed), firstErr
+}
+
+// limitedReader считает прочитанные байты и возвращает ErrAttachmentTooLarge,
+// как только содержимое превышает remaining.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	read      int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// Читаем на байт больше лимита, чтобы отличить файл ровно допустимого размера от большего
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrAttachmentTooLarge
+	}
+	return n, err
+}
+
+// BlobStore хранит содержимое вложений по ключу.
+type BlobStore interface {
+	// Put сохраняет содержимое r под ключом key. Если чтение r или запись завершились
+	// ошибкой, частично записанные данные не остаются в хранилище.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete не считает ошибкой отсутствие ключа.
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error)
+	// Get и Delete находят вложение только среди вложений задачи taskID.
+	Get(ctx context.Context, taskID, id uuid.UUID) (entity.Attachment, error)
+	List(ctx context.Context, taskID uuid.UUID) ([]entity.Attachment, error)
+	// Delete удаляет метаданные и ставит ключ файла в очередь на удаление из хранилища.
+	Delete(ctx context.Context, taskID, id uuid.UUID) (entity.Attachment, error)
+	// PendingBlobDeletions возвращает ключи файлов, ожидающих удаления из хранилища.
+	PendingBlobDeletions(ctx context.Context, limit int) ([]string, error)
+	ForgetBlobDeletions(ctx context.Context, keys []string) error
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+)
+
+var (
+	// ErrUnauthenticated возвращается, если учетные данные запроса отсутствуют или недействительны.
+	ErrUnauthenticated = entity.NewError(entity.KindUnauthenticated, "unauthenticated", "unauthenticated")
+	// ErrNotTaskOwner возвращается, если автор запроса не может изменять или удалять задачу вне проектов.
+	ErrNotTaskOwner = &ForbiddenError{Reason: "task is owned by another user"}
+	// ErrInsufficientScope возвращается, если области доступа автора запроса не разрешают операцию.
+	ErrInsufficientScope = &ForbiddenError{Reason: "insufficient scope"}
+)
+
+// TokenVerifier проверяет токен доступа и возвращает его владельца. Недействительный
+// токен отклоняется с ErrUnauthenticated.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (entity.Principal, error)
+}
+
+type principalKey struct{}
+
+// WithPrincipal возвращает контекст с аутентифицированным автором запроса. Usecase'ы
+// получают автора из контекста, как и сведения для истории изменений (см. WithAudit).
+func WithPrincipal(ctx context.Context, principal entity.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext возвращает автора запроса, сохраненного WithPrincipal. ok == false
+// у фоновых задач и при отключенной аутентификации.
+func PrincipalFromContext(ctx context.Context) (principal entity.Principal, ok bool) {
+	principal, ok = ctx.Value(principalKey{}).(entity.Principal)
+	return principal, ok
+}
+
+// stampCreator записывает автора запроса автором новой задачи. Без аутентификации
+// сохраняется переданное значение: так экземпляры повторяющихся задач наследуют автора шаблона.
+func stampCreator(ctx context.Context, task *entity.Task) {
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		task.CreatedBy = principal.Subject
+	}
+}
+
+// TokenVerifiers выбирает проверку по виду токена: секреты ключей API начинаются
+// с entity.APIKeyPrefix, остальные токены считаются JWT. Без JWT принимаются только ключи API.
+type TokenVerifiers struct {
+	JWT     TokenVerifier
+	APIKeys TokenVerifier
+}
+
+func (v TokenVerifiers) Verify(ctx context.Context, token string) (entity.Principal, error) {
+	if strings.HasPrefix(token, entity.APIKeyPrefix) {
+		return v.APIKeys.Verify(ctx, token)
+	}
+	if v.JWT == nil {
+		return entity.Principal{}, fmt.Errorf("%w: JWT authentication is not configured", ErrUnauthenticated)
+	}
+	return v.JWT.Verify(ctx, token)
+}
+
+// RequireScope проверяет, что автору запроса разрешена область доступа scope. Запросы без
+// аутентификации не ограничиваются.
+func RequireScope(ctx context.Context, scope string) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if ok && !principal.HasScope(scope) {
+		return fmt.Errorf("%w: %s is required", ErrInsufficientScope, scope)
+	}
+	return nil
+}
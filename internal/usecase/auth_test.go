@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+)
+
+type fakeVerifier struct {
+	subject string
+}
+
+func (v fakeVerifier) Verify(ctx context.Context, token string) (entity.Principal, error) {
+	return entity.Principal{Subject: v.subject}, nil
+}
+
+func TestTokenVerifiers(t *testing.T) {
+	apiKey := entity.APIKeyPrefix + "secret"
+
+	tests := []struct {
+		name      string
+		verifiers TokenVerifiers
+		token     string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "api key",
+			verifiers: TokenVerifiers{JWT: fakeVerifier{"user"}, APIKeys: fakeVerifier{"bot"}},
+			token:     apiKey,
+			want:      "bot",
+		},
+		{
+			name:      "jwt",
+			verifiers: TokenVerifiers{JWT: fakeVerifier{"user"}, APIKeys: fakeVerifier{"bot"}},
+			token:     "header.payload.signature",
+			want:      "user",
+		},
+		{
+			name:      "api key without jwt",
+			verifiers: TokenVerifiers{APIKeys: fakeVerifier{"bot"}},
+			token:     apiKey,
+			want:      "bot",
+		},
+		{
+			name:      "jwt without jwt verifier",
+			verifiers: TokenVerifiers{APIKeys: fakeVerifier{"bot"}},
+			token:     "header.payload.signature",
+			wantErr:   ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := tt.verifiers.Verify(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if principal.Subject != tt.want {
+				t.Errorf("subject = %q, want %q", principal.Subject, tt.want)
+			}
+		})
+	}
+}
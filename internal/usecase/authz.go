@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+// ErrForbidden — общий признак отказа в доступе: errors.Is(err, ErrForbidden) выполняется
+// для любой *ForbiddenError, а транспорт отвечает по ней кодом forbidden.
+var ErrForbidden = entity.NewError(entity.KindForbidden, "forbidden", "forbidden")
+
+// ForbiddenError — отказ в доступе к задаче или проекту с причиной отказа.
+type ForbiddenError struct {
+	Reason string
+}
+
+func (e *ForbiddenError) Error() string {
+	return "forbidden: " + e.Reason
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return ErrForbidden
+}
+
+// AuthorizationService — единственное место, где проверяются права на задачи и проекты.
+// Задачи проекта доступны по роли автора запроса в проекте (см. entity.ProjectRole),
+// задачи вне проектов видны всем, а изменять их могут автор и исполнитель, удалять — автор.
+// Запросы без аутентификации (фоновые задачи, отключенная аутентификация) и запросы
+// с областью доступа admin не ограничиваются.
+type AuthorizationService struct {
+	memberRepo ProjectMemberRepository
+	taskRepo   TaskRepository
+}
+
+func NewAuthorizationService(memberRepo ProjectMemberRepository, taskRepo TaskRepository) *AuthorizationService {
+	return &AuthorizationService{
+		memberRepo: memberRepo,
+		taskRepo:   taskRepo,
+	}
+}
+
+// restricted возвращает автора запроса, если к запросу применяются проверки прав.
+func (s *AuthorizationService) restricted(ctx context.Context) (entity.Principal, bool) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.HasScope(entity.ScopeAdmin) {
+		return entity.Principal{}, false
+	}
+	return principal, true
+}
+
+// AuthorizeProject проверяет, что автору запроса разрешена операция action в проекте.
+// nil означает задачи вне проектов: их может просматривать и создавать любой пользователь.
+func (s *AuthorizationService) AuthorizeProject(ctx context.Context, action entity.Action, projectID *uuid.UUID) error {
+	principal, restricted := s.restricted(ctx)
+	if !restricted || projectID == nil {
+		return nil
+	}
+	role, err := s.memberRepo.Role(ctx, *projectID, principal.Subject)
+	if err != nil {
+		return err
+	}
+	if !role.Includes(action.RequiredRole()) {
+		return &ForbiddenError{Reason: fmt.Sprintf("%s role in the project is required to %s", action.RequiredRole(), action)}
+	}
+	return nil
+}
+
+// AuthorizeTask проверяет, что автору запроса разрешена операция action над задачей.
+// Участник проекта с ролью member может удалять задачи, которые создал сам.
+func (s *AuthorizationService) AuthorizeTask(ctx context.Context, action entity.Action, task entity.Task) error {
+	principal, restricted := s.restricted(ctx)
+	if !restricted {
+		return nil
+	}
+	if task.ProjectID == nil {
+		return checkOwnership(principal, action, task)
+	}
+
+	role, err := s.memberRepo.Role(ctx, *task.ProjectID, principal.Subject)
+	if err != nil {
+		return err
+	}
+	if role.Includes(action.RequiredRole()) {
+		return nil
+	}
+	if action == entity.ActionDelete && role.Includes(entity.RoleMember) && task.CreatedBy == principal.Subject {
+		return nil
+	}
+	return &ForbiddenError{Reason: fmt.Sprintf("%s role in the project is required to %s the task", action.RequiredRole(), action)}
+}
+
+// AuthorizeComment проверяет право изменить или удалить комментарий к задаче task: это
+// может автор комментария и участник проекта задачи с ролью maintainer и выше. Комментарии
+// без автора проверяются только по правам на задачу.
+func (s *AuthorizationService) AuthorizeComment(ctx context.Context, task entity.Task, comment entity.Comment) error {
+	principal, restricted := s.restricted(ctx)
+	if !restricted || comment.CreatedBy == "" || comment.CreatedBy == principal.Subject {
+		return nil
+	}
+	if task.ProjectID != nil {
+		role, err := s.memberRepo.Role(ctx, *task.ProjectID, principal.Subject)
+		if err != nil {
+			return err
+		}
+		if role.Includes(entity.RoleMaintainer) {
+			return nil
+		}
+	}
+	return ErrNotCommentAuthor
+}
+
+// LoadTask загружает задачу и проверяет, что автору запроса разрешена операция action над ней.
+// Используется вложенными ресурсами задачи: комментариями, вложениями, историей и повторениями.
+func (s *AuthorizationService) LoadTask(ctx context.Context, action entity.Action, id string) (entity.Task, error) {
+	task, err := s.taskRepo.Get(ctx, id)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	if err := s.AuthorizeTask(ctx, action, task); err != nil {
+		return entity.Task{}, err
+	}
+	return task, nil
+}
+
+// FilterVisible оставляет задачи, которые автор запроса может просматривать. Роль
+// в каждом проекте запрашивается один раз.
+func (s *AuthorizationService) FilterVisible(ctx context.Context, tasks []entity.Task) ([]entity.Task, error) {
+	principal, restricted := s.restricted(ctx)
+	if !restricted {
+		return tasks, nil
+	}
+	roles := make(map[uuid.UUID]entity.ProjectRole)
+	visible := tasks[:0:0]
+	for _, task := range tasks {
+		if task.ProjectID != nil {
+			role, checked := roles[*task.ProjectID]
+			if !checked {
+				var err error
+				if role, err = s.memberRepo.Role(ctx, *task.ProjectID, principal.Subject); err != nil {
+					return nil, err
+				}
+				roles[*task.ProjectID] = role
+			}
+			if !role.Includes(entity.ActionView.RequiredRole()) {
+				continue
+			}
+		}
+		visible = append(visible, task)
+	}
+	return visible, nil
+}
+
+// AuthorizeAdmin проверяет право на обслуживание всех задач сразу, например очистку корзины.
+func (s *AuthorizationService) AuthorizeAdmin(ctx context.Context) error {
+	return RequireScope(ctx, entity.ScopeAdmin)
+}
+
+// VisibleTo возвращает пользователя, которому списки задач показывают только задачи
+// вне проектов и задачи его проектов. Пустая строка означает, что списки не ограничены.
+func (s *AuthorizationService) VisibleTo(ctx context.Context) string {
+	principal, restricted := s.restricted(ctx)
+	if !restricted {
+		return ""
+	}
+	return principal.Subject
+}
+
+// checkOwnership применяет к задаче вне проектов права автора и исполнителя. Задачи
+// без автора, созданные до появления аутентификации, не ограничиваются.
+func checkOwnership(principal entity.Principal, action entity.Action, task entity.Task) error {
+	if task.CreatedBy == "" || principal.Subject == task.CreatedBy {
+		return nil
+	}
+	switch action {
+	case entity.ActionView, entity.ActionCreate:
+		return nil
+	case entity.ActionEdit:
+		if principal.Subject == task.AssigneeID {
+			return nil
+		}
+	}
+	return ErrNotTaskOwner
+}
+
+type ProjectMemberRepository interface {
+	// Role возвращает роль пользователя в проекте или пустую роль, если её нет.
+	Role(ctx context.Context, projectID uuid.UUID, subject string) (entity.ProjectRole, error)
+	List(ctx context.Context, projectID uuid.UUID) ([]entity.ProjectMember, error)
+	// Set назначает или меняет роль участника. Set и Remove возвращают ErrLastProjectOwner,
+	// если у проекта не осталось бы владельца.
+	Set(ctx context.Context, member entity.ProjectMember) (entity.ProjectMember, error)
+	// Remove снимает роль; ErrProjectMemberNotFound, если роли нет.
+	Remove(ctx context.Context, projectID uuid.UUID, subject string) error
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestAuthorizationRoles(t *testing.T) {
+	projectID := uuid.New()
+	members := fakeMemberRepo{roles: map[uuid.UUID]map[string]entity.ProjectRole{
+		projectID: {
+			"viewer":     entity.RoleViewer,
+			"member":     entity.RoleMember,
+			"maintainer": entity.RoleMaintainer,
+			"owner":      entity.RoleOwner,
+		},
+	}}
+	authz := NewAuthorizationService(members, nil)
+	actions := []entity.Action{entity.ActionView, entity.ActionCreate, entity.ActionEdit, entity.ActionDelete, entity.ActionManage}
+
+	// allowed перечисляет операции, разрешенные автору запроса в проекте; пустой subject
+	// означает запрос без аутентификации.
+	tests := []struct {
+		name    string
+		subject string
+		scopes  []string
+		allowed []entity.Action
+	}{
+		{name: "not a member", subject: "stranger"},
+		{name: "viewer", subject: "viewer", allowed: actions[:1]},
+		{name: "member", subject: "member", allowed: actions[:3]},
+		{name: "maintainer", subject: "maintainer", allowed: actions[:4]},
+		{name: "owner", subject: "owner", allowed: actions},
+		{name: "admin scope", subject: "stranger", scopes: []string{entity.ScopeAdmin}, allowed: actions},
+		{name: "unauthenticated", allowed: actions},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.subject != "" {
+			scopes := tt.scopes
+			if scopes == nil {
+				scopes = []string{entity.ScopeTasksWrite}
+			}
+			ctx = WithPrincipal(ctx, entity.Principal{Subject: tt.subject, Scopes: scopes})
+		}
+		task := entity.Task{ID: uuid.New(), ProjectID: &projectID, CreatedBy: "someone"}
+
+		for _, action := range actions {
+			want := slices.Contains(tt.allowed, action)
+			t.Run(tt.name+"/project/"+string(action), func(t *testing.T) {
+				checkAuthorized(t, authz.AuthorizeProject(ctx, action, &projectID), want)
+			})
+			t.Run(tt.name+"/task/"+string(action), func(t *testing.T) {
+				checkAuthorized(t, authz.AuthorizeTask(ctx, action, task), want)
+			})
+		}
+
+		t.Run(tt.name+"/outside projects", func(t *testing.T) {
+			checkAuthorized(t, authz.AuthorizeProject(ctx, entity.ActionCreate, nil), true)
+		})
+		t.Run(tt.name+"/visible to", func(t *testing.T) {
+			want := tt.subject
+			if len(tt.scopes) > 0 {
+				want = ""
+			}
+			if got := authz.VisibleTo(ctx); got != want {
+				t.Errorf("VisibleTo = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeTaskOwnership(t *testing.T) {
+	projectID := uuid.New()
+	members := fakeMemberRepo{roles: map[uuid.UUID]map[string]entity.ProjectRole{
+		projectID: {"viewer": entity.RoleViewer, "member": entity.RoleMember},
+	}}
+	authz := NewAuthorizationService(members, nil)
+
+	personal := entity.Task{ID: uuid.New(), CreatedBy: "author", AssigneeID: "assignee"}
+	legacy := entity.Task{ID: uuid.New()}
+	own := func(subject string) entity.Task {
+		return entity.Task{ID: uuid.New(), ProjectID: &projectID, CreatedBy: subject}
+	}
+
+	tests := []struct {
+		name    string
+		subject string
+		action  entity.Action
+		task    entity.Task
+		wantErr error
+	}{
+		{name: "member deletes own task", subject: "member", action: entity.ActionDelete, task: own("member")},
+		{name: "viewer deletes own task", subject: "viewer", action: entity.ActionDelete, task: own("viewer"), wantErr: ErrForbidden},
+		{name: "author edits personal task", subject: "author", action: entity.ActionEdit, task: personal},
+		{name: "author deletes personal task", subject: "author", action: entity.ActionDelete, task: personal},
+		{name: "assignee edits personal task", subject: "assignee", action: entity.ActionEdit, task: personal},
+		{name: "assignee deletes personal task", subject: "assignee", action: entity.ActionDelete, task: personal, wantErr: ErrNotTaskOwner},
+		{name: "stranger views personal task", subject: "stranger", action: entity.ActionView, task: personal},
+		{name: "stranger edits personal task", subject: "stranger", action: entity.ActionEdit, task: personal, wantErr: ErrNotTaskOwner},
+		{name: "stranger deletes task without author", subject: "stranger", action: entity.ActionDelete, task: legacy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithPrincipal(context.Background(), entity.Principal{Subject: tt.subject, Scopes: []string{entity.ScopeTasksWrite}})
+			if err := authz.AuthorizeTask(ctx, tt.action, tt.task); !errors.Is(err, tt.wantErr) {
+				t.Errorf("AuthorizeTask error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// checkAuthorized проверяет, что err — nil для разрешенной операции и отказ ErrForbidden иначе.
+func checkAuthorized(t *testing.T, err error, allowed bool) {
+	t.Helper()
+	if allowed && err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !allowed && !errors.Is(err, ErrForbidden) {
+		t.Errorf("error = %v, want ErrForbidden", err)
+	}
+}
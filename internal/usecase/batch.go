@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// MaxBatchSize — максимальное число операций в одном пакете.
+const MaxBatchSize = 1000
+
+var (
+	ErrEmptyBatch    = entity.NewError(entity.KindInvalidArgument, "empty_batch", "batch is empty")
+	ErrBatchTooLarge = entity.NewError(entity.KindInvalidArgument, "batch_too_large", "batch is too large")
+	// ErrBatchAborted возвращается вместе с результатами, если атомарный пакет был отменен.
+	ErrBatchAborted = entity.NewError(entity.KindFailedPrecondition, "batch_aborted", "batch aborted")
+	ErrTaskExists   = entity.NewError(entity.KindAlreadyExists, "task_exists", "task already exists")
+	// ErrNotApplied отмечает операции атомарного пакета, отмененные из-за ошибки в другой операции.
+	ErrNotApplied = entity.NewError(entity.KindFailedPrecondition, "not_applied", "not applied: batch aborted")
+)
+
+func (uc *TaskUseCaseImpl) BatchCreate(ctx context.Context, tasks []entity.Task, atomic bool) (entity.BatchResult, error) {
+	logger.Log.Info("Starting batch task creation", "count", len(tasks))
+
+	if err := checkBatchSize(len(tasks)); err != nil {
+		return entity.BatchResult{}, err
+	}
+
+	fields, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to load custom fields for batch")
+		return entity.BatchResult{}, err
+	}
+
+	now := time.Now()
+	errs := make([]error, len(tasks))
+	projects := make(map[uuid.UUID]error)
+	// Повтор ID в пакете отклоняется заранее: в неатомарном режиме вставка пропустила бы
+	// конфликт и отметила обе операции успешными
+	seen := make(map[uuid.UUID]bool, len(tasks))
+	for i := range tasks {
+		if id := tasks[i].ID; id != uuid.Nil {
+			if seen[id] {
+				errs[i] = ErrTaskExists
+				continue
+			}
+			seen[id] = true
+		}
+		if err := tasks[i].Validate(); err != nil {
+			errs[i] = fmt.Errorf("%w: %w", ErrInvalidTask, err)
+			continue
+		}
+		if err := uc.checkStatus(tasks[i]); err != nil {
+			errs[i] = err
+			continue
+		}
+		if err := normalizeCustomFields(fields, &tasks[i]); err != nil {
+			errs[i] = err
+			continue
+		}
+		if tasks[i].ID == uuid.Nil {
+			tasks[i].ID = uuid.New()
+		}
+		tasks[i].Rank = ""
+		stampCreator(ctx, &tasks[i])
+		if err := uc.checkParent(ctx, &tasks[i]); err != nil {
+			errs[i] = err
+			continue
+		}
+		if projectID := tasks[i].ProjectID; projectID != nil {
+			// Проверяем каждый проект пакета один раз
+			err, checked := projects[*projectID]
+			if !checked {
+				err = uc.checkProject(ctx, projectID)
+				if err == nil {
+					err = uc.authz.AuthorizeProject(ctx, entity.ActionCreate, projectID)
+				}
+				projects[*projectID] = err
+			}
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+		}
+		tasks[i].CreatedAt = now
+		tasks[i].UpdatedAt = now
+	}
+
+	return uc.runBatch(ctx, "create", errs, atomic, func(valid []int) ([]entity.BatchItemResult, error) {
+		batch := make([]entity.Task, len(valid))
+		for i, idx := range valid {
+			batch[i] = tasks[idx]
+		}
+		return uc.taskRepo.CreateBatch(ctx, batch, atomic)
+	}, func(i int) uuid.UUID { return tasks[i].ID })
+}
+
+func (uc *TaskUseCaseImpl) BatchUpdate(ctx context.Context, tasks []entity.Task, atomic bool) (entity.BatchResult, error) {
+	logger.Log.Info("Starting batch task update", "count", len(tasks))
+
+	if err := checkBatchSize(len(tasks)); err != nil {
+		return entity.BatchResult{}, err
+	}
+
+	fields, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to load custom fields for batch")
+		return entity.BatchResult{}, err
+	}
+
+	errs := make([]error, len(tasks))
+	for i := range tasks {
+		if tasks[i].ID == uuid.Nil {
+			errs[i] = ErrTaskNotFound
+			continue
+		}
+		if err := tasks[i].Validate(); err != nil {
+			errs[i] = fmt.Errorf("%w: %w", ErrInvalidTask, err)
+			continue
+		}
+		if err := normalizeCustomFields(fields, &tasks[i]); err != nil {
+			errs[i] = err
+		}
+	}
+
+	return uc.runBatch(ctx, "update", errs, atomic, func(valid []int) ([]entity.BatchItemResult, error) {
+		refs := make([]entity.TaskRef, len(valid))
+		for i, idx := range valid {
+			refs[i] = entity.TaskRef{ID: tasks[idx].ID, Version: tasks[idx].Version}
+		}
+		return uc.taskRepo.UpdateBatch(ctx, refs, atomic, func(i int, current *entity.Task) error {
+			return uc.replace(ctx, current, tasks[valid[i]], UpdateOptions{})
+		})
+	}, func(i int) uuid.UUID { return tasks[i].ID })
+}
+
+func (uc *TaskUseCaseImpl) BatchDelete(ctx context.Context, refs []entity.TaskRef, atomic bool) (entity.BatchResult, error) {
+	logger.Log.Info("Starting batch task deletion", "count", len(refs))
+
+	if err := checkBatchSize(len(refs)); err != nil {
+		return entity.BatchResult{}, err
+	}
+
+	errs := make([]error, len(refs))
+	for i := range refs {
+		if refs[i].ID == uuid.Nil {
+			errs[i] = ErrTaskNotFound
+		}
+	}
+
+	return uc.runBatch(ctx, "delete", errs, atomic, func(valid []int) ([]entity.BatchItemResult, error) {
+		batch := make([]entity.TaskRef, len(valid))
+		for i, idx := range valid {
+			batch[i] = refs[idx]
+		}
+		// Права проверяются по заблокированной строке, поэтому задачу нельзя перенести
+		// в другой проект между проверкой и удалением
+		return uc.taskRepo.DeleteBatch(ctx, batch, atomic, func(_ int, task entity.Task) error {
+			return uc.authz.AuthorizeTask(ctx, entity.ActionDelete, task)
+		})
+	}, func(i int) uuid.UUID { return refs[i].ID })
+}
+
+func checkBatchSize(n int) error {
+	if n == 0 {
+		return ErrEmptyBatch
+	}
+	if n > MaxBatchSize {
+		return ErrBatchTooLarge
+	}
+	return nil
+}
+
+// runBatch передает в репозиторий операции без ошибок валидации и собирает общий
+// результат в порядке входного списка. Атомарный пакет с ошибками валидации
+// отменяется без обращения к репозиторию. Кэш сбрасывается один раз на пакет.
+func (uc *TaskUseCaseImpl) runBatch(ctx context.Context, op string, errs []error, atomic bool,
+	apply func(valid []int) ([]entity.BatchItemResult, error), idOf func(i int) uuid.UUID) (entity.BatchResult, error) {
+	result := entity.BatchResult{Items: make([]entity.BatchItemResult, len(errs))}
+
+	var valid []int
+	for i, err := range errs {
+		result.Items[i] = entity.BatchItemResult{Index: i, ID: idOf(i)}
+		if err != nil {
+			result.Items[i].Fail(err)
+		} else {
+			valid = append(valid, i)
+		}
+	}
+
+	if atomic && len(valid) < len(errs) {
+		for _, i := range valid {
+			result.Items[i].Fail(ErrNotApplied)
+		}
+		result.Failed = len(errs)
+		logger.Log.Warn("Batch validation failed", "op", op)
+		return result, ErrBatchAborted
+	}
+
+	var applyErr error
+	if len(valid) > 0 {
+		items, err := apply(valid)
+		if err != nil && !errors.Is(err, ErrBatchAborted) {
+			logger.Log.WithError(err).Error("Failed to apply batch")
+			return entity.BatchResult{}, err
+		}
+		applyErr = err
+		for j, item := range items {
+			i := valid[j]
+			item.Index = i
+			result.Items[i] = item
+		}
+	}
+
+	for _, item := range result.Items {
+		if item.Error != "" {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+	}
+	result.Committed = applyErr == nil && result.Succeeded > 0
+
+	if result.Committed {
+		if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+			logger.Log.WithError(err).Error("Failed to invalidate cache after batch")
+		}
+	}
+
+	logger.Log.Info("Batch processed", "op", op, "succeeded", result.Succeeded, "failed", result.Failed)
+	return result, applyErr
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestBatchCreateDuplicateIDs(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		atomic    bool
+		wantErr   error
+		wantCodes []string
+	}{
+		{
+			name:      "non-atomic",
+			wantCodes: []string{"", "", "task_exists"},
+		},
+		{
+			name:      "atomic",
+			atomic:    true,
+			wantErr:   ErrBatchAborted,
+			wantCodes: []string{"not_applied", "not_applied", "task_exists"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeTaskRepo()
+			tasks := []entity.Task{
+				{ID: id, Title: "First", Status: "todo"},
+				{Title: "Second", Status: "todo"},
+				{ID: id, Title: "Duplicate", Status: "todo"},
+			}
+
+			result, err := newTestTaskUseCase(repo).BatchCreate(context.Background(), tasks, tt.atomic)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BatchCreate error = %v, want %v", err, tt.wantErr)
+			}
+			var codes []string
+			for _, item := range result.Items {
+				codes = append(codes, item.Code)
+			}
+			if !slices.Equal(codes, tt.wantCodes) {
+				t.Errorf("item codes = %q, want %q", codes, tt.wantCodes)
+			}
+			if tt.atomic && len(repo.tasks) != 0 {
+				t.Errorf("atomic batch stored %d tasks", len(repo.tasks))
+			}
+			if !tt.atomic && repo.tasks[id].Title != "First" {
+				t.Errorf("stored task %q, want the first one", repo.tasks[id].Title)
+			}
+		})
+	}
+}
+
+func TestBatchValidationErrors(t *testing.T) {
+	existing := entity.Task{ID: uuid.New(), Title: "Existing", Status: "todo", Version: 1}
+	invalid := entity.Task{ID: existing.ID, Status: "todo"}
+
+	tests := []struct {
+		name string
+		run  func(uc *TaskUseCaseImpl) (entity.BatchResult, error)
+	}{
+		{
+			name: "create",
+			run: func(uc *TaskUseCaseImpl) (entity.BatchResult, error) {
+				return uc.BatchCreate(context.Background(), []entity.Task{{Status: "todo"}}, false)
+			},
+		},
+		{
+			name: "update",
+			run: func(uc *TaskUseCaseImpl) (entity.BatchResult, error) {
+				return uc.BatchUpdate(context.Background(), []entity.Task{invalid}, false)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.run(newTestTaskUseCase(newFakeTaskRepo(existing)))
+			if err != nil {
+				t.Fatalf("batch error = %v", err)
+			}
+			item := result.Items[0]
+			if item.Code != ErrInvalidTask.Code {
+				t.Errorf("item code = %q, want %q", item.Code, ErrInvalidTask.Code)
+			}
+			if !item.Fields.Has("title") {
+				t.Errorf("item fields = %v, want an error for title", item.Fields)
+			}
+		})
+	}
+}
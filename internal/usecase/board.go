@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// ErrInvalidPosition возвращается, если соседи задачи на доске не найдены в её колонке
+// или идут не в том порядке.
+var ErrInvalidPosition = entity.NewError(entity.KindFailedPrecondition, "invalid_position", "invalid board position")
+
+func (uc *TaskUseCaseImpl) MoveOnBoard(ctx context.Context, id string, version int64, position entity.BoardPosition, opts ...UpdateOption) (entity.Task, error) {
+	logger.Log.Info("Moving task on board", "id", id, "status", position.Status)
+
+	options := newUpdateOptions(opts)
+	movedTask, err := uc.taskRepo.RankFunc(ctx, id, version, position, func(task *entity.Task) error {
+		if err := uc.authz.AuthorizeTask(ctx, entity.ActionEdit, *task); err != nil {
+			return err
+		}
+		if position.Status == "" || position.Status == task.Status {
+			task.UpdatedAt = time.Now()
+			return nil
+		}
+		updated := *task
+		updated.Status = position.Status
+		if err := uc.checkTransition(*task, updated); err != nil {
+			return err
+		}
+		if err := uc.checkBlockers(ctx, *task, updated, options); err != nil {
+			return err
+		}
+		updated.UpdatedAt = time.Now()
+		*task = updated
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to move task on board")
+		return entity.Task{}, err
+	}
+
+	if err := uc.invalidateProjects(ctx, movedTask.ProjectID); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after board move")
+	}
+
+	// Изменение уже сохранено, поэтому ошибка загрузки меток не отменяет его
+	if err := uc.loadLabels(ctx, &movedTask); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+	}
+
+	logger.Log.Info("Task moved on board successfully", "id", id)
+	return movedTask, nil
+}
+
+func (uc *TaskUseCaseImpl) Board(ctx context.Context, params entity.BoardParams) (entity.Board, error) {
+	logger.Log.Info("Getting board", "project_id", params.ProjectID)
+
+	board := entity.Board{Columns: make([]entity.BoardColumn, 0, len(uc.workflow.Statuses))}
+	for _, status := range uc.workflow.Statuses {
+		page, err := uc.List(ctx, entity.TaskListParams{
+			Filter: entity.TaskFilter{ProjectID: params.ProjectID, Statuses: []string{status}},
+			Sort:   entity.TaskSort{Field: entity.TaskSortRank},
+			Limit:  params.Limit,
+		})
+		if err != nil {
+			return entity.Board{}, err
+		}
+		board.Columns = append(board.Columns, entity.BoardColumn{
+			Status:     status,
+			Tasks:      page.Tasks,
+			NextCursor: page.NextCursor,
+			HasMore:    page.HasMore,
+		})
+	}
+	return board, nil
+}
+
+func (uc *TaskUseCaseImpl) RebalanceRanks(ctx context.Context) (int64, error) {
+	if err := uc.authz.AuthorizeAdmin(ctx); err != nil {
+		return 0, err
+	}
+	statuses, err := uc.taskRepo.LongRankColumns(ctx, entity.MaxRankLength)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to find board columns to rebalance")
+		return 0, err
+	}
+
+	var total int64
+	for _, status := range statuses {
+		n, err := uc.taskRepo.RebalanceRanks(ctx, status, entity.MaxRankLength)
+		if err != nil {
+			logger.Log.WithError(err).Error("Failed to rebalance board column", "status", status)
+			return total, err
+		}
+		total += n
+	}
+
+	if total > 0 {
+		if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+			logger.Log.WithError(err).Error("Failed to invalidate cache after rank rebalance")
+		}
+		logger.Log.Info("Board ranks rebalanced", "count", total)
+	}
+	return total, nil
+}
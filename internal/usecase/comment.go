@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCommentNotFound = entity.NewError(entity.KindNotFound, "comment_not_found", "comment not found")
+	ErrInvalidComment  = entity.NewError(entity.KindValidation, "invalid_comment", "invalid comment")
+	// ErrInvalidReply возвращается при ответе на ответ: вложенность ограничена одним уровнем.
+	ErrInvalidReply = entity.NewError(entity.KindFailedPrecondition, "invalid_reply", "replies are only allowed to top-level comments")
+	// ErrNotCommentAuthor возвращается, если комментарий меняет не автор и не maintainer проекта.
+	ErrNotCommentAuthor = &ForbiddenError{Reason: "comment is owned by another user"}
+)
+
+// CommentUseCase управляет комментариями задач. Комментарии задач из корзины
+// недоступны, пока задача не восстановлена.
+type CommentUseCase interface {
+	Create(ctx context.Context, taskID string, comment entity.Comment) (entity.Comment, error)
+	List(ctx context.Context, taskID string, params entity.CommentListParams) (entity.CommentPage, error)
+	// Update меняет текст комментария и отмечает время правки. Update и Delete разрешены
+	// автору комментария и участникам проекта с ролью maintainer и выше.
+	Update(ctx context.Context, taskID, id, body string) (entity.Comment, error)
+	// Delete удаляет комментарий вместе с ответами на него.
+	Delete(ctx context.Context, taskID, id string) error
+}
+
+type CommentUseCaseImpl struct {
+	commentRepo CommentRepository
+	authz       *AuthorizationService
+}
+
+func NewCommentUseCase(commentRepo CommentRepository, authz *AuthorizationService) *CommentUseCaseImpl {
+	return &CommentUseCaseImpl{
+		commentRepo: commentRepo,
+		authz:       authz,
+	}
+}
+
+func (uc *CommentUseCaseImpl) Create(ctx context.Context, taskID string, comment entity.Comment) (entity.Comment, error) {
+	logger.Log.Info("Starting comment creation", "task_id", taskID)
+
+	if err := comment.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Comment validation failed")
+		return entity.Comment{}, fmt.Errorf("%w: %w", ErrInvalidComment, err)
+	}
+
+	task, err := uc.authz.LoadTask(ctx, entity.ActionEdit, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for comment")
+		return entity.Comment{}, err
+	}
+	if comment.ParentID != nil {
+		parent, err := uc.commentRepo.Get(ctx, task.ID, *comment.ParentID)
+		if err != nil {
+			logger.Log.WithError(err).Error("Failed to get parent comment")
+			return entity.Comment{}, err
+		}
+		if parent.ParentID != nil {
+			return entity.Comment{}, ErrInvalidReply
+		}
+	}
+
+	comment.ID = uuid.New()
+	comment.TaskID = task.ID
+	comment.CreatedBy = ""
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		comment.CreatedBy = principal.Subject
+	}
+	comment.CreatedAt = time.Now()
+	comment.EditedAt = nil
+	comment.Replies = nil
+
+	createdComment, err := uc.commentRepo.Create(ctx, comment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to create comment")
+		return entity.Comment{}, err
+	}
+
+	logger.Log.Info("Comment created successfully", "comment_id", createdComment.ID)
+	return createdComment, nil
+}
+
+func (uc *CommentUseCaseImpl) List(ctx context.Context, taskID string, params entity.CommentListParams) (entity.CommentPage, error) {
+	logger.Log.Info("Listing comments", "task_id", taskID)
+
+	task, err := uc.authz.LoadTask(ctx, entity.ActionView, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for comments")
+		return entity.CommentPage{}, err
+	}
+
+	limit := params.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	if params.Cursor != "" {
+		after, err := decodeCommentCursor(params.Cursor)
+		if err != nil {
+			logger.Log.WithError(err).Warn("Invalid comments cursor")
+			return entity.CommentPage{}, ErrInvalidCursor
+		}
+		params.After = &after
+	}
+
+	// Запрашиваем на один комментарий больше, чтобы понять, есть ли следующая страница
+	params.Limit = limit + 1
+	comments, err := uc.commentRepo.List(ctx, task.ID, params)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list comments from repository")
+		return entity.CommentPage{}, err
+	}
+
+	page := entity.CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		page.HasMore = true
+		last := page.Comments[limit-1]
+		page.NextCursor = encodeCommentCursor(entity.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	if page.Comments == nil {
+		page.Comments = []entity.Comment{}
+	}
+
+	if len(page.Comments) > 0 {
+		ids := make([]uuid.UUID, len(page.Comments))
+		for i, comment := range page.Comments {
+			ids[i] = comment.ID
+		}
+		replies, err := uc.commentRepo.Replies(ctx, ids)
+		if err != nil {
+			logger.Log.WithError(err).Error("Failed to load comment replies")
+			return entity.CommentPage{}, err
+		}
+		for i := range page.Comments {
+			page.Comments[i].Replies = replies[page.Comments[i].ID]
+		}
+	}
+
+	logger.Log.Info("Comments listed successfully", "count", len(page.Comments))
+	return page, nil
+}
+
+func (uc *CommentUseCaseImpl) Update(ctx context.Context, taskID, id, body string) (entity.Comment, error) {
+	logger.Log.Info("Starting comment update", "task_id", taskID, "id", id)
+
+	commentID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.Comment{}, ErrCommentNotFound
+	}
+	task, err := uc.authz.LoadTask(ctx, entity.ActionEdit, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for comment")
+		return entity.Comment{}, err
+	}
+
+	now := time.Now()
+	comment := entity.Comment{ID: commentID, TaskID: task.ID, Body: body, EditedAt: &now}
+	if err := comment.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Validation failed during comment update")
+		return entity.Comment{}, fmt.Errorf("%w: %w", ErrInvalidComment, err)
+	}
+	if err := uc.authorizeComment(ctx, task, commentID); err != nil {
+		logger.Log.WithError(err).Warn("Comment update rejected")
+		return entity.Comment{}, err
+	}
+
+	updatedComment, err := uc.commentRepo.Update(ctx, comment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to update comment in repository")
+		return entity.Comment{}, err
+	}
+
+	logger.Log.Info("Comment updated successfully", "id", id)
+	return updatedComment, nil
+}
+
+func (uc *CommentUseCaseImpl) Delete(ctx context.Context, taskID, id string) error {
+	logger.Log.Info("Deleting comment", "task_id", taskID, "id", id)
+
+	commentID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+	task, err := uc.authz.LoadTask(ctx, entity.ActionEdit, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for comment")
+		return err
+	}
+	if err := uc.authorizeComment(ctx, task, commentID); err != nil {
+		logger.Log.WithError(err).Warn("Comment deletion rejected")
+		return err
+	}
+
+	if err := uc.commentRepo.Delete(ctx, task.ID, commentID); err != nil {
+		logger.Log.WithError(err).Error("Failed to delete comment from repository")
+		return err
+	}
+
+	logger.Log.Info("Comment deleted successfully", "id", id)
+	return nil
+}
+
+// authorizeComment загружает комментарий задачи и проверяет право изменить его.
+func (uc *CommentUseCaseImpl) authorizeComment(ctx context.Context, task entity.Task, id uuid.UUID) error {
+	comment, err := uc.commentRepo.Get(ctx, task.ID, id)
+	if err != nil {
+		return err
+	}
+	return uc.authz.AuthorizeComment(ctx, task, comment)
+}
+
+func encodeCommentCursor(c entity.CommentCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCommentCursor(s string) (entity.CommentCursor, error) {
+	var c entity.CommentCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment entity.Comment) (entity.Comment, error)
+	// Get, Update и Delete находят комментарий только среди комментариев задачи taskID.
+	Get(ctx context.Context, taskID, id uuid.UUID) (entity.Comment, error)
+	Update(ctx context.Context, comment entity.Comment) (entity.Comment, error)
+	Delete(ctx context.Context, taskID, id uuid.UUID) error
+	// List возвращает комментарии верхнего уровня в порядке создания.
+	List(ctx context.Context, taskID uuid.UUID, params entity.CommentListParams) ([]entity.Comment, error)
+	// Replies возвращает ответы на комментарии, сгруппированные по ID родителя.
+	Replies(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID][]entity.Comment, error)
+}
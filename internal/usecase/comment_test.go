@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestCommentAuthorship(t *testing.T) {
+	projectID := uuid.New()
+	projectTask := entity.Task{ID: uuid.New(), Title: "Project task", Status: "todo", ProjectID: &projectID, Version: 1}
+	personalTask := entity.Task{ID: uuid.New(), Title: "Personal task", Status: "todo", CreatedBy: "owner", Version: 1}
+	members := fakeMemberRepo{roles: map[uuid.UUID]map[string]entity.ProjectRole{
+		projectID: {
+			"author":     entity.RoleMember,
+			"member":     entity.RoleMember,
+			"maintainer": entity.RoleMaintainer,
+		},
+	}}
+
+	user := func(subject string, scopes ...string) context.Context {
+		if len(scopes) == 0 {
+			scopes = []string{entity.ScopeTasksWrite}
+		}
+		return WithPrincipal(context.Background(), entity.Principal{Subject: subject, Scopes: scopes})
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		task      entity.Task
+		createdBy string
+		wantErr   error
+	}{
+		{name: "author", ctx: user("author"), task: projectTask, createdBy: "author"},
+		{name: "other member", ctx: user("member"), task: projectTask, createdBy: "author", wantErr: ErrNotCommentAuthor},
+		{name: "maintainer", ctx: user("maintainer"), task: projectTask, createdBy: "author"},
+		{name: "admin", ctx: user("admin", entity.ScopeAdmin), task: projectTask, createdBy: "author"},
+		{name: "unauthenticated", ctx: context.Background(), task: projectTask, createdBy: "author"},
+		{name: "comment without author", ctx: user("member"), task: projectTask},
+		{name: "task owner outside projects", ctx: user("owner"), task: personalTask, createdBy: "author", wantErr: ErrNotCommentAuthor},
+		{name: "author outside projects", ctx: user("author"), task: personalTask, createdBy: "author", wantErr: ErrNotTaskOwner},
+	}
+
+	for _, tt := range tests {
+		for _, op := range []string{"update", "delete"} {
+			t.Run(tt.name+"/"+op, func(t *testing.T) {
+				comment := entity.Comment{ID: uuid.New(), TaskID: tt.task.ID, Body: "Comment", CreatedBy: tt.createdBy}
+				comments := &fakeCommentRepo{comments: map[uuid.UUID]entity.Comment{comment.ID: comment}}
+				tasks := newFakeTaskRepo(projectTask, personalTask)
+				uc := NewCommentUseCase(comments, NewAuthorizationService(members, tasks))
+
+				var err error
+				if op == "update" {
+					_, err = uc.Update(tt.ctx, tt.task.ID.String(), comment.ID.String(), "Edited")
+				} else {
+					err = uc.Delete(tt.ctx, tt.task.ID.String(), comment.ID.String())
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("%s error = %v, want %v", op, err, tt.wantErr)
+				}
+				_, kept := comments.comments[comment.ID]
+				changed := !kept || comments.comments[comment.ID].Body != comment.Body
+				if changed != (tt.wantErr == nil) {
+					t.Errorf("comment changed = %v, want %v", changed, tt.wantErr == nil)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCustomFieldNotFound = entity.NewError(entity.KindNotFound, "custom_field_not_found", "custom field not found")
+	ErrCustomFieldExists   = entity.NewError(entity.KindAlreadyExists, "custom_field_exists", "custom field with this key already exists")
+	ErrInvalidCustomField  = entity.NewError(entity.KindValidation, "invalid_custom_field", "invalid custom field")
+)
+
+// customFieldIndexLease — на сколько реплика берет в работу построение индекса поля.
+// Аренда длиннее ограничения времени построения в репозитории, поэтому истекает, только
+// если реплика остановилась, и тогда построение возобновит другая.
+const customFieldIndexLease = 15 * time.Minute
+
+// CustomFieldUseCase управляет схемами пользовательских полей задач.
+type CustomFieldUseCase interface {
+	// Create сохраняет схему поля; индекс его значений строит BuildIndexes.
+	Create(ctx context.Context, field entity.CustomField) (entity.CustomField, error)
+	List(ctx context.Context) ([]entity.CustomField, error)
+	Get(ctx context.Context, id string) (entity.CustomField, error)
+	// Update меняет название, варианты и обязательность поля; ключ и тип не меняются.
+	// Значения, уже сохраненные у задач, не перепроверяются до их следующего изменения.
+	Update(ctx context.Context, field entity.CustomField) (entity.CustomField, error)
+	// Delete удаляет схему поля вместе с его значениями у всех задач.
+	Delete(ctx context.Context, id string) error
+	// BuildIndexes строит индексы не более limit полей, ожидающих построения, и возвращает
+	// число обработанных полей, включая поля, индекс которых построить не удалось.
+	BuildIndexes(ctx context.Context, limit int) (int, error)
+}
+
+type CustomFieldUseCaseImpl struct {
+	customFieldRepo CustomFieldRepository
+	cacheRepo       CacheRepository
+}
+
+func NewCustomFieldUseCase(customFieldRepo CustomFieldRepository, cacheRepo CacheRepository) *CustomFieldUseCaseImpl {
+	return &CustomFieldUseCaseImpl{
+		customFieldRepo: customFieldRepo,
+		cacheRepo:       cacheRepo,
+	}
+}
+
+func (uc *CustomFieldUseCaseImpl) Create(ctx context.Context, field entity.CustomField) (entity.CustomField, error) {
+	logger.Log.Info("Starting custom field creation", "key", field.Key)
+
+	if err := field.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Custom field validation failed")
+		return entity.CustomField{}, fmt.Errorf("%w: %w", ErrInvalidCustomField, err)
+	}
+
+	field.ID = uuid.New()
+	field.CreatedAt = time.Now()
+	field.UpdatedAt = field.CreatedAt
+
+	createdField, err := uc.customFieldRepo.Create(ctx, field)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to create custom field")
+		return entity.CustomField{}, err
+	}
+
+	logger.Log.Info("Custom field created successfully", "custom_field_id", createdField.ID)
+	return createdField, nil
+}
+
+func (uc *CustomFieldUseCaseImpl) List(ctx context.Context) ([]entity.CustomField, error) {
+	logger.Log.Info("Listing custom fields")
+	fields, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list custom fields from repository")
+		return nil, err
+	}
+	if fields == nil {
+		fields = []entity.CustomField{}
+	}
+	return fields, nil
+}
+
+func (uc *CustomFieldUseCaseImpl) Get(ctx context.Context, id string) (entity.CustomField, error) {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.CustomField{}, ErrCustomFieldNotFound
+	}
+	return uc.customFieldRepo.Get(ctx, parsedID)
+}
+
+func (uc *CustomFieldUseCaseImpl) Update(ctx context.Context, field entity.CustomField) (entity.CustomField, error) {
+	logger.Log.Info("Starting custom field update", "id", field.ID.String())
+
+	current, err := uc.customFieldRepo.Get(ctx, field.ID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get custom field for update")
+		return entity.CustomField{}, err
+	}
+	if field.Key != "" && field.Key != current.Key {
+		return entity.CustomField{}, fmt.Errorf("%w: key cannot be changed", ErrInvalidCustomField)
+	}
+	if field.Type != "" && field.Type != current.Type {
+		return entity.CustomField{}, fmt.Errorf("%w: type cannot be changed", ErrInvalidCustomField)
+	}
+	field.Key = current.Key
+	field.Type = current.Type
+	if err := field.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Validation failed during custom field update")
+		return entity.CustomField{}, fmt.Errorf("%w: %w", ErrInvalidCustomField, err)
+	}
+	field.UpdatedAt = time.Now()
+
+	updatedField, err := uc.customFieldRepo.Update(ctx, field)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to update custom field in repository")
+		return entity.CustomField{}, err
+	}
+
+	logger.Log.Info("Custom field updated successfully", "id", updatedField.ID.String())
+	return updatedField, nil
+}
+
+func (uc *CustomFieldUseCaseImpl) Delete(ctx context.Context, id string) error {
+	logger.Log.Info("Deleting custom field", "id", id)
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrCustomFieldNotFound
+	}
+	if err := uc.customFieldRepo.Delete(ctx, parsedID); err != nil {
+		logger.Log.WithError(err).Error("Failed to delete custom field from repository")
+		return err
+	}
+
+	// Значения поля удалены у задач любых проектов
+	if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after custom field deletion")
+	}
+
+	logger.Log.Info("Custom field deleted successfully", "id", id)
+	return nil
+}
+
+func (uc *CustomFieldUseCaseImpl) BuildIndexes(ctx context.Context, limit int) (int, error) {
+	processed := 0
+	for processed < limit {
+		now := time.Now().UTC()
+		claimed, err := uc.customFieldRepo.ClaimPendingIndexes(ctx, now, now.Add(customFieldIndexLease), 1)
+		if err != nil {
+			logger.Log.WithError(err).Error("Failed to claim pending custom field indexes")
+			return processed, err
+		}
+		if len(claimed) == 0 {
+			break
+		}
+
+		field := claimed[0]
+		logger.Log.Info("Building custom field index", "custom_field_id", field.ID, "key", field.Key)
+		if err := uc.customFieldRepo.BuildIndex(ctx, field); err != nil {
+			// Поле помечено failed, его можно удалить и создать заново. Прерванное остановкой
+			// сервиса построение снова ожидает очереди
+			logger.Log.WithError(err).Error("Failed to build custom field index", "custom_field_id", field.ID)
+		} else {
+			logger.Log.Info("Custom field index built", "custom_field_id", field.ID)
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// checkCustomFields проверяет значения пользовательских полей задачи по текущим схемам
+// и приводит их к хранимому виду.
+func (uc *TaskUseCaseImpl) checkCustomFields(ctx context.Context, task *entity.Task) error {
+	fields, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	return normalizeCustomFields(fields, task)
+}
+
+func normalizeCustomFields(fields []entity.CustomField, task *entity.Task) error {
+	values, err := entity.NormalizeCustomFields(fields, task.CustomFields)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+	task.CustomFields = values
+	return nil
+}
+
+// resolveCustomFieldParams проверяет, что сортировка и фильтры списка ссылаются на
+// существующие пользовательские поля, и приводит значения фильтров к типам полей.
+func (uc *TaskUseCaseImpl) resolveCustomFieldParams(ctx context.Context, params *entity.TaskListParams) error {
+	sortKey, sortByField := params.Sort.CustomFieldKey()
+	if !sortByField && len(params.Filter.CustomFields) == 0 {
+		return nil
+	}
+
+	fields, err := uc.customFieldRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	schema := make(map[string]entity.CustomField, len(fields))
+	for _, field := range fields {
+		schema[field.Key] = field
+	}
+
+	if _, ok := schema[sortKey]; sortByField && !ok {
+		return ErrInvalidSort
+	}
+	filters := make([]entity.CustomFieldFilter, len(params.Filter.CustomFields))
+	for i, filter := range params.Filter.CustomFields {
+		field, ok := schema[filter.Key]
+		if !ok {
+			return fmt.Errorf("%w: unknown custom field %q", ErrInvalidFilter, filter.Key)
+		}
+		if !entity.ValidCustomFieldOp(filter.Op) {
+			return fmt.Errorf("%w: unsupported operation %q", ErrInvalidFilter, filter.Op)
+		}
+		filter.Typed, err = field.ParseValue(filter.Value)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+		}
+		filters[i] = filter
+	}
+	params.Filter.CustomFields = filters
+	return nil
+}
+
+type CustomFieldRepository interface {
+	// Create и Update возвращают ErrCustomFieldExists, если ключ поля уже занят.
+	Create(ctx context.Context, field entity.CustomField) (entity.CustomField, error)
+	List(ctx context.Context) ([]entity.CustomField, error)
+	Get(ctx context.Context, id uuid.UUID) (entity.CustomField, error)
+	Update(ctx context.Context, field entity.CustomField) (entity.CustomField, error)
+	// Delete удаляет схему поля и его значения у задач.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ClaimPendingIndexes берет в аренду до leaseUntil не более limit полей, индексы
+	// которых ожидают построения.
+	ClaimPendingIndexes(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.CustomField, error)
+	// BuildIndex строит индекс значений поля и записывает статус ready или failed.
+	BuildIndex(ctx context.Context, field entity.CustomField) error
+}
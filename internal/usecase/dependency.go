@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDependencyNotFound = entity.NewError(entity.KindNotFound, "dependency_not_found", "dependency not found")
+	ErrDependencyCycle    = entity.NewError(entity.KindFailedPrecondition, "dependency_cycle", "dependency would create a cycle")
+	// ErrTaskBlocked возвращается, если задачу переводят в статус из Workflow.RequiresUnblocked,
+	// пока блокирующие её задачи не выполнены.
+	ErrTaskBlocked = entity.NewError(entity.KindConflict, "task_blocked", "task is blocked by unfinished tasks")
+)
+
+// BlockedError перечисляет невыполненные задачи, блокирующие переход.
+type BlockedError struct {
+	Status   string      `json:"status"`
+	Blockers []uuid.UUID `json:"blockers"`
+}
+
+func (e *BlockedError) Error() string {
+	ids := make([]string, len(e.Blockers))
+	for i, id := range e.Blockers {
+		ids[i] = id.String()
+	}
+	return fmt.Sprintf("%s: %q requires %s to be completed", ErrTaskBlocked, e.Status, strings.Join(ids, ", "))
+}
+
+func (e *BlockedError) Unwrap() error {
+	return ErrTaskBlocked
+}
+
+// UpdateOptions изменяет проверки, выполняемые при обновлении задачи.
+type UpdateOptions struct {
+	// IgnoreBlockers разрешает перевести задачу в статус из Workflow.RequiresUnblocked
+	// при невыполненных блокирующих задачах.
+	IgnoreBlockers bool
+}
+
+type UpdateOption func(*UpdateOptions)
+
+// IgnoreBlockers отключает проверку блокирующих задач.
+func IgnoreBlockers() UpdateOption {
+	return func(o *UpdateOptions) {
+		o.IgnoreBlockers = true
+	}
+}
+
+func newUpdateOptions(opts []UpdateOption) UpdateOptions {
+	var o UpdateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (uc *TaskUseCaseImpl) AddDependency(ctx context.Context, blockedID string, blockerID uuid.UUID) (entity.TaskDependency, error) {
+	logger.Log.Info("Adding task dependency", "blocked_id", blockedID, "blocker_id", blockerID)
+
+	blocked, err := uuid.Parse(blockedID)
+	if err != nil {
+		return entity.TaskDependency{}, ErrTaskNotFound
+	}
+	if blocked == blockerID {
+		return entity.TaskDependency{}, fmt.Errorf("%w: task cannot block itself", ErrDependencyCycle)
+	}
+	if err := uc.authorizeDependency(ctx, blockedID, &blockerID); err != nil {
+		logger.Log.WithError(err).Warn("Task dependency rejected")
+		return entity.TaskDependency{}, err
+	}
+
+	dep, err := uc.taskRepo.AddDependency(ctx, entity.TaskDependency{
+		BlockerID: blockerID,
+		BlockedID: blocked,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to add task dependency")
+		return entity.TaskDependency{}, err
+	}
+
+	logger.Log.Info("Task dependency added", "blocked_id", blockedID, "blocker_id", blockerID)
+	return dep, nil
+}
+
+func (uc *TaskUseCaseImpl) RemoveDependency(ctx context.Context, blockedID string, blockerID uuid.UUID) error {
+	logger.Log.Info("Removing task dependency", "blocked_id", blockedID, "blocker_id", blockerID)
+
+	blocked, err := uuid.Parse(blockedID)
+	if err != nil {
+		return ErrDependencyNotFound
+	}
+	if err := uc.authorizeDependency(ctx, blockedID, nil); err != nil {
+		logger.Log.WithError(err).Warn("Task dependency removal rejected")
+		return err
+	}
+
+	if err := uc.taskRepo.RemoveDependency(ctx, blockerID, blocked); err != nil {
+		logger.Log.WithError(err).Error("Failed to remove task dependency")
+		return err
+	}
+
+	logger.Log.Info("Task dependency removed", "blocked_id", blockedID, "blocker_id", blockerID)
+	return nil
+}
+
+func (uc *TaskUseCaseImpl) DependencyGraph(ctx context.Context, id string, depth int) (entity.TaskGraph, error) {
+	logger.Log.Info("Getting task dependency graph", "id", id)
+
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.TaskGraph{}, ErrTaskNotFound
+	}
+	if depth < 1 || depth > entity.MaxGraphDepth {
+		depth = entity.MaxGraphDepth
+	}
+
+	graph, err := uc.taskRepo.DependencyGraph(ctx, taskID, depth)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get dependency graph from repository")
+		return entity.TaskGraph{}, err
+	}
+	if graph, err = uc.visibleGraph(ctx, taskID, graph); err != nil {
+		return entity.TaskGraph{}, err
+	}
+	if err := uc.loadLabels(ctx, taskPointers(graph.Nodes)...); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+		return entity.TaskGraph{}, err
+	}
+	return graph, nil
+}
+
+// authorizeDependency проверяет, что автор запроса может изменять блокируемую задачу
+// и видит блокирующую задачу blockerID, если она указана.
+func (uc *TaskUseCaseImpl) authorizeDependency(ctx context.Context, blockedID string, blockerID *uuid.UUID) error {
+	if err := uc.authorizeEdit(ctx, blockedID); err != nil {
+		return err
+	}
+	if _, ok := PrincipalFromContext(ctx); !ok || blockerID == nil {
+		return nil
+	}
+	blocker, err := uc.taskRepo.Get(ctx, blockerID.String())
+	if err != nil {
+		return err
+	}
+	return uc.authz.AuthorizeTask(ctx, entity.ActionView, blocker)
+}
+
+// visibleGraph проверяет доступ к корню графа и убирает из графа задачи, которые
+// автор запроса не может просматривать, вместе с их связями.
+func (uc *TaskUseCaseImpl) visibleGraph(ctx context.Context, rootID uuid.UUID, graph entity.TaskGraph) (entity.TaskGraph, error) {
+	for _, node := range graph.Nodes {
+		if node.ID == rootID {
+			if err := uc.authz.AuthorizeTask(ctx, entity.ActionView, node); err != nil {
+				return entity.TaskGraph{}, err
+			}
+		}
+	}
+
+	nodes, err := uc.authz.FilterVisible(ctx, graph.Nodes)
+	if err != nil {
+		return entity.TaskGraph{}, err
+	}
+	if len(nodes) == len(graph.Nodes) {
+		return graph, nil
+	}
+	visible := make(map[uuid.UUID]bool, len(nodes))
+	for _, node := range nodes {
+		visible[node.ID] = true
+	}
+	edges := make([]entity.TaskDependency, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if visible[edge.BlockerID] && visible[edge.BlockedID] {
+			edges = append(edges, edge)
+		}
+	}
+	return entity.TaskGraph{Nodes: nodes, Edges: edges}, nil
+}
+
+// checkBlockers запрещает переводить задачу в статус из Workflow.RequiresUnblocked,
+// пока блокирующие её задачи не выполнены.
+func (uc *TaskUseCaseImpl) checkBlockers(ctx context.Context, current, updated entity.Task, opts UpdateOptions) error {
+	if opts.IgnoreBlockers || current.Status == updated.Status || !uc.workflow.IsBlockable(updated.Status) {
+		return nil
+	}
+	blockers, err := uc.taskRepo.OpenBlockers(ctx, updated.ID, uc.workflow.Completed)
+	if err != nil {
+		return err
+	}
+	if len(blockers) > 0 {
+		return &BlockedError{Status: updated.Status, Blockers: blockers}
+	}
+	return nil
+}
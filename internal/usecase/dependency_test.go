@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAddDependencyRejectsInvalidEdge(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name      string
+		blockedID string
+		blockerID uuid.UUID
+		wantErr   error
+	}{
+		{name: "task blocks itself", blockedID: id.String(), blockerID: id, wantErr: ErrDependencyCycle},
+		{name: "malformed task ID", blockedID: "not-a-uuid", blockerID: id, wantErr: ErrTaskNotFound},
+	}
+
+	// Проверки выполняются до обращения к хранилищу, поэтому зависимости не нужны
+	uc := &TaskUseCaseImpl{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.AddDependency(context.Background(), tt.blockedID, tt.blockerID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddDependency error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
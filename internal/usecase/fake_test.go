@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+// Хранилища для тестов сценариев держат данные в памяти. Методы, которые тест не
+// реализует, достаются от встроенного nil-интерфейса и приводят к панике.
+
+type fakeTaskRepo struct {
+	TaskRepository
+	tasks map[uuid.UUID]entity.Task
+}
+
+func newFakeTaskRepo(tasks ...entity.Task) *fakeTaskRepo {
+	r := &fakeTaskRepo{tasks: make(map[uuid.UUID]entity.Task)}
+	for _, task := range tasks {
+		r.tasks[task.ID] = task
+	}
+	return r
+}
+
+func (r *fakeTaskRepo) Get(ctx context.Context, id string) (entity.Task, error) {
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.Task{}, ErrTaskNotFound
+	}
+	task, ok := r.tasks[taskID]
+	if !ok || task.DeletedAt != nil {
+		return entity.Task{}, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (r *fakeTaskRepo) UpdateFunc(ctx context.Context, id string, version int64, fn func(task *entity.Task) error) (entity.Task, error) {
+	task, err := r.Get(ctx, id)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	if version != 0 && version != task.Version {
+		return entity.Task{}, ErrVersionConflict
+	}
+	if err := fn(&task); err != nil {
+		return entity.Task{}, err
+	}
+	task.Version++
+	r.tasks[task.ID] = task
+	return task, nil
+}
+
+func (r *fakeTaskRepo) Children(ctx context.Context, id uuid.UUID) ([]entity.Task, error) {
+	var children []entity.Task
+	for _, task := range r.tasks {
+		if task.ParentID != nil && *task.ParentID == id && task.DeletedAt == nil {
+			children = append(children, task)
+		}
+	}
+	return children, nil
+}
+
+// CreateBatch, как и неатомарная вставка в Postgres, пропускает задачи с уже существующим ID.
+func (r *fakeTaskRepo) CreateBatch(ctx context.Context, tasks []entity.Task, atomic bool) ([]entity.BatchItemResult, error) {
+	results := make([]entity.BatchItemResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = entity.BatchItemResult{Index: i, ID: task.ID}
+		if _, ok := r.tasks[task.ID]; ok {
+			results[i].Fail(ErrTaskExists)
+			continue
+		}
+		task.Version = 1
+		r.tasks[task.ID] = task
+		results[i].Task = &task
+	}
+	return results, nil
+}
+
+// fakeMemberRepo хранит роли по проекту и пользователю.
+type fakeMemberRepo struct {
+	ProjectMemberRepository
+	roles map[uuid.UUID]map[string]entity.ProjectRole
+}
+
+func (r fakeMemberRepo) Role(ctx context.Context, projectID uuid.UUID, subject string) (entity.ProjectRole, error) {
+	return r.roles[projectID][subject], nil
+}
+
+type fakeCommentRepo struct {
+	CommentRepository
+	comments map[uuid.UUID]entity.Comment
+}
+
+func (r *fakeCommentRepo) Get(ctx context.Context, taskID, id uuid.UUID) (entity.Comment, error) {
+	comment, ok := r.comments[id]
+	if !ok || comment.TaskID != taskID {
+		return entity.Comment{}, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func (r *fakeCommentRepo) Update(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	current, err := r.Get(ctx, comment.TaskID, comment.ID)
+	if err != nil {
+		return entity.Comment{}, err
+	}
+	current.Body, current.EditedAt = comment.Body, comment.EditedAt
+	r.comments[comment.ID] = current
+	return current, nil
+}
+
+func (r *fakeCommentRepo) Delete(ctx context.Context, taskID, id uuid.UUID) error {
+	if _, err := r.Get(ctx, taskID, id); err != nil {
+		return err
+	}
+	delete(r.comments, id)
+	return nil
+}
+
+type fakeCustomFieldRepo struct {
+	CustomFieldRepository
+}
+
+func (fakeCustomFieldRepo) List(ctx context.Context) ([]entity.CustomField, error) {
+	return nil, nil
+}
+
+type fakeLabelRepo struct {
+	LabelRepository
+}
+
+func (fakeLabelRepo) ByTasks(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]entity.Label, error) {
+	return map[uuid.UUID][]entity.Label{}, nil
+}
+
+type fakeCache struct{}
+
+func (fakeCache) SetTaskPage(ctx context.Context, key string, page entity.TaskPage, ttl time.Duration) error {
+	return nil
+}
+
+func (fakeCache) GetTaskPage(ctx context.Context, key string) (*entity.TaskPage, error) {
+	return nil, nil
+}
+
+func (fakeCache) Invalidate(ctx context.Context) error {
+	return nil
+}
+
+func (fakeCache) InvalidateScopes(ctx context.Context, scopes ...string) error {
+	return nil
+}
+
+// newTestTaskUseCase собирает сценарии задач поверх fakeTaskRepo с процессом по умолчанию.
+func newTestTaskUseCase(repo *fakeTaskRepo) *TaskUseCaseImpl {
+	return NewTaskUseCase(repo, nil, fakeLabelRepo{}, fakeCustomFieldRepo{}, fakeCache{},
+		NewAuthorizationService(nil, repo), entity.DefaultWorkflow())
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRevisionNotFound = entity.NewError(entity.KindNotFound, "revision_not_found", "task revision not found")
+)
+
+// Audit описывает, кто и в рамках какого запроса меняет задачи. Репозиторий
+// сохраняет его в истории задачи вместе с изменением.
+type Audit struct {
+	Actor     string
+	RequestID string
+	// RevertedTo — ревизия, к которой возвращается задача; 0, если изменение не является возвратом.
+	RevertedTo int64
+}
+
+type auditKey struct{}
+
+// WithAudit возвращает контекст, изменения задач в котором записываются в историю от имени audit.
+func WithAudit(ctx context.Context, audit Audit) context.Context {
+	return context.WithValue(ctx, auditKey{}, audit)
+}
+
+// AuditFromContext возвращает Audit, сохраненный WithAudit, или пустой Audit.
+func AuditFromContext(ctx context.Context) Audit {
+	audit, _ := ctx.Value(auditKey{}).(Audit)
+	return audit
+}
+
+// HistoryUseCase читает историю изменений задач и возвращает задачи к прежним ревизиям.
+// Саму историю пишет репозиторий задач в транзакции каждого изменения.
+type HistoryUseCase interface {
+	List(ctx context.Context, taskID string, params entity.TaskHistoryParams) (entity.TaskHistoryPage, error)
+	// Revert возвращает изменяемые поля задачи к состоянию после ревизии revision.
+	// Возврат проходит те же проверки, что и Update, и сам записывается в историю.
+	// Родитель задачи не возвращается: он меняется только перемещением.
+	Revert(ctx context.Context, taskID string, revision, version int64) (entity.Task, error)
+}
+
+type HistoryUseCaseImpl struct {
+	historyRepo HistoryRepository
+	authz       *AuthorizationService
+	taskUseCase TaskUseCase
+}
+
+func NewHistoryUseCase(historyRepo HistoryRepository, authz *AuthorizationService, taskUseCase TaskUseCase) *HistoryUseCaseImpl {
+	return &HistoryUseCaseImpl{
+		historyRepo: historyRepo,
+		authz:       authz,
+		taskUseCase: taskUseCase,
+	}
+}
+
+func (uc *HistoryUseCaseImpl) List(ctx context.Context, taskID string, params entity.TaskHistoryParams) (entity.TaskHistoryPage, error) {
+	logger.Log.Info("Listing task history", "task_id", taskID)
+
+	task, err := uc.authz.LoadTask(ctx, entity.ActionView, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for history")
+		return entity.TaskHistoryPage{}, err
+	}
+
+	limit := params.Limit
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	params.Limit = limit + 1
+	entries, err := uc.historyRepo.List(ctx, task.ID, params)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list task history from repository")
+		return entity.TaskHistoryPage{}, err
+	}
+
+	page := entity.TaskHistoryPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.HasMore = true
+		page.NextBefore = page.Entries[limit-1].Revision
+	}
+	if page.Entries == nil {
+		page.Entries = []entity.TaskHistoryEntry{}
+	}
+
+	logger.Log.Info("Task history listed successfully", "count", len(page.Entries))
+	return page, nil
+}
+
+func (uc *HistoryUseCaseImpl) Revert(ctx context.Context, taskID string, revision, version int64) (entity.Task, error) {
+	logger.Log.Info("Reverting task", "task_id", taskID, "revision", revision)
+
+	task, err := uc.authz.LoadTask(ctx, entity.ActionEdit, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for revert")
+		return entity.Task{}, err
+	}
+
+	target, err := uc.historyRepo.Snapshot(ctx, task.ID, revision)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task revision")
+		return entity.Task{}, err
+	}
+	target.Version = version
+
+	audit := AuditFromContext(ctx)
+	audit.RevertedTo = revision
+	revertedTask, err := uc.taskUseCase.Update(WithAudit(ctx, audit), target)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to revert task")
+		return entity.Task{}, err
+	}
+
+	logger.Log.Info("Task reverted successfully", "task_id", taskID, "revision", revision)
+	return revertedTask, nil
+}
+
+type HistoryRepository interface {
+	// List возвращает записи истории задачи от новых к старым, начиная с ревизии меньше params.Before.
+	List(ctx context.Context, taskID uuid.UUID, params entity.TaskHistoryParams) ([]entity.TaskHistoryEntry, error)
+	// Snapshot возвращает задачу, изменяемые поля которой взяты из ревизии revision,
+	// а остальные — из текущего состояния задачи.
+	Snapshot(ctx context.Context, taskID uuid.UUID, revision int64) (entity.Task, error)
+}
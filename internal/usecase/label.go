@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLabelNotFound    = entity.NewError(entity.KindNotFound, "label_not_found", "label not found")
+	ErrLabelExists      = entity.NewError(entity.KindAlreadyExists, "label_exists", "label with this name already exists")
+	ErrLabelNotAttached = entity.NewError(entity.KindNotFound, "label_not_attached", "label is not attached to the task")
+	ErrInvalidLabel     = entity.NewError(entity.KindValidation, "invalid_label", "invalid label")
+)
+
+type LabelUseCase interface {
+	Create(ctx context.Context, label entity.Label) (entity.Label, error)
+	List(ctx context.Context) ([]entity.Label, error)
+	Update(ctx context.Context, label entity.Label) (entity.Label, error)
+	// Delete удаляет метку и снимает её со всех задач.
+	Delete(ctx context.Context, id string) error
+}
+
+type LabelUseCaseImpl struct {
+	labelRepo LabelRepository
+	cacheRepo CacheRepository
+}
+
+func NewLabelUseCase(labelRepo LabelRepository, cacheRepo CacheRepository) *LabelUseCaseImpl {
+	return &LabelUseCaseImpl{
+		labelRepo: labelRepo,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (uc *LabelUseCaseImpl) Create(ctx context.Context, label entity.Label) (entity.Label, error) {
+	logger.Log.Info("Starting label creation", "name", label.Name)
+
+	if label.Color == "" {
+		label.Color = entity.DefaultLabelColor
+	}
+	if err := label.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Label validation failed")
+		return entity.Label{}, fmt.Errorf("%w: %w", ErrInvalidLabel, err)
+	}
+
+	label.ID = uuid.New()
+	label.CreatedAt = time.Now()
+
+	createdLabel, err := uc.labelRepo.Create(ctx, label)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to create label")
+		return entity.Label{}, err
+	}
+
+	logger.Log.Info("Label created successfully", "label_id", createdLabel.ID)
+	return createdLabel, nil
+}
+
+func (uc *LabelUseCaseImpl) List(ctx context.Context) ([]entity.Label, error) {
+	logger.Log.Info("Listing labels")
+	labels, err := uc.labelRepo.List(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list labels from repository")
+		return nil, err
+	}
+	if labels == nil {
+		labels = []entity.Label{}
+	}
+	return labels, nil
+}
+
+func (uc *LabelUseCaseImpl) Update(ctx context.Context, label entity.Label) (entity.Label, error) {
+	logger.Log.Info("Starting label update", "id", label.ID.String())
+
+	if label.Color == "" {
+		label.Color = entity.DefaultLabelColor
+	}
+	if err := label.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Validation failed during label update")
+		return entity.Label{}, fmt.Errorf("%w: %w", ErrInvalidLabel, err)
+	}
+
+	updatedLabel, err := uc.labelRepo.Update(ctx, label)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to update label in repository")
+		return entity.Label{}, err
+	}
+
+	// Метка может входить в закэшированные страницы любых проектов
+	if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after label update")
+	}
+
+	logger.Log.Info("Label updated successfully", "id", updatedLabel.ID.String())
+	return updatedLabel, nil
+}
+
+func (uc *LabelUseCaseImpl) Delete(ctx context.Context, id string) error {
+	logger.Log.Info("Deleting label", "id", id)
+
+	if err := uc.labelRepo.Delete(ctx, id); err != nil {
+		logger.Log.WithError(err).Error("Failed to delete label from repository")
+		return err
+	}
+
+	if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after label deletion")
+	}
+
+	logger.Log.Info("Label deleted successfully", "id", id)
+	return nil
+}
+
+func (uc *TaskUseCaseImpl) AttachLabel(ctx context.Context, id string, labelID uuid.UUID) (entity.Task, error) {
+	logger.Log.Info("Attaching label to task", "id", id, "label_id", labelID)
+
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.Task{}, ErrTaskNotFound
+	}
+	if err := uc.authorizeEdit(ctx, id); err != nil {
+		return entity.Task{}, err
+	}
+	if err := uc.labelRepo.Attach(ctx, taskID, labelID); err != nil {
+		logger.Log.WithError(err).Error("Failed to attach label")
+		return entity.Task{}, err
+	}
+
+	return uc.afterLabelChange(ctx, id)
+}
+
+func (uc *TaskUseCaseImpl) DetachLabel(ctx context.Context, id string, labelID uuid.UUID) (entity.Task, error) {
+	logger.Log.Info("Detaching label from task", "id", id, "label_id", labelID)
+
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.Task{}, ErrTaskNotFound
+	}
+	if err := uc.authorizeEdit(ctx, id); err != nil {
+		return entity.Task{}, err
+	}
+	if err := uc.labelRepo.Detach(ctx, taskID, labelID); err != nil {
+		logger.Log.WithError(err).Error("Failed to detach label")
+		return entity.Task{}, err
+	}
+
+	return uc.afterLabelChange(ctx, id)
+}
+
+// authorizeEdit проверяет, что автор запроса может изменять задачу, изменения которой
+// не проходят через UpdateFunc.
+func (uc *TaskUseCaseImpl) authorizeEdit(ctx context.Context, id string) error {
+	if _, ok := PrincipalFromContext(ctx); !ok {
+		return nil
+	}
+	task, err := uc.taskRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return uc.authz.AuthorizeTask(ctx, entity.ActionEdit, task)
+}
+
+// afterLabelChange сбрасывает кэш списков проекта задачи и возвращает задачу с актуальными метками.
+func (uc *TaskUseCaseImpl) afterLabelChange(ctx context.Context, id string) (entity.Task, error) {
+	task, err := uc.Get(ctx, id)
+	if err != nil {
+		return entity.Task{}, err
+	}
+	if err := uc.invalidateProjects(ctx, task.ProjectID); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after label change")
+	}
+	return task, nil
+}
+
+// loadLabels заполняет метки перечисленных задач одним запросом к хранилищу.
+func (uc *TaskUseCaseImpl) loadLabels(ctx context.Context, tasks ...*entity.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	labels, err := uc.labelRepo.ByTasks(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.Labels = labels[task.ID]
+	}
+	return nil
+}
+
+// taskPointers возвращает указатели на элементы среза для loadLabels.
+func taskPointers(tasks []entity.Task) []*entity.Task {
+	pointers := make([]*entity.Task, len(tasks))
+	for i := range tasks {
+		pointers[i] = &tasks[i]
+	}
+	return pointers
+}
+
+type LabelRepository interface {
+	// Create и Update возвращают ErrLabelExists, если имя метки уже занято.
+	Create(ctx context.Context, label entity.Label) (entity.Label, error)
+	List(ctx context.Context) ([]entity.Label, error)
+	Update(ctx context.Context, label entity.Label) (entity.Label, error)
+	Delete(ctx context.Context, id string) error
+	// Attach назначает метку задаче; повторное назначение не считается ошибкой.
+	Attach(ctx context.Context, taskID, labelID uuid.UUID) error
+	// Detach возвращает ErrLabelNotAttached, если метка не назначена задаче.
+	Detach(ctx context.Context, taskID, labelID uuid.UUID) error
+	// ByTasks возвращает метки задач, сгруппированные по ID задачи.
+	ByTasks(ctx context.Context, taskIDs []uuid.UUID) (map[uuid.UUID][]entity.Label, error)
+}
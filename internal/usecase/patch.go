@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// TaskPatch изменяет загруженную из хранилища задачу. Идентификатор, время
+// создания, автор, ранг и отметка об удалении задачи патчем не меняются.
+type TaskPatch func(task *entity.Task) error
+
+// NewMergePatch создает патч в формате JSON Merge Patch (RFC 7396).
+func NewMergePatch(doc []byte) (TaskPatch, error) {
+	if !json.Valid(doc) {
+		return nil, fmt.Errorf("%w: malformed merge patch", ErrInvalidPatch)
+	}
+	return func(task *entity.Task) error {
+		return applyJSON(task, func(original []byte) ([]byte, error) {
+			return jsonpatch.MergePatch(original, doc)
+		})
+	}, nil
+}
+
+// NewJSONPatch создает патч в формате JSON Patch (RFC 6902).
+func NewJSONPatch(doc []byte) (TaskPatch, error) {
+	patch, err := jsonpatch.DecodePatch(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+	return func(task *entity.Task) error {
+		return applyJSON(task, patch.Apply)
+	}, nil
+}
+
+// NewFieldMaskPatch создает патч, копирующий из src только перечисленные поля.
+func NewFieldMaskPatch(paths []string, src entity.Task) (TaskPatch, error) {
+	for _, path := range paths {
+		switch path {
+		case "title", "description", "status", "project_id", "start_at", "due_at", "priority", "custom_fields", "assignee_id":
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, path)
+		}
+	}
+	return func(task *entity.Task) error {
+		for _, path := range paths {
+			switch path {
+			case "title":
+				task.Title = src.Title
+			case "description":
+				task.Description = src.Description
+			case "status":
+				task.Status = src.Status
+			case "project_id":
+				task.ProjectID = src.ProjectID
+			case "start_at":
+				task.StartAt = src.StartAt
+			case "due_at":
+				task.DueAt = src.DueAt
+			case "priority":
+				task.Priority = src.Priority
+			case "custom_fields":
+				task.CustomFields = src.CustomFields
+			case "assignee_id":
+				task.AssigneeID = src.AssigneeID
+			}
+		}
+		return nil
+	}, nil
+}
+
+// applyJSON применяет преобразование к JSON-представлению задачи.
+func applyJSON(task *entity.Task, apply func(original []byte) ([]byte, error)) error {
+	original, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	patched, err := apply(original)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	var result entity.Task
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+	result.ID = task.ID
+	result.CreatedAt = task.CreatedAt
+	result.UpdatedAt = task.UpdatedAt
+	result.Rank = task.Rank
+	result.CreatedBy = task.CreatedBy
+	result.DeletedAt = task.DeletedAt
+	*task = result
+	return nil
+}
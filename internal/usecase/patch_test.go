@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestTaskPatchProtectedFields(t *testing.T) {
+	newMerge := func(doc string) func() (TaskPatch, error) {
+		return func() (TaskPatch, error) { return NewMergePatch([]byte(doc)) }
+	}
+	newJSON := func(doc string) func() (TaskPatch, error) {
+		return func() (TaskPatch, error) { return NewJSONPatch([]byte(doc)) }
+	}
+
+	tests := []struct {
+		name      string
+		patch     func() (TaskPatch, error)
+		wantTitle string
+	}{
+		{
+			name:      "merge patch replaces protected fields",
+			patch:     newMerge(`{"title": "Renamed", "id": "` + uuid.NewString() + `", "created_at": "2000-01-01T00:00:00Z", "rank": "0001", "created_by": "intruder", "deleted_at": "2000-01-01T00:00:00Z"}`),
+			wantTitle: "Renamed",
+		},
+		{
+			name:      "merge patch removes protected fields",
+			patch:     newMerge(`{"id": null, "created_at": null, "rank": null, "created_by": null}`),
+			wantTitle: "Report",
+		},
+		{
+			name: "JSON patch replaces protected fields",
+			patch: newJSON(`[
+				{"op": "replace", "path": "/title", "value": "Renamed"},
+				{"op": "replace", "path": "/id", "value": "` + uuid.NewString() + `"},
+				{"op": "replace", "path": "/created_at", "value": "2000-01-01T00:00:00Z"},
+				{"op": "replace", "path": "/rank", "value": "0001"},
+				{"op": "replace", "path": "/created_by", "value": "intruder"},
+				{"op": "add", "path": "/deleted_at", "value": "2000-01-01T00:00:00Z"}
+			]`),
+			wantTitle: "Renamed",
+		},
+		{
+			name: "JSON patch removes protected fields",
+			patch: newJSON(`[
+				{"op": "remove", "path": "/id"},
+				{"op": "remove", "path": "/created_at"},
+				{"op": "remove", "path": "/rank"},
+				{"op": "remove", "path": "/created_by"}
+			]`),
+			wantTitle: "Report",
+		},
+		{
+			name: "JSON patch copies into protected fields",
+			patch: newJSON(`[
+				{"op": "copy", "from": "/title", "path": "/created_by"},
+				{"op": "copy", "from": "/title", "path": "/rank"},
+				{"op": "move", "from": "/updated_at", "path": "/created_at"}
+			]`),
+			wantTitle: "Report",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := entity.Task{
+				ID:        uuid.New(),
+				Title:     "Report",
+				Status:    "todo",
+				CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				UpdatedAt: time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC),
+				Rank:      "V",
+				CreatedBy: "author",
+			}
+			patch, err := tt.patch()
+			if err != nil {
+				t.Fatalf("create patch: %v", err)
+			}
+
+			task := original
+			if err := patch(&task); err != nil {
+				t.Fatalf("apply patch: %v", err)
+			}
+			if task.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", task.Title, tt.wantTitle)
+			}
+			if task.ID != original.ID {
+				t.Errorf("ID = %s, want %s", task.ID, original.ID)
+			}
+			if !task.CreatedAt.Equal(original.CreatedAt) {
+				t.Errorf("CreatedAt = %s, want %s", task.CreatedAt, original.CreatedAt)
+			}
+			if !task.UpdatedAt.Equal(original.UpdatedAt) {
+				t.Errorf("UpdatedAt = %s, want %s", task.UpdatedAt, original.UpdatedAt)
+			}
+			if task.Rank != original.Rank {
+				t.Errorf("Rank = %q, want %q", task.Rank, original.Rank)
+			}
+			if task.CreatedBy != original.CreatedBy {
+				t.Errorf("CreatedBy = %q, want %q", task.CreatedBy, original.CreatedBy)
+			}
+			if task.DeletedAt != nil {
+				t.Errorf("DeletedAt = %s, want nil", task.DeletedAt)
+			}
+		})
+	}
+}
+
+func TestTaskPatchInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch func() (TaskPatch, error)
+		// wantCreateErr — ошибка ожидается уже при создании патча, а не при применении.
+		wantCreateErr bool
+	}{
+		{
+			name:          "malformed merge patch",
+			patch:         func() (TaskPatch, error) { return NewMergePatch([]byte(`{"title":`)) },
+			wantCreateErr: true,
+		},
+		{
+			name:          "malformed JSON patch",
+			patch:         func() (TaskPatch, error) { return NewJSONPatch([]byte(`{"op": "replace"}`)) },
+			wantCreateErr: true,
+		},
+		{
+			name: "failed test operation",
+			patch: func() (TaskPatch, error) {
+				return NewJSONPatch([]byte(`[{"op": "test", "path": "/title", "value": "Other"}]`))
+			},
+		},
+		{
+			name:  "remove missing path",
+			patch: func() (TaskPatch, error) { return NewJSONPatch([]byte(`[{"op": "remove", "path": "/missing"}]`)) },
+		},
+		{
+			name:  "wrong field type",
+			patch: func() (TaskPatch, error) { return NewMergePatch([]byte(`{"priority": "high"}`)) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := tt.patch()
+			if tt.wantCreateErr {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Fatalf("create patch error = %v, want %v", err, ErrInvalidPatch)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("create patch: %v", err)
+			}
+
+			task := entity.Task{ID: uuid.New(), Title: "Report", Status: "todo"}
+			if err := patch(&task); !errors.Is(err, ErrInvalidPatch) {
+				t.Errorf("apply patch error = %v, want %v", err, ErrInvalidPatch)
+			}
+		})
+	}
+}
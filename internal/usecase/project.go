@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProjectNotFound = entity.NewError(entity.KindNotFound, "project_not_found", "project not found")
+	ErrProjectExists   = entity.NewError(entity.KindAlreadyExists, "project_exists", "project with this name already exists")
+	// ErrProjectNotEmpty возвращается при удалении проекта, в котором есть задачи, включая задачи в корзине.
+	ErrProjectNotEmpty = entity.NewError(entity.KindConflict, "project_not_empty", "project has tasks")
+	ErrInvalidProject  = entity.NewError(entity.KindValidation, "invalid_project", "invalid project")
+
+	ErrProjectMemberNotFound = entity.NewError(entity.KindNotFound, "project_member_not_found", "project member not found")
+	ErrInvalidProjectMember  = entity.NewError(entity.KindValidation, "invalid_project_member", "invalid project member")
+	// ErrLastProjectOwner возвращается, если изменение оставило бы проект без владельца.
+	ErrLastProjectOwner = entity.NewError(entity.KindConflict, "last_project_owner", "project must keep at least one owner")
+)
+
+// ProjectUseCase управляет проектами и ролями в них. Автор запроса, создавший проект,
+// становится его владельцем; изменять и удалять проект и управлять ролями может только владелец.
+type ProjectUseCase interface {
+	Create(ctx context.Context, project entity.Project) (entity.Project, error)
+	Get(ctx context.Context, id string) (entity.Project, error)
+	// List возвращает проекты, в которых у автора запроса есть роль.
+	List(ctx context.Context) ([]entity.Project, error)
+	Update(ctx context.Context, project entity.Project) (entity.Project, error)
+	Delete(ctx context.Context, id string) error
+
+	ListMembers(ctx context.Context, projectID string) ([]entity.ProjectMember, error)
+	// SetMember назначает пользователю роль в проекте или меняет её.
+	SetMember(ctx context.Context, member entity.ProjectMember) (entity.ProjectMember, error)
+	RemoveMember(ctx context.Context, projectID string, subject string) error
+}
+
+type ProjectUseCaseImpl struct {
+	projectRepo ProjectRepository
+	memberRepo  ProjectMemberRepository
+	cacheRepo   CacheRepository
+	authz       *AuthorizationService
+}
+
+func NewProjectUseCase(projectRepo ProjectRepository, memberRepo ProjectMemberRepository, cacheRepo CacheRepository, authz *AuthorizationService) *ProjectUseCaseImpl {
+	return &ProjectUseCaseImpl{
+		projectRepo: projectRepo,
+		memberRepo:  memberRepo,
+		cacheRepo:   cacheRepo,
+		authz:       authz,
+	}
+}
+
+func (uc *ProjectUseCaseImpl) Create(ctx context.Context, project entity.Project) (entity.Project, error) {
+	logger.Log.Info("Starting project creation", "name", project.Name)
+
+	if err := project.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Project validation failed")
+		return entity.Project{}, fmt.Errorf("%w: %w", ErrInvalidProject, err)
+	}
+
+	project.ID = uuid.New()
+	project.CreatedAt = time.Now()
+	project.UpdatedAt = project.CreatedAt
+
+	var owner string
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		owner = principal.Subject
+	}
+	createdProject, err := uc.projectRepo.Create(ctx, project, owner)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to create project")
+		return entity.Project{}, err
+	}
+
+	logger.Log.Info("Project created successfully", "project_id", createdProject.ID)
+	return createdProject, nil
+}
+
+func (uc *ProjectUseCaseImpl) Get(ctx context.Context, id string) (entity.Project, error) {
+	logger.Log.Info("Getting project", "id", id)
+	project, err := uc.projectRepo.Get(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get project from repository")
+		return entity.Project{}, err
+	}
+	if err := uc.authz.AuthorizeProject(ctx, entity.ActionView, &project.ID); err != nil {
+		logger.Log.WithError(err).Warn("Project access rejected")
+		return entity.Project{}, err
+	}
+	return project, nil
+}
+
+func (uc *ProjectUseCaseImpl) List(ctx context.Context) ([]entity.Project, error) {
+	logger.Log.Info("Listing projects")
+	projects, err := uc.projectRepo.List(ctx, uc.authz.VisibleTo(ctx))
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list projects from repository")
+		return nil, err
+	}
+	if projects == nil {
+		projects = []entity.Project{}
+	}
+	return projects, nil
+}
+
+func (uc *ProjectUseCaseImpl) Update(ctx context.Context, project entity.Project) (entity.Project, error) {
+	logger.Log.Info("Starting project update", "id", project.ID.String())
+
+	if err := project.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Validation failed during project update")
+		return entity.Project{}, fmt.Errorf("%w: %w", ErrInvalidProject, err)
+	}
+	if err := uc.authz.AuthorizeProject(ctx, entity.ActionManage, &project.ID); err != nil {
+		logger.Log.WithError(err).Warn("Project update rejected")
+		return entity.Project{}, err
+	}
+
+	project.UpdatedAt = time.Now()
+	updatedProject, err := uc.projectRepo.Update(ctx, project)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to update project in repository")
+		return entity.Project{}, err
+	}
+
+	logger.Log.Info("Project updated successfully", "id", updatedProject.ID.String())
+	return updatedProject, nil
+}
+
+func (uc *ProjectUseCaseImpl) Delete(ctx context.Context, id string) error {
+	logger.Log.Info("Deleting project", "id", id)
+
+	if projectID, err := uuid.Parse(id); err == nil {
+		if err := uc.authz.AuthorizeProject(ctx, entity.ActionManage, &projectID); err != nil {
+			logger.Log.WithError(err).Warn("Project deletion rejected")
+			return err
+		}
+	}
+	if err := uc.projectRepo.Delete(ctx, id); err != nil {
+		logger.Log.WithError(err).Error("Failed to delete project from repository")
+		return err
+	}
+
+	// Пустые страницы удаленного проекта иначе продолжали бы отдаваться из кэша
+	if projectID, err := uuid.Parse(id); err == nil {
+		if err := uc.cacheRepo.InvalidateScopes(ctx, cacheScope(&projectID)); err != nil {
+			logger.Log.WithError(err).Error("Failed to invalidate cache after project deletion")
+		}
+	}
+
+	logger.Log.Info("Project deleted successfully", "id", id)
+	return nil
+}
+
+func (uc *ProjectUseCaseImpl) ListMembers(ctx context.Context, projectID string) ([]entity.ProjectMember, error) {
+	logger.Log.Info("Listing project members", "project_id", projectID)
+
+	id, err := uc.manageableProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := uc.memberRepo.List(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list project members from repository")
+		return nil, err
+	}
+	if members == nil {
+		members = []entity.ProjectMember{}
+	}
+	return members, nil
+}
+
+func (uc *ProjectUseCaseImpl) SetMember(ctx context.Context, member entity.ProjectMember) (entity.ProjectMember, error) {
+	logger.Log.Info("Setting project member role", "project_id", member.ProjectID, "role", member.Role)
+
+	if err := member.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Project member validation failed")
+		return entity.ProjectMember{}, fmt.Errorf("%w: %w", ErrInvalidProjectMember, err)
+	}
+	if _, err := uc.manageableProject(ctx, member.ProjectID.String()); err != nil {
+		return entity.ProjectMember{}, err
+	}
+
+	member.CreatedAt = time.Now()
+	member.UpdatedAt = member.CreatedAt
+	savedMember, err := uc.memberRepo.Set(ctx, member)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to set project member role")
+		return entity.ProjectMember{}, err
+	}
+
+	uc.invalidateMembership(ctx)
+	logger.Log.Info("Project member role set successfully", "project_id", member.ProjectID)
+	return savedMember, nil
+}
+
+func (uc *ProjectUseCaseImpl) RemoveMember(ctx context.Context, projectID string, subject string) error {
+	logger.Log.Info("Removing project member", "project_id", projectID)
+
+	id, err := uc.manageableProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if err := uc.memberRepo.Remove(ctx, id, subject); err != nil {
+		logger.Log.WithError(err).Error("Failed to remove project member")
+		return err
+	}
+
+	uc.invalidateMembership(ctx)
+	logger.Log.Info("Project member removed successfully", "project_id", projectID)
+	return nil
+}
+
+// manageableProject проверяет, что проект существует и автор запроса может управлять его участниками.
+func (uc *ProjectUseCaseImpl) manageableProject(ctx context.Context, projectID string) (uuid.UUID, error) {
+	project, err := uc.projectRepo.Get(ctx, projectID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get project from repository")
+		return uuid.Nil, err
+	}
+	if err := uc.authz.AuthorizeProject(ctx, entity.ActionManage, &project.ID); err != nil {
+		logger.Log.WithError(err).Warn("Project members management rejected")
+		return uuid.Nil, err
+	}
+	return project.ID, nil
+}
+
+// invalidateMembership сбрасывает закэшированные списки задач: от ролей зависит,
+// какие задачи видит пользователь.
+func (uc *ProjectUseCaseImpl) invalidateMembership(ctx context.Context) {
+	if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after membership change")
+	}
+}
+
+type ProjectRepository interface {
+	// Create и Update возвращают ErrProjectExists, если имя проекта уже занято. Непустой
+	// owner становится владельцем проекта в той же транзакции.
+	Create(ctx context.Context, project entity.Project, owner string) (entity.Project, error)
+	Get(ctx context.Context, id string) (entity.Project, error)
+	// List возвращает все проекты или, если visibleTo не пуст, проекты, в которых у него есть роль.
+	List(ctx context.Context, visibleTo string) ([]entity.Project, error)
+	Update(ctx context.Context, project entity.Project) (entity.Project, error)
+	// Delete возвращает ErrProjectNotEmpty, если в проекте остались задачи.
+	Delete(ctx context.Context, id string) error
+	// Exists проверяет, существует ли проект.
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// checkProject проверяет, что проект задачи существует. Задачи без проекта допустимы.
+func (uc *TaskUseCaseImpl) checkProject(ctx context.Context, projectID *uuid.UUID) error {
+	if projectID == nil {
+		return nil
+	}
+	exists, err := uc.projectRepo.Exists(ctx, *projectID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// cacheScope возвращает префикс ключей кэша для списков задач проекта или общих списков.
+func cacheScope(projectID *uuid.UUID) string {
+	if projectID == nil {
+		return "all"
+	}
+	return "project:" + projectID.String()
+}
+
+// invalidateProjects сбрасывает закэшированные общие списки задач и списки перечисленных проектов.
+func (uc *TaskUseCaseImpl) invalidateProjects(ctx context.Context, projectIDs ...*uuid.UUID) error {
+	scopes := []string{cacheScope(nil)}
+	for _, id := range projectIDs {
+		if id != nil {
+			scopes = append(scopes, cacheScope(id))
+		}
+	}
+	return uc.cacheRepo.InvalidateScopes(ctx, scopes...)
+}
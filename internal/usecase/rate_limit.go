@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// rateLimitRetryInterval — как долго после ошибки основного хранилища счетчиков
+// используются лимиты в памяти процесса, прежде чем снова обратиться к нему.
+const rateLimitRetryInterval = 5 * time.Second
+
+// ErrRateLimited возвращается транспортом, если запрос отклонен ограничителем частоты.
+var ErrRateLimited = entity.NewError(entity.KindRateLimited, "rate_limited", "rate limit exceeded")
+
+// RateLimitUseCase ограничивает частоту запросов одного клиента к группе маршрутов.
+type RateLimitUseCase interface {
+	// Allow учитывает запрос клиента identity методом method к группе маршрутов group.
+	// Если для запроса нет лимита, возвращает разрешение с нулевым Limit.
+	Allow(ctx context.Context, identity, group, method string) (entity.RateLimitResult, error)
+}
+
+type RateLimitUseCaseImpl struct {
+	store    RateLimitStore
+	fallback RateLimitStore
+	policy   entity.RateLimitPolicy
+	// storeRetryAt — время в наносекундах Unix, до которого основное хранилище не используется.
+	storeRetryAt atomic.Int64
+}
+
+// NewRateLimitUseCase создает ограничитель, который хранит счетчики в store, а пока store
+// недоступен — в fallback.
+func NewRateLimitUseCase(store, fallback RateLimitStore, policy entity.RateLimitPolicy) *RateLimitUseCaseImpl {
+	return &RateLimitUseCaseImpl{
+		store:    store,
+		fallback: fallback,
+		policy:   policy,
+	}
+}
+
+func (uc *RateLimitUseCaseImpl) Allow(ctx context.Context, identity, group, method string) (entity.RateLimitResult, error) {
+	limit, bucket := uc.policy.Limit(group, method)
+	if !limit.Enabled() {
+		return entity.RateLimitResult{Allowed: true}, nil
+	}
+	key := identity + ":" + bucket
+
+	if time.Now().UnixNano() >= uc.storeRetryAt.Load() {
+		result, err := uc.store.Take(ctx, key, limit)
+		if err == nil {
+			return result, nil
+		}
+		logger.Log.WithError(err).Warn("Rate limit store is unavailable, using in-process limits")
+		uc.storeRetryAt.Store(time.Now().Add(rateLimitRetryInterval).UnixNano())
+	}
+	return uc.fallback.Take(ctx, key, limit)
+}
+
+// RateLimitIdentity возвращает ключ клиента для ограничения частоты запросов: ключ API
+// или пользователя из контекста, а без аутентификации — IP-адрес клиента.
+func RateLimitIdentity(ctx context.Context, ip string) string {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return "ip:" + ip
+	}
+	if strings.HasPrefix(principal.Subject, entity.APIKeySubjectPrefix) {
+		return principal.Subject
+	}
+	return "user:" + principal.Subject
+}
+
+type RateLimitStore interface {
+	// Take расходует один запрос из лимита limit по ключу key.
+	Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error)
+}
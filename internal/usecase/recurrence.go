@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRecurrenceNotFound = entity.NewError(entity.KindNotFound, "recurrence_not_found", "recurrence not found")
+	ErrInvalidRecurrence  = entity.NewError(entity.KindValidation, "invalid_recurrence", "invalid recurrence")
+)
+
+// recurrenceLease — на сколько реплика берет серию в работу. Если реплика не успела
+// создать экземпляр, по истечении аренды серию возьмет другая.
+const recurrenceLease = time.Minute
+
+// RecurrenceUseCase управляет сериями повторяющихся задач, привязанными к задаче-шаблону.
+type RecurrenceUseCase interface {
+	// Set задает правило повторения задачи-шаблона или заменяет существующее.
+	Set(ctx context.Context, templateID string, recurrence entity.Recurrence) (entity.Recurrence, error)
+	Get(ctx context.Context, templateID string) (entity.Recurrence, error)
+	Delete(ctx context.Context, templateID string) error
+	Pause(ctx context.Context, templateID string) (entity.Recurrence, error)
+	// Resume возобновляет серию с первого экземпляра после текущего момента.
+	Resume(ctx context.Context, templateID string) (entity.Recurrence, error)
+	// Preview возвращает до count ближайших экземпляров серии.
+	Preview(ctx context.Context, templateID string, count int) (entity.RecurrencePreview, error)
+	// RunDue создает экземпляры серий, наступившие к now, и возвращает число созданных задач.
+	RunDue(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type RecurrenceUseCaseImpl struct {
+	recurrenceRepo RecurrenceRepository
+	authz          *AuthorizationService
+	taskUseCase    TaskUseCase
+	workflow       entity.Workflow
+}
+
+func NewRecurrenceUseCase(recurrenceRepo RecurrenceRepository, authz *AuthorizationService, taskUseCase TaskUseCase, workflow entity.Workflow) *RecurrenceUseCaseImpl {
+	return &RecurrenceUseCaseImpl{
+		recurrenceRepo: recurrenceRepo,
+		authz:          authz,
+		taskUseCase:    taskUseCase,
+		workflow:       workflow,
+	}
+}
+
+func (uc *RecurrenceUseCaseImpl) Set(ctx context.Context, templateID string, recurrence entity.Recurrence) (entity.Recurrence, error) {
+	logger.Log.Info("Setting task recurrence", "template_id", templateID, "rrule", recurrence.RRule)
+
+	template, err := uc.authz.LoadTask(ctx, entity.ActionEdit, templateID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get recurrence template")
+		return entity.Recurrence{}, err
+	}
+
+	now := time.Now().UTC()
+	if recurrence.Timezone == "" {
+		recurrence.Timezone = "UTC"
+	}
+	if recurrence.StartsAt.IsZero() {
+		recurrence.StartsAt = now.Truncate(time.Minute)
+		if template.StartAt != nil {
+			recurrence.StartsAt = *template.StartAt
+		}
+	}
+	if err := recurrence.Validate(); err != nil {
+		logger.Log.WithError(err).Error("Recurrence validation failed")
+		return entity.Recurrence{}, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+
+	rule, dtstart, _ := recurrence.Rule()
+	recurrence.ID = uuid.New()
+	recurrence.TemplateID = template.ID
+	recurrence.StartsAt = recurrence.StartsAt.UTC()
+	recurrence.NextRunAt = nextOccurrence(rule, dtstart, now)
+	recurrence.CreatedAt = now
+	recurrence.UpdatedAt = now
+
+	savedRecurrence, err := uc.recurrenceRepo.Upsert(ctx, recurrence)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to save recurrence")
+		return entity.Recurrence{}, err
+	}
+
+	logger.Log.Info("Task recurrence set successfully", "recurrence_id", savedRecurrence.ID)
+	return savedRecurrence, nil
+}
+
+func (uc *RecurrenceUseCaseImpl) Get(ctx context.Context, templateID string) (entity.Recurrence, error) {
+	logger.Log.Info("Getting task recurrence", "template_id", templateID)
+
+	id, err := uuid.Parse(templateID)
+	if err != nil {
+		return entity.Recurrence{}, ErrRecurrenceNotFound
+	}
+	if _, err := uc.authz.LoadTask(ctx, entity.ActionView, templateID); err != nil {
+		return entity.Recurrence{}, err
+	}
+	return uc.recurrenceRepo.GetByTemplate(ctx, id)
+}
+
+func (uc *RecurrenceUseCaseImpl) Delete(ctx context.Context, templateID string) error {
+	logger.Log.Info("Deleting task recurrence", "template_id", templateID)
+
+	id, err := uuid.Parse(templateID)
+	if err != nil {
+		return ErrRecurrenceNotFound
+	}
+	if _, err := uc.authz.LoadTask(ctx, entity.ActionEdit, templateID); err != nil {
+		return err
+	}
+	if err := uc.recurrenceRepo.DeleteByTemplate(ctx, id); err != nil {
+		logger.Log.WithError(err).Error("Failed to delete recurrence")
+		return err
+	}
+
+	logger.Log.Info("Task recurrence deleted successfully", "template_id", templateID)
+	return nil
+}
+
+func (uc *RecurrenceUseCaseImpl) Pause(ctx context.Context, templateID string) (entity.Recurrence, error) {
+	logger.Log.Info("Pausing task recurrence", "template_id", templateID)
+
+	id, err := uuid.Parse(templateID)
+	if err != nil {
+		return entity.Recurrence{}, ErrRecurrenceNotFound
+	}
+	if _, err := uc.authz.LoadTask(ctx, entity.ActionEdit, templateID); err != nil {
+		return entity.Recurrence{}, err
+	}
+	recurrence, err := uc.recurrenceRepo.Pause(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to pause recurrence")
+		return entity.Recurrence{}, err
+	}
+	return recurrence, nil
+}
+
+func (uc *RecurrenceUseCaseImpl) Resume(ctx context.Context, templateID string) (entity.Recurrence, error) {
+	logger.Log.Info("Resuming task recurrence", "template_id", templateID)
+
+	if _, err := uc.authz.LoadTask(ctx, entity.ActionEdit, templateID); err != nil {
+		return entity.Recurrence{}, err
+	}
+	recurrence, err := uc.Get(ctx, templateID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get recurrence")
+		return entity.Recurrence{}, err
+	}
+	rule, dtstart, err := recurrence.Rule()
+	if err != nil {
+		return entity.Recurrence{}, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+
+	// Экземпляры, пропущенные за время паузы, не создаются
+	next := nextOccurrence(rule, dtstart, time.Now().UTC())
+	resumed, err := uc.recurrenceRepo.Resume(ctx, recurrence.TemplateID, next)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to resume recurrence")
+		return entity.Recurrence{}, err
+	}
+	return resumed, nil
+}
+
+func (uc *RecurrenceUseCaseImpl) Preview(ctx context.Context, templateID string, count int) (entity.RecurrencePreview, error) {
+	logger.Log.Info("Previewing task recurrence", "template_id", templateID, "count", count)
+
+	if count < 1 || count > entity.MaxRecurrencePreview {
+		count = 10
+	}
+	recurrence, err := uc.Get(ctx, templateID)
+	if err != nil {
+		return entity.RecurrencePreview{}, err
+	}
+	rule, dtstart, err := recurrence.Rule()
+	if err != nil {
+		return entity.RecurrencePreview{}, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+
+	occurrences := rule.Occurrences(dtstart, time.Now(), count)
+	if occurrences == nil {
+		occurrences = []time.Time{}
+	}
+	return entity.RecurrencePreview{Occurrences: occurrences}, nil
+}
+
+func (uc *RecurrenceUseCaseImpl) RunDue(ctx context.Context, now time.Time, limit int) (int, error) {
+	now = now.UTC()
+	leaseUntil := now.Add(recurrenceLease).Truncate(time.Microsecond)
+	claimed, err := uc.recurrenceRepo.ClaimDue(ctx, now, leaseUntil, limit)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to claim due recurrences")
+		return 0, err
+	}
+
+	created := 0
+	for _, recurrence := range claimed {
+		ok, err := uc.runOccurrence(ctx, recurrence, now, leaseUntil)
+		if err != nil {
+			// Серия останется в аренде до её истечения и будет обработана повторно
+			logger.Log.WithError(err).Error("Failed to create recurring task", "recurrence_id", recurrence.ID)
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+	if created > 0 {
+		logger.Log.Info("Recurring tasks created", "count", created)
+	}
+	return created, nil
+}
+
+// runOccurrence создает последний наступивший экземпляр серии и переносит серию на следующий.
+// Более ранние экземпляры, пропущенные, пока сервис не работал, не создаются.
+func (uc *RecurrenceUseCaseImpl) runOccurrence(ctx context.Context, recurrence entity.Recurrence, now, leaseUntil time.Time) (bool, error) {
+	rule, dtstart, err := recurrence.Rule()
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidRecurrence, err)
+	}
+	last, next := rule.Due(dtstart, recurrence.NextRunAt.Add(-time.Nanosecond), now)
+	next = utcTime(next)
+
+	created := false
+	if last != nil {
+		created, err = uc.createOccurrence(ctx, recurrence, *last)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	advanced, err := uc.recurrenceRepo.Advance(ctx, recurrence, leaseUntil, utcTime(last), next)
+	if err != nil {
+		return false, err
+	}
+	if !advanced {
+		logger.Log.Warn("Recurrence changed while creating occurrence", "recurrence_id", recurrence.ID)
+	}
+	return created, nil
+}
+
+// createOccurrence создает экземпляр серии на момент at. Возвращает false, если экземпляр
+// уже создан другой репликой.
+func (uc *RecurrenceUseCaseImpl) createOccurrence(ctx context.Context, recurrence entity.Recurrence, at time.Time) (bool, error) {
+	template, err := uc.taskUseCase.Get(ctx, recurrence.TemplateID.String())
+	if err != nil {
+		return false, err
+	}
+
+	task, err := uc.taskUseCase.Create(ctx, recurrence.OccurrenceTask(template, uc.workflow.InitialStatus(), at))
+	if errors.Is(err, ErrTaskExists) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, label := range template.Labels {
+		if _, err := uc.taskUseCase.AttachLabel(ctx, task.ID.String(), label.ID); err != nil {
+			logger.Log.WithError(err).Error("Failed to copy label to recurring task", "task_id", task.ID, "label_id", label.ID)
+		}
+	}
+	return true, nil
+}
+
+// nextOccurrence возвращает первый экземпляр серии после now в UTC или nil, если серия завершена.
+func nextOccurrence(rule entity.RRule, dtstart, now time.Time) *time.Time {
+	occurrences := rule.Occurrences(dtstart, now, 1)
+	if len(occurrences) == 0 {
+		return nil
+	}
+	return utcTime(&occurrences[0])
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+type RecurrenceRepository interface {
+	// Upsert создает серию или заменяет правило существующей серии шаблона.
+	Upsert(ctx context.Context, recurrence entity.Recurrence) (entity.Recurrence, error)
+	GetByTemplate(ctx context.Context, templateID uuid.UUID) (entity.Recurrence, error)
+	DeleteByTemplate(ctx context.Context, templateID uuid.UUID) error
+	Pause(ctx context.Context, templateID uuid.UUID) (entity.Recurrence, error)
+	// Resume снимает паузу и переносит серию на nextRunAt; nil означает, что серия завершена.
+	Resume(ctx context.Context, templateID uuid.UUID, nextRunAt *time.Time) (entity.Recurrence, error)
+	// ClaimDue берет в аренду до limit наступивших серий, шаблоны которых не в корзине;
+	// одну серию получает только одна реплика.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.Recurrence, error)
+	// Advance переносит серию на nextRunAt и снимает аренду, если она еще принадлежит вызывающему.
+	Advance(ctx context.Context, claimed entity.Recurrence, leaseUntil time.Time, lastRunAt, nextRunAt *time.Time) (bool, error)
+}
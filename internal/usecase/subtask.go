@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrParentNotFound = entity.NewError(entity.KindFailedPrecondition, "parent_not_found", "parent task not found")
+	// ErrInvalidParent возвращается, если задачу нельзя поместить под выбранного родителя:
+	// родитель является самой задачей или её потомком, находится в другом проекте
+	// или иерархия стала бы глубже entity.MaxTaskDepth.
+	ErrInvalidParent = entity.NewError(entity.KindFailedPrecondition, "invalid_parent", "invalid parent task")
+	// ErrProjectChange возвращается при смене проекта у подзадачи или у задачи с подзадачами:
+	// подзадачи всегда находятся в проекте родителя.
+	ErrProjectChange = entity.NewError(entity.KindFailedPrecondition, "project_change_in_hierarchy", "project of a task in a hierarchy cannot be changed")
+)
+
+func (uc *TaskUseCaseImpl) Children(ctx context.Context, id string) ([]entity.Task, error) {
+	logger.Log.Info("Listing child tasks", "id", id)
+
+	parent, err := uc.taskRepo.Get(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task from repository")
+		return nil, err
+	}
+	// Подзадачи всегда находятся в проекте родителя
+	if err := uc.authz.AuthorizeTask(ctx, entity.ActionView, parent); err != nil {
+		return nil, err
+	}
+
+	children, err := uc.taskRepo.Children(ctx, parent.ID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to list child tasks from repository")
+		return nil, err
+	}
+	if children == nil {
+		children = []entity.Task{}
+	}
+	if err := uc.loadLabels(ctx, taskPointers(children)...); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+		return nil, err
+	}
+	return children, nil
+}
+
+func (uc *TaskUseCaseImpl) Subtree(ctx context.Context, id string) (entity.TaskSubtree, error) {
+	logger.Log.Info("Getting task subtree", "id", id)
+
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return entity.TaskSubtree{}, ErrTaskNotFound
+	}
+
+	nodes, err := uc.taskRepo.Subtree(ctx, taskID)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task subtree from repository")
+		return entity.TaskSubtree{}, err
+	}
+	if err := uc.authz.AuthorizeTask(ctx, entity.ActionView, nodes[0].Task); err != nil {
+		return entity.TaskSubtree{}, err
+	}
+
+	// Первым в обходе идет корень поддерева
+	subtree := entity.TaskSubtree{
+		Task:        nodes[0].Task,
+		Descendants: nodes[1:],
+	}
+	subtree.Progress = uc.progress(subtree)
+
+	tasks := []*entity.Task{&subtree.Task}
+	for i := range subtree.Descendants {
+		tasks = append(tasks, &subtree.Descendants[i].Task)
+	}
+	if err := uc.loadLabels(ctx, tasks...); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+		return entity.TaskSubtree{}, err
+	}
+	return subtree, nil
+}
+
+// progress считает долю выполненных задач среди потомков. Прогресс задачи без
+// подзадач определяется её собственным статусом.
+func (uc *TaskUseCaseImpl) progress(subtree entity.TaskSubtree) entity.TaskProgress {
+	var p entity.TaskProgress
+	if len(subtree.Descendants) == 0 {
+		p.Total = 1
+		if uc.workflow.IsCompleted(subtree.Task.Status) {
+			p.Completed = 1
+		}
+	}
+	for _, node := range subtree.Descendants {
+		p.Total++
+		if uc.workflow.IsCompleted(node.Status) {
+			p.Completed++
+		}
+	}
+	p.Percent = float64(p.Completed) * 100 / float64(p.Total)
+	return p
+}
+
+func (uc *TaskUseCaseImpl) Move(ctx context.Context, id string, parentID *uuid.UUID, version int64) (entity.Task, error) {
+	logger.Log.Info("Moving task", "id", id, "parent_id", parentID)
+
+	movedTask, err := uc.taskRepo.MoveFunc(ctx, id, version, parentID, func(task *entity.Task, placement entity.TaskPlacement) error {
+		if err := uc.authz.AuthorizeTask(ctx, entity.ActionEdit, *task); err != nil {
+			return err
+		}
+		if err := checkPlacement(*task, placement); err != nil {
+			return err
+		}
+		task.ParentID = parentID
+		task.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to move task")
+		return entity.Task{}, err
+	}
+
+	if err := uc.invalidateProjects(ctx, movedTask.ProjectID); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after task move")
+	}
+
+	if err := uc.loadLabels(ctx, &movedTask); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+	}
+
+	logger.Log.Info("Task moved successfully", "id", id)
+	return movedTask, nil
+}
+
+// checkParent проверяет родителя новой задачи. Задача без проекта наследует проект родителя.
+func (uc *TaskUseCaseImpl) checkParent(ctx context.Context, task *entity.Task) error {
+	if task.ParentID == nil {
+		return nil
+	}
+	placement, err := uc.taskRepo.Placement(ctx, task.ID, task.ParentID)
+	if err != nil {
+		return err
+	}
+	if task.ProjectID == nil {
+		task.ProjectID = placement.Parent.ProjectID
+	}
+	return checkPlacement(*task, placement)
+}
+
+// checkProjectChange запрещает менять проект задачи, которая входит в иерархию. Вызывается
+// под блокировкой задачи, а перемещение под неё блокирует родителя, поэтому подзадача
+// не может появиться между проверкой и сохранением.
+func (uc *TaskUseCaseImpl) checkProjectChange(ctx context.Context, current, updated entity.Task) error {
+	if sameID(current.ProjectID, updated.ProjectID) {
+		return nil
+	}
+	if current.ParentID != nil {
+		return fmt.Errorf("%w: subtask belongs to its parent's project", ErrProjectChange)
+	}
+	children, err := uc.taskRepo.Children(ctx, current.ID)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return fmt.Errorf("%w: task has subtasks", ErrProjectChange)
+	}
+	return nil
+}
+
+// checkPlacement проверяет, что перемещение не образует цикл, не выводит задачу
+// из проекта родителя и не превышает допустимую глубину иерархии.
+func checkPlacement(task entity.Task, placement entity.TaskPlacement) error {
+	if placement.Parent == nil {
+		return nil
+	}
+	if placement.HasAncestor(task.ID) {
+		return fmt.Errorf("%w: task cannot be moved under itself or its descendant", ErrInvalidParent)
+	}
+	if !sameID(task.ProjectID, placement.Parent.ProjectID) {
+		return fmt.Errorf("%w: parent belongs to another project", ErrInvalidParent)
+	}
+	if placement.Depth() > entity.MaxTaskDepth {
+		return fmt.Errorf("%w: hierarchy cannot be deeper than %d levels", ErrInvalidParent, entity.MaxTaskDepth)
+	}
+	return nil
+}
+
+// sameID сравнивает необязательные ID по значению.
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestProjectChangeInHierarchy(t *testing.T) {
+	oldProject, newProject := uuid.New(), uuid.New()
+	parent := entity.Task{ID: uuid.New(), Title: "Parent", Status: "todo", ProjectID: &oldProject, Version: 1}
+	child := entity.Task{ID: uuid.New(), Title: "Child", Status: "todo", ProjectID: &oldProject, ParentID: &parent.ID, Version: 1}
+	leaf := entity.Task{ID: uuid.New(), Title: "Leaf", Status: "todo", ProjectID: &oldProject, Version: 1}
+
+	update := func(ctx context.Context, uc *TaskUseCaseImpl, task entity.Task, project *uuid.UUID) error {
+		task.ProjectID = project
+		_, err := uc.Update(ctx, task)
+		return err
+	}
+	patch := func(ctx context.Context, uc *TaskUseCaseImpl, task entity.Task, project *uuid.UUID) error {
+		doc := `{"project_id": null}`
+		if project != nil {
+			doc = `{"project_id": "` + project.String() + `"}`
+		}
+		p, err := NewMergePatch([]byte(doc))
+		if err != nil {
+			return err
+		}
+		_, err = uc.Patch(ctx, task.ID.String(), 0, p)
+		return err
+	}
+
+	tests := []struct {
+		name    string
+		task    entity.Task
+		project *uuid.UUID
+		wantErr error
+	}{
+		{name: "subtask to another project", task: child, project: &newProject, wantErr: ErrProjectChange},
+		{name: "subtask out of projects", task: child, project: nil, wantErr: ErrProjectChange},
+		{name: "parent to another project", task: parent, project: &newProject, wantErr: ErrProjectChange},
+		{name: "parent out of projects", task: parent, project: nil, wantErr: ErrProjectChange},
+		{name: "subtask within its project", task: child, project: &oldProject},
+		{name: "parent within its project", task: parent, project: &oldProject},
+		{name: "task outside hierarchy", task: leaf, project: &newProject},
+	}
+
+	for _, apply := range []struct {
+		name string
+		fn   func(context.Context, *TaskUseCaseImpl, entity.Task, *uuid.UUID) error
+	}{{"update", update}, {"patch", patch}} {
+		for _, tt := range tests {
+			t.Run(apply.name+"/"+tt.name, func(t *testing.T) {
+				repo := newFakeTaskRepo(parent, child, leaf)
+				uc := newTestTaskUseCase(repo)
+
+				err := apply.fn(context.Background(), uc, tt.task, tt.project)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil && !sameID(repo.tasks[tt.task.ID].ProjectID, tt.task.ProjectID) {
+					t.Errorf("project changed to %v despite error", repo.tasks[tt.task.ID].ProjectID)
+				}
+			})
+		}
+	}
+}
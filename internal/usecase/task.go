@@ -2,7 +2,13 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/KarpovAlexandrGo/task-service/internal/entity"
@@ -11,7 +17,15 @@ import (
 )
 
 var (
-	ErrTaskNotFound = errors.New("task not found")
+	ErrTaskNotFound  = entity.NewError(entity.KindNotFound, "task_not_found", "task not found")
+	ErrInvalidCursor = entity.NewError(entity.KindInvalidArgument, "invalid_cursor", "invalid pagination cursor")
+	ErrInvalidSort   = entity.NewError(entity.KindInvalidArgument, "invalid_sort", "invalid sort field")
+	ErrInvalidFilter = entity.NewError(entity.KindInvalidArgument, "invalid_filter", "invalid filter")
+	ErrEmptyQuery    = entity.NewError(entity.KindInvalidArgument, "empty_query", "search query is empty")
+	ErrInvalidPatch  = entity.NewError(entity.KindInvalidArgument, "invalid_patch", "invalid patch")
+	ErrInvalidTask   = entity.NewError(entity.KindValidation, "invalid_task", "invalid task")
+	// ErrVersionConflict возвращается, если задача изменилась после того, как клиент прочитал её версию.
+	ErrVersionConflict = entity.NewError(entity.KindVersionConflict, "version_conflict", "task version conflict")
 )
 
 type Logger interface {
@@ -19,23 +33,82 @@ type Logger interface {
 	Warn(msg string, fields map[string]interface{})
 }
 
+// TaskUseCase управляет задачами. Аутентифицированный автор запроса передается в контексте
+// (см. WithPrincipal): он становится автором новых задач, а каждая операция проверяет его
+// права через AuthorizationService и при отказе возвращает ошибку ErrForbidden.
+// Списки и поиск возвращают только задачи, доступные автору запроса.
 type TaskUseCase interface {
 	Create(ctx context.Context, task entity.Task) (entity.Task, error)
 	Get(ctx context.Context, id string) (entity.Task, error)
-	List(ctx context.Context, page, limit int) ([]entity.Task, error)
-	Update(ctx context.Context, task entity.Task) (entity.Task, error)
-	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, params entity.TaskListParams) (entity.TaskPage, error)
+	Search(ctx context.Context, params entity.TaskSearchParams) ([]entity.TaskSearchResult, error)
+	// Update, Patch и Delete выполняются только если текущая версия задачи равна
+	// переданной (task.Version или version); нулевая версия отключает проверку.
+	Update(ctx context.Context, task entity.Task, opts ...UpdateOption) (entity.Task, error)
+	Patch(ctx context.Context, id string, version int64, patch TaskPatch, opts ...UpdateOption) (entity.Task, error)
+	// Delete перемещает задачу в корзину.
+	Delete(ctx context.Context, id string, version int64) error
+	ListTrash(ctx context.Context, params entity.TaskListParams) (entity.TaskPage, error)
+	Restore(ctx context.Context, id string) (entity.Task, error)
+	// PurgeDeleted окончательно удаляет задачи, находящиеся в корзине дольше retention.
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error)
+
+	// Пакетные операции. При atomic все операции применяются в одной транзакции
+	// либо не применяется ни одна, иначе каждая операция выполняется независимо.
+	BatchCreate(ctx context.Context, tasks []entity.Task, atomic bool) (entity.BatchResult, error)
+	BatchUpdate(ctx context.Context, tasks []entity.Task, atomic bool) (entity.BatchResult, error)
+	BatchDelete(ctx context.Context, refs []entity.TaskRef, atomic bool) (entity.BatchResult, error)
+
+	// NextStatuses перечисляет статусы, в которые процесс позволяет перевести задачу.
+	NextStatuses(ctx context.Context, id string) ([]entity.StatusTransition, error)
+
+	// Children возвращает непосредственные подзадачи, Subtree — все вложенные задачи
+	// с прогрессом их выполнения.
+	Children(ctx context.Context, id string) ([]entity.Task, error)
+	Subtree(ctx context.Context, id string) (entity.TaskSubtree, error)
+	// Move переносит задачу вместе с подзадачами под parentID; nil делает её задачей верхнего уровня.
+	Move(ctx context.Context, id string, parentID *uuid.UUID, version int64) (entity.Task, error)
+
+	// AddDependency отмечает, что blockerID блокирует задачу blockedID; связь,
+	// замыкающая цикл, отклоняется с ErrDependencyCycle.
+	AddDependency(ctx context.Context, blockedID string, blockerID uuid.UUID) (entity.TaskDependency, error)
+	RemoveDependency(ctx context.Context, blockedID string, blockerID uuid.UUID) error
+	// DependencyGraph возвращает задачи, достижимые от задачи не более чем за depth связей в любую сторону.
+	DependencyGraph(ctx context.Context, id string, depth int) (entity.TaskGraph, error)
+
+	// AttachLabel и DetachLabel назначают и снимают метку и возвращают задачу с актуальными метками.
+	AttachLabel(ctx context.Context, id string, labelID uuid.UUID) (entity.Task, error)
+	DetachLabel(ctx context.Context, id string, labelID uuid.UUID) (entity.Task, error)
+
+	// MoveOnBoard переводит задачу в колонку position.Status и ставит её между соседями.
+	// Смена колонки проверяется процессом работы так же, как смена статуса в Update.
+	MoveOnBoard(ctx context.Context, id string, version int64, position entity.BoardPosition, opts ...UpdateOption) (entity.Task, error)
+	// Board возвращает первые params.Limit задач каждой колонки в порядке рангов.
+	Board(ctx context.Context, params entity.BoardParams) (entity.Board, error)
+	// RebalanceRanks равномерно перераспределяет ранги в колонках, где они стали
+	// длиннее entity.MaxRankLength, и возвращает число задач с новыми рангами.
+	RebalanceRanks(ctx context.Context) (int64, error)
 }
 
 type TaskUseCaseImpl struct {
-	taskRepo  TaskRepository
-	cacheRepo CacheRepository
+	taskRepo        TaskRepository
+	projectRepo     ProjectRepository
+	labelRepo       LabelRepository
+	customFieldRepo CustomFieldRepository
+	cacheRepo       CacheRepository
+	authz           *AuthorizationService
+	workflow        entity.Workflow
 }
 
-func NewTaskUseCase(taskRepo TaskRepository, cacheRepo CacheRepository) *TaskUseCaseImpl {
+func NewTaskUseCase(taskRepo TaskRepository, projectRepo ProjectRepository, labelRepo LabelRepository, customFieldRepo CustomFieldRepository, cacheRepo CacheRepository, authz *AuthorizationService, workflow entity.Workflow) *TaskUseCaseImpl {
 	return &TaskUseCaseImpl{
-		taskRepo:  taskRepo,
-		cacheRepo: cacheRepo,
+		taskRepo:        taskRepo,
+		projectRepo:     projectRepo,
+		labelRepo:       labelRepo,
+		customFieldRepo: customFieldRepo,
+		cacheRepo:       cacheRepo,
+		authz:           authz,
+		workflow:        workflow,
 	}
 }
 
@@ -44,12 +117,35 @@ func (uc *TaskUseCaseImpl) Create(ctx context.Context, task entity.Task) (entity
 
 	if err := task.Validate(); err != nil {
 		logger.Log.WithError(err).Error("Task validation failed")
+		return entity.Task{}, fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+	if err := uc.checkStatus(task); err != nil {
+		logger.Log.WithError(err).Warn("Task status rejected by workflow")
+		return entity.Task{}, err
+	}
+	if err := uc.checkCustomFields(ctx, &task); err != nil {
+		logger.Log.WithError(err).Error("Task custom fields check failed")
 		return entity.Task{}, err
 	}
 
 	if task.ID == uuid.Nil {
 		task.ID = uuid.New()
 	}
+	// Новая задача встает в конец колонки; место на доске задает MoveOnBoard
+	task.Rank = ""
+	stampCreator(ctx, &task)
+	if err := uc.checkParent(ctx, &task); err != nil {
+		logger.Log.WithError(err).Error("Task parent check failed")
+		return entity.Task{}, err
+	}
+	if err := uc.checkProject(ctx, task.ProjectID); err != nil {
+		logger.Log.WithError(err).Error("Task project check failed")
+		return entity.Task{}, err
+	}
+	if err := uc.authz.AuthorizeProject(ctx, entity.ActionCreate, task.ProjectID); err != nil {
+		logger.Log.WithError(err).Warn("Task creation rejected")
+		return entity.Task{}, err
+	}
 	task.CreatedAt = time.Now()
 	task.UpdatedAt = task.CreatedAt
 
@@ -59,7 +155,7 @@ func (uc *TaskUseCaseImpl) Create(ctx context.Context, task entity.Task) (entity
 		return entity.Task{}, err
 	}
 
-	if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+	if err := uc.invalidateProjects(ctx, createdTask.ProjectID); err != nil {
 		logger.Log.WithError(err).Error("Failed to invalidate cache")
 	}
 
@@ -74,73 +170,311 @@ func (uc *TaskUseCaseImpl) Get(ctx context.Context, id string) (entity.Task, err
 		logger.Log.WithError(err).Error("Failed to get task from repository")
 		return entity.Task{}, err
 	}
+	if err := uc.authz.AuthorizeTask(ctx, entity.ActionView, task); err != nil {
+		logger.Log.WithError(err).Warn("Task access rejected")
+		return entity.Task{}, err
+	}
+	if err := uc.loadLabels(ctx, &task); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+		return entity.Task{}, err
+	}
 	return task, nil
 }
 
-func (uc *TaskUseCaseImpl) List(ctx context.Context, page, limit int) ([]entity.Task, error) {
+func (uc *TaskUseCaseImpl) List(ctx context.Context, params entity.TaskListParams) (entity.TaskPage, error) {
 	logger.Log.Info("Listing tasks")
 
-	if page < 1 {
-		page = 1
-	}
+	limit := params.Limit
 	if limit < 1 || limit > 100 {
 		limit = 20
 	}
+	params.Limit = limit
+	if params.Sort.Field == "" {
+		params.Sort.Field = entity.TaskSortCreatedAt
+	}
+	if !params.Sort.IsValid() {
+		return entity.TaskPage{}, ErrInvalidSort
+	}
+	// Ограничение видимости входит в ключ кэша, поэтому пользователи не получают чужие страницы
+	params.Filter.VisibleTo = uc.authz.VisibleTo(ctx)
+	if err := uc.resolveCustomFieldParams(ctx, &params); err != nil {
+		logger.Log.WithError(err).Warn("Task list custom field parameters rejected")
+		return entity.TaskPage{}, err
+	}
+
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor)
+		if err != nil || after.Sort != params.Sort {
+			logger.Log.WithError(err).Warn("Invalid pagination cursor")
+			return entity.TaskPage{}, ErrInvalidCursor
+		}
+		params.After = &after
+	}
 
-	tasks, err := uc.cacheRepo.GetTasks(ctx)
-	if err == nil {
-		logger.Log.Info("Tasks retrieved from cache")
-		return tasks[limit*(page-1) : limit*page], nil // Простая пагинация
+	// Права проверяются до обращения к кэшу, чтобы отзыв роли действовал сразу,
+	// а не после истечения срока хранения страницы
+	if err := uc.checkProject(ctx, params.Filter.ProjectID); err != nil {
+		logger.Log.WithError(err).Error("Task list project check failed")
+		return entity.TaskPage{}, err
+	}
+	if err := uc.authz.AuthorizeProject(ctx, entity.ActionView, params.Filter.ProjectID); err != nil {
+		logger.Log.WithError(err).Warn("Task list access rejected")
+		return entity.TaskPage{}, err
 	}
 
-	logger.Log.Info("Cache miss, retrieving from repository")
+	// Выборка по срокам зависит от текущего времени, поэтому не кэшируется
+	relative := params.Filter.IsRelative()
+	cacheKey := listCacheKey(params)
+	if !relative {
+		cached, err := uc.cacheRepo.GetTaskPage(ctx, cacheKey)
+		if err != nil {
+			logger.Log.WithError(err).Error("Failed to get tasks from cache")
+		} else if cached != nil {
+			logger.Log.Info("Tasks retrieved from cache")
+			return *cached, nil
+		}
+		logger.Log.Info("Cache miss, retrieving from repository")
+	}
 
-	tasks, err = uc.taskRepo.List(ctx)
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	repoParams := params
+	repoParams.Limit = limit + 1
+	repoParams.Filter = uc.resolveDueFilter(params.Filter, time.Now())
+	tasks, err := uc.taskRepo.List(ctx, repoParams)
 	if err != nil {
 		logger.Log.WithError(err).Error("Failed to list tasks from repository")
+		return entity.TaskPage{}, err
+	}
+
+	if err := uc.loadLabels(ctx, taskPointers(tasks)...); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+		return entity.TaskPage{}, err
+	}
+
+	page := entity.TaskPage{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.HasMore = true
+		last := page.Tasks[limit-1]
+		page.NextCursor = encodeCursor(entity.TaskCursor{
+			Sort:  params.Sort,
+			Value: sortValue(last, params.Sort.Field),
+			ID:    last.ID,
+		})
+	}
+	if page.Tasks == nil {
+		page.Tasks = []entity.Task{}
+	}
+
+	if !relative {
+		if err := uc.cacheRepo.SetTaskPage(ctx, cacheKey, page, 5*time.Minute); err != nil {
+			logger.Log.WithError(err).Error("Failed to set tasks in cache")
+		}
+	}
+
+	logger.Log.Info("Tasks listed successfully", "count", len(page.Tasks))
+	return page, nil
+}
+
+func (uc *TaskUseCaseImpl) ListTrash(ctx context.Context, params entity.TaskListParams) (entity.TaskPage, error) {
+	params.Filter.Deleted = true
+	return uc.List(ctx, params)
+}
+
+func (uc *TaskUseCaseImpl) Search(ctx context.Context, params entity.TaskSearchParams) ([]entity.TaskSearchResult, error) {
+	logger.Log.Info("Searching tasks", "query", params.Query)
+
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		return nil, ErrEmptyQuery
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+	if err := uc.authz.AuthorizeProject(ctx, entity.ActionView, params.ProjectID); err != nil {
+		logger.Log.WithError(err).Warn("Task search access rejected")
 		return nil, err
 	}
+	params.VisibleTo = uc.authz.VisibleTo(ctx)
 
-	if err := uc.cacheRepo.SetTasks(ctx, tasks, 5*time.Minute); err != nil {
-		logger.Log.WithError(err).Error("Failed to set tasks in cache")
+	results, err := uc.taskRepo.Search(ctx, params)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to search tasks in repository")
+		return nil, err
+	}
+	if results == nil {
+		results = []entity.TaskSearchResult{}
+	}
+	tasks := make([]*entity.Task, len(results))
+	for i := range results {
+		tasks[i] = &results[i].Task
+	}
+	if err := uc.loadLabels(ctx, tasks...); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+		return nil, err
 	}
 
-	logger.Log.Info("Tasks listed successfully", "count", len(tasks))
-	return tasks[limit*(page-1) : limit*page], nil // Простая пагинация
+	logger.Log.Info("Tasks searched successfully", "count", len(results))
+	return results, nil
 }
 
-func (uc *TaskUseCaseImpl) Update(ctx context.Context, task entity.Task) (entity.Task, error) {
+func (uc *TaskUseCaseImpl) Update(ctx context.Context, task entity.Task, opts ...UpdateOption) (entity.Task, error) {
 	logger.Log.Info("Starting task update", "id", task.ID.String())
 
 	if err := task.Validate(); err != nil {
 		logger.Log.WithError(err).Error("Validation failed during task update")
+		return entity.Task{}, fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+	if err := uc.checkCustomFields(ctx, &task); err != nil {
+		logger.Log.WithError(err).Error("Task custom fields check failed")
 		return entity.Task{}, err
 	}
 
-	task.UpdatedAt = time.Now()
-	updatedTask, err := uc.taskRepo.Update(ctx, task)
+	// Переход проверяется относительно статуса, заблокированного в транзакции обновления
+	var previousProject *uuid.UUID
+	updatedTask, err := uc.taskRepo.UpdateFunc(ctx, task.ID.String(), task.Version, func(current *entity.Task) error {
+		previousProject = current.ProjectID
+		return uc.replace(ctx, current, task, newUpdateOptions(opts))
+	})
 	if err != nil {
 		logger.Log.WithError(err).Error("Failed to update task in repository")
 		return entity.Task{}, err
 	}
 
-	if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+	if err := uc.invalidateProjects(ctx, previousProject, updatedTask.ProjectID); err != nil {
 		logger.Log.WithError(err).Error("Failed to invalidate cache after task update")
 	}
 
+	// Изменение уже сохранено, поэтому ошибка загрузки меток не отменяет его
+	if err := uc.loadLabels(ctx, &updatedTask); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+	}
+
 	logger.Log.Info("Task updated successfully", "id", updatedTask.ID.String())
 	return updatedTask, nil
 }
 
-func (uc *TaskUseCaseImpl) Delete(ctx context.Context, id string) error {
+// replace переносит изменяемые поля task в current, если процесс разрешает смену статуса.
+func (uc *TaskUseCaseImpl) replace(ctx context.Context, current *entity.Task, task entity.Task, opts UpdateOptions) error {
+	if err := uc.authz.AuthorizeTask(ctx, entity.ActionEdit, *current); err != nil {
+		return err
+	}
+	if !sameID(current.ProjectID, task.ProjectID) {
+		if err := uc.authz.AuthorizeProject(ctx, entity.ActionCreate, task.ProjectID); err != nil {
+			return err
+		}
+	}
+	updated := *current
+	updated.Title = task.Title
+	updated.Description = task.Description
+	updated.Status = task.Status
+	updated.ProjectID = task.ProjectID
+	updated.StartAt = task.StartAt
+	updated.DueAt = task.DueAt
+	updated.Priority = task.Priority
+	updated.CustomFields = task.CustomFields
+	updated.AssigneeID = task.AssigneeID
+	if err := uc.checkProjectChange(ctx, *current, updated); err != nil {
+		return err
+	}
+	if err := uc.checkTransition(*current, updated); err != nil {
+		return err
+	}
+	if err := uc.checkBlockers(ctx, *current, updated, opts); err != nil {
+		return err
+	}
+	updated.UpdatedAt = time.Now()
+	*current = updated
+	return nil
+}
+
+func (uc *TaskUseCaseImpl) Patch(ctx context.Context, id string, version int64, patch TaskPatch, opts ...UpdateOption) (entity.Task, error) {
+	logger.Log.Info("Starting task patch", "id", id)
+
+	options := newUpdateOptions(opts)
+	var previousProject *uuid.UUID
+	patchedTask, err := uc.taskRepo.UpdateFunc(ctx, id, version, func(task *entity.Task) error {
+		current := *task
+		previousProject = current.ProjectID
+		if err := uc.authz.AuthorizeTask(ctx, entity.ActionEdit, current); err != nil {
+			return err
+		}
+		if err := patch(task); err != nil {
+			return err
+		}
+		if !sameID(current.ProjectID, task.ProjectID) {
+			if err := uc.authz.AuthorizeProject(ctx, entity.ActionCreate, task.ProjectID); err != nil {
+				return err
+			}
+		}
+		if err := uc.checkProjectChange(ctx, current, *task); err != nil {
+			return err
+		}
+		if err := task.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTask, err)
+		}
+		if err := uc.checkCustomFields(ctx, task); err != nil {
+			return err
+		}
+		if err := uc.checkTransition(current, *task); err != nil {
+			return err
+		}
+		if !sameID(current.ParentID, task.ParentID) {
+			return fmt.Errorf("%w: parent_id can only be changed by moving the task", ErrInvalidPatch)
+		}
+		if err := uc.checkBlockers(ctx, current, *task, options); err != nil {
+			return err
+		}
+		task.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to patch task")
+		return entity.Task{}, err
+	}
+
+	if err := uc.invalidateProjects(ctx, previousProject, patchedTask.ProjectID); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after task patch")
+	}
+
+	// Изменение уже сохранено, поэтому ошибка загрузки меток не отменяет его
+	if err := uc.loadLabels(ctx, &patchedTask); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+	}
+
+	logger.Log.Info("Task patched successfully", "id", id)
+	return patchedTask, nil
+}
+
+func (uc *TaskUseCaseImpl) Delete(ctx context.Context, id string, version int64) error {
 	logger.Log.Info("Deleting task", "id", id)
 
-	if err := uc.taskRepo.Delete(ctx, id); err != nil {
+	// Права проверяются по прочитанной версии задачи, и удаляется именно она: если задачу
+	// успеют изменить или перенести в другой проект, удаление будет отклонено с
+	// ErrVersionConflict, даже когда клиент не передал версию. Та же версия определяет,
+	// списки какого проекта сбросить в кэше
+	task, err := uc.taskRepo.Get(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task for deletion")
+		return err
+	}
+	if version != 0 && version != task.Version {
+		return ErrVersionConflict
+	}
+	if err := uc.authz.AuthorizeTask(ctx, entity.ActionDelete, task); err != nil {
+		logger.Log.WithError(err).Warn("Task deletion rejected")
+		return err
+	}
+
+	if err := uc.taskRepo.Delete(ctx, id, task.Version); err != nil {
 		logger.Log.WithError(err).Error("Failed to delete task from repository")
 		return err
 	}
 
-	if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+	if err := uc.invalidateProjects(ctx, task.ProjectID); err != nil {
 		logger.Log.WithError(err).Error("Failed to invalidate cache after task deletion")
 	}
 
@@ -148,16 +482,216 @@ func (uc *TaskUseCaseImpl) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (uc *TaskUseCaseImpl) Restore(ctx context.Context, id string) (entity.Task, error) {
+	logger.Log.Info("Restoring task", "id", id)
+
+	task, err := uc.taskRepo.Restore(ctx, id, func(task entity.Task) error {
+		return uc.authz.AuthorizeTask(ctx, entity.ActionDelete, task)
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to restore task from trash")
+		return entity.Task{}, err
+	}
+
+	if err := uc.invalidateProjects(ctx, task.ProjectID); err != nil {
+		logger.Log.WithError(err).Error("Failed to invalidate cache after task restore")
+	}
+
+	// Изменение уже сохранено, поэтому ошибка загрузки меток не отменяет его
+	if err := uc.loadLabels(ctx, &task); err != nil {
+		logger.Log.WithError(err).Error("Failed to load task labels")
+	}
+
+	logger.Log.Info("Task restored successfully", "id", id)
+	return task, nil
+}
+
+func (uc *TaskUseCaseImpl) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	if err := uc.authz.AuthorizeAdmin(ctx); err != nil {
+		return 0, err
+	}
+	deletedBefore := time.Now().Add(-retention)
+
+	var total int64
+	for {
+		n, err := uc.taskRepo.Purge(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			logger.Log.WithError(err).Error("Failed to purge deleted tasks")
+			return total, err
+		}
+		total += n
+		if n < purgeBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		if err := uc.cacheRepo.Invalidate(ctx); err != nil {
+			logger.Log.WithError(err).Error("Failed to invalidate cache after trash purge")
+		}
+		logger.Log.Info("Deleted tasks purged", "count", total)
+	}
+	return total, nil
+}
+
+// purgeBatchSize ограничивает число строк, удаляемых одним запросом при очистке корзины.
+const purgeBatchSize = 500
+
+// TaskRepository хранит задачи. Каждое изменение задачи записывается в её историю
+// в той же транзакции вместе с AuditFromContext(ctx).
 type TaskRepository interface {
 	Create(ctx context.Context, task entity.Task) (entity.Task, error)
 	Get(ctx context.Context, id string) (entity.Task, error)
-	List(ctx context.Context) ([]entity.Task, error)
+	// List возвращает не более params.Limit задач, подходящих под params.Filter,
+	// упорядоченных по (params.Sort.Field, id) и начиная после params.After.
+	List(ctx context.Context, params entity.TaskListParams) ([]entity.Task, error)
+	// Search выполняет ранжированный полнотекстовый поиск, лучшие совпадения идут первыми.
+	Search(ctx context.Context, params entity.TaskSearchParams) ([]entity.TaskSearchResult, error)
+	// Update, UpdateFunc и Delete увеличивают версию задачи и возвращают ErrVersionConflict,
+	// если ненулевая ожидаемая версия не совпадает с текущей.
 	Update(ctx context.Context, task entity.Task) (entity.Task, error)
-	Delete(ctx context.Context, id string) error
+	// UpdateFunc в одной транзакции блокирует задачу, передает её в fn и сохраняет результат.
+	// Если fn возвращает ошибку, изменения не сохраняются.
+	UpdateFunc(ctx context.Context, id string, version int64, fn func(task *entity.Task) error) (entity.Task, error)
+	// Delete помечает задачу удаленной; Get, List, Search и Update её больше не видят.
+	Delete(ctx context.Context, id string, version int64) error
+	// Restore возвращает задачу из корзины, если fn не отклоняет восстановленную задачу;
+	// ошибка fn отменяет восстановление.
+	Restore(ctx context.Context, id string, fn func(task entity.Task) error) (entity.Task, error)
+	// Purge удаляет не более batchSize задач, помеченных удаленными раньше deletedBefore.
+	Purge(ctx context.Context, deletedBefore time.Time, batchSize int) (int64, error)
+
+	// Children возвращает неудаленные подзадачи в порядке создания.
+	Children(ctx context.Context, id uuid.UUID) ([]entity.Task, error)
+	// Subtree возвращает задачу и её неудаленных потомков в порядке обхода в глубину.
+	Subtree(ctx context.Context, id uuid.UUID) ([]entity.TaskNode, error)
+	// Placement описывает положение задачи id под родителем parentID.
+	Placement(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (entity.TaskPlacement, error)
+	// MoveFunc в одной транзакции блокирует задачу, вычисляет её положение под parentID,
+	// передает их в fn и сохраняет результат. Перемещения выполняются последовательно.
+	MoveFunc(ctx context.Context, id string, version int64, parentID *uuid.UUID,
+		fn func(task *entity.Task, placement entity.TaskPlacement) error) (entity.Task, error)
+
+	// AddDependency добавляет связь, если обе задачи существуют и связь не замыкает цикл.
+	// Повторное добавление существующей связи не является ошибкой.
+	AddDependency(ctx context.Context, dep entity.TaskDependency) (entity.TaskDependency, error)
+	RemoveDependency(ctx context.Context, blockerID, blockedID uuid.UUID) error
+	// OpenBlockers возвращает неудаленные блокирующие задачи, статус которых не входит в completed.
+	OpenBlockers(ctx context.Context, id uuid.UUID, completed []string) ([]uuid.UUID, error)
+	DependencyGraph(ctx context.Context, id uuid.UUID, depth int) (entity.TaskGraph, error)
+
+	// Пакетные методы возвращают результаты в порядке входного списка. В атомарном
+	// режиме ошибка любой операции отменяет транзакцию и возвращается ErrBatchAborted.
+	CreateBatch(ctx context.Context, tasks []entity.Task, atomic bool) ([]entity.BatchItemResult, error)
+	// UpdateBatch блокирует каждую задачу refs[i], проверяет версию и сохраняет её после fn(i, task).
+	UpdateBatch(ctx context.Context, refs []entity.TaskRef, atomic bool, fn func(i int, task *entity.Task) error) ([]entity.BatchItemResult, error)
+	// DeleteBatch блокирует каждую задачу refs[i], проверяет версию и перемещает её в
+	// корзину, если fn(i, task) не вернула ошибку.
+	DeleteBatch(ctx context.Context, refs []entity.TaskRef, atomic bool, fn func(i int, task entity.Task) error) ([]entity.BatchItemResult, error)
+
+	// RankFunc в одной транзакции блокирует задачу, передает её в fn и назначает ей ранг
+	// между соседями position в колонке её нового статуса. Соседи, не найденные в колонке,
+	// отклоняются с ErrInvalidPosition.
+	RankFunc(ctx context.Context, id string, version int64, position entity.BoardPosition,
+		fn func(task *entity.Task) error) (entity.Task, error)
+	// LongRankColumns возвращает статусы колонок, в которых есть ранги длиннее maxLength.
+	LongRankColumns(ctx context.Context, maxLength int) ([]string, error)
+	// RebalanceRanks заново распределяет ранги колонки status с сохранением порядка,
+	// если в ней все еще есть ранги длиннее maxLength.
+	RebalanceRanks(ctx context.Context, status string, maxLength int) (int64, error)
 }
 
 type CacheRepository interface {
-	SetTasks(ctx context.Context, tasks []entity.Task, ttl time.Duration) error
-	GetTasks(ctx context.Context) ([]entity.Task, error)
+	SetTaskPage(ctx context.Context, key string, page entity.TaskPage, ttl time.Duration) error
+	// GetTaskPage возвращает nil без ошибки, если страницы нет в кэше.
+	GetTaskPage(ctx context.Context, key string) (*entity.TaskPage, error)
+	// Invalidate удаляет все закэшированные страницы, InvalidateScopes — страницы,
+	// ключи которых начинаются с перечисленных префиксов.
 	Invalidate(ctx context.Context) error
+	InvalidateScopes(ctx context.Context, scopes ...string) error
+}
+
+// encodeCursor кодирует позицию в непрозрачную для клиента строку.
+func encodeCursor(c entity.TaskCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// sortValue возвращает значение поля сортировки задачи в том виде, в котором оно хранится в курсоре.
+func sortValue(task entity.Task, field string) string {
+	switch field {
+	case entity.TaskSortUpdatedAt:
+		return task.UpdatedAt.Format(time.RFC3339Nano)
+	case entity.TaskSortTitle:
+		return task.Title
+	case entity.TaskSortDueAt:
+		return dueSortValue(task)
+	case entity.TaskSortPriority:
+		return strconv.Itoa(-task.Priority) + "|" + dueSortValue(task)
+	case entity.TaskSortRank:
+		return task.Rank
+	}
+	if key, ok := (entity.TaskSort{Field: field}).CustomFieldKey(); ok {
+		if value, ok := task.CustomFields[key]; ok {
+			return string(value)
+		}
+		return "null"
+	}
+	return task.CreatedAt.Format(time.RFC3339Nano)
+}
+
+// dueSortValue возвращает срок задачи для курсора; задачи без срока сортируются как бесконечно поздние.
+func dueSortValue(task entity.Task) string {
+	if task.DueAt == nil {
+		return "infinity"
+	}
+	return task.DueAt.Format(time.RFC3339Nano)
+}
+
+// resolveDueFilter переводит относительные фильтры по срокам в границы DueFrom и DueTo
+// на момент now. Несколько фильтров сужают диапазон совместно.
+func (uc *TaskUseCaseImpl) resolveDueFilter(filter entity.TaskFilter, now time.Time) entity.TaskFilter {
+	narrow := func(from, to time.Time) {
+		if filter.DueFrom == nil || filter.DueFrom.Before(from) {
+			filter.DueFrom = &from
+		}
+		if filter.DueTo == nil || filter.DueTo.After(to) {
+			filter.DueTo = &to
+		}
+	}
+
+	if filter.Overdue {
+		if filter.DueTo == nil || filter.DueTo.After(now) {
+			filter.DueTo = &now
+		}
+		filter.ExcludeStatuses = uc.workflow.Completed
+	}
+	if filter.DueToday {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		narrow(today, today.AddDate(0, 0, 1))
+	}
+	if filter.DueWithinDays > 0 {
+		narrow(now, now.AddDate(0, 0, filter.DueWithinDays))
+	}
+	return filter
+}
+
+// listCacheKey строит ключ кэша, уникальный для набора фильтров, сортировки и позиции.
+// Ключи страниц проекта начинаются с префикса проекта, чтобы их можно было сбросить отдельно.
+func listCacheKey(params entity.TaskListParams) string {
+	data, _ := json.Marshal(params)
+	sum := sha1.Sum(data)
+	return cacheScope(params.Filter.ProjectID) + ":" + hex.EncodeToString(sum[:])
+}
+
+func decodeCursor(s string) (entity.TaskCursor, error) {
+	var c entity.TaskCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, err
+	}
+	return c, nil
 }
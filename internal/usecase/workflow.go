@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/KarpovAlexandrGo/task-service/pkg/logger"
+)
+
+// ErrInvalidTransition возвращается, если процесс не разрешает перевести задачу в новый статус.
+var ErrInvalidTransition = entity.NewError(entity.KindFailedPrecondition, "invalid_transition", "invalid status transition")
+
+// TransitionError описывает отклоненный переход: недопустимый статус, запрещенный
+// переход или невыполненные условия перехода.
+type TransitionError struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to"`
+	// Allowed перечисляет статусы, в которые задача может перейти из From.
+	Allowed      []string `json:"allowed,omitempty"`
+	FailedGuards []string `json:"failed_guards,omitempty"`
+}
+
+func (e *TransitionError) Error() string {
+	switch {
+	case len(e.FailedGuards) > 0:
+		return fmt.Sprintf("%s: %q requires %s", ErrInvalidTransition, e.To, strings.Join(e.FailedGuards, ", "))
+	case e.From == "":
+		return fmt.Sprintf("%s: unknown status %q", ErrInvalidTransition, e.To)
+	default:
+		return fmt.Sprintf("%s: %q -> %q is not allowed", ErrInvalidTransition, e.From, e.To)
+	}
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// checkStatus проверяет статус новой задачи и условия перехода в него.
+func (uc *TaskUseCaseImpl) checkStatus(task entity.Task) error {
+	if !uc.workflow.HasStatus(task.Status) {
+		return &TransitionError{To: task.Status}
+	}
+	if failed := uc.workflow.FailedGuards(task, task.Status); len(failed) > 0 {
+		return &TransitionError{To: task.Status, FailedGuards: failed}
+	}
+	return nil
+}
+
+// checkTransition проверяет переход задачи из статуса current.Status в updated.Status.
+// Условия проверяются только при смене статуса.
+func (uc *TaskUseCaseImpl) checkTransition(current, updated entity.Task) error {
+	if current.Status == updated.Status {
+		return nil
+	}
+	if !uc.workflow.CanTransition(current.Status, updated.Status) {
+		return &TransitionError{From: current.Status, To: updated.Status, Allowed: uc.workflow.Transitions[current.Status]}
+	}
+	if failed := uc.workflow.FailedGuards(updated, updated.Status); len(failed) > 0 {
+		return &TransitionError{From: current.Status, To: updated.Status, FailedGuards: failed}
+	}
+	return nil
+}
+
+func (uc *TaskUseCaseImpl) NextStatuses(ctx context.Context, id string) ([]entity.StatusTransition, error) {
+	task, err := uc.taskRepo.Get(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Failed to get task from repository")
+		return nil, err
+	}
+	if err := uc.authz.AuthorizeTask(ctx, entity.ActionView, task); err != nil {
+		return nil, err
+	}
+	return uc.workflow.NextStatuses(task), nil
+}
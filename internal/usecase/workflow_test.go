@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/KarpovAlexandrGo/task-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+// testWorkflow — линейный процесс с условием перехода на проверку.
+func testWorkflow() entity.Workflow {
+	return entity.Workflow{
+		Statuses: []string{"backlog", "todo", "review", "done"},
+		Transitions: map[string][]string{
+			"backlog": {"todo"},
+			"todo":    {"backlog", "review"},
+			"review":  {"todo", "done"},
+		},
+		Guards:    map[string][]string{"review": {entity.GuardDescriptionRequired}},
+		Completed: []string{"done"},
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		name        string
+		from, to    string
+		description string
+		wantErr     *TransitionError
+	}{
+		{name: "allowed", from: "backlog", to: "todo"},
+		{name: "allowed back", from: "review", to: "todo"},
+		{name: "same status", from: "done", to: "done"},
+		{name: "guard passed", from: "todo", to: "review", description: "Ready"},
+		{
+			name: "guard failed", from: "todo", to: "review", description: "  ",
+			wantErr: &TransitionError{From: "todo", To: "review", FailedGuards: []string{entity.GuardDescriptionRequired}},
+		},
+		{
+			name: "skipping a status", from: "backlog", to: "done",
+			wantErr: &TransitionError{From: "backlog", To: "done", Allowed: []string{"todo"}},
+		},
+		{
+			name: "from final status", from: "done", to: "todo",
+			wantErr: &TransitionError{From: "done", To: "todo"},
+		},
+		{
+			name: "unknown status", from: "todo", to: "archived",
+			wantErr: &TransitionError{From: "todo", To: "archived", Allowed: []string{"backlog", "review"}},
+		},
+	}
+
+	uc := &TaskUseCaseImpl{workflow: testWorkflow()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := entity.Task{Status: tt.from}
+			updated := entity.Task{Status: tt.to, Description: tt.description}
+			checkTransitionError(t, uc.checkTransition(current, updated), tt.wantErr)
+		})
+	}
+}
+
+func TestCheckStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		description string
+		wantErr     *TransitionError
+	}{
+		{name: "initial status", status: "backlog"},
+		{name: "any declared status", status: "done"},
+		{name: "guard passed", status: "review", description: "Ready"},
+		{
+			name: "guard failed", status: "review",
+			wantErr: &TransitionError{To: "review", FailedGuards: []string{entity.GuardDescriptionRequired}},
+		},
+		{name: "unknown status", status: "archived", wantErr: &TransitionError{To: "archived"}},
+	}
+
+	uc := &TaskUseCaseImpl{workflow: testWorkflow()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := entity.Task{Status: tt.status, Description: tt.description}
+			checkTransitionError(t, uc.checkStatus(task), tt.wantErr)
+		})
+	}
+}
+
+func TestUpdateChecksTransition(t *testing.T) {
+	task := entity.Task{ID: uuid.New(), Title: "Task", Status: "backlog", Version: 1}
+	repo := newFakeTaskRepo(task)
+	uc := newTestTaskUseCase(repo)
+	uc.workflow = testWorkflow()
+
+	task.Status = "done"
+	_, err := uc.Update(context.Background(), task)
+	checkTransitionError(t, err, &TransitionError{From: "backlog", To: "done", Allowed: []string{"todo"}})
+	if stored := repo.tasks[task.ID]; stored.Status != "backlog" || stored.Version != 1 {
+		t.Errorf("stored task = %s v%d, want backlog v1", stored.Status, stored.Version)
+	}
+
+	task.Status = "todo"
+	updated, err := uc.Update(context.Background(), task)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Status != "todo" {
+		t.Errorf("status = %q, want todo", updated.Status)
+	}
+}
+
+// checkTransitionError сравнивает err с ожидаемым отказом в переходе; want == nil
+// означает, что переход разрешен.
+func checkTransitionError(t *testing.T, err error, want *TransitionError) {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+
+	var got *TransitionError
+	if !errors.As(err, &got) {
+		t.Fatalf("error = %v, want *TransitionError", err)
+	}
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("error %v does not wrap ErrInvalidTransition", err)
+	}
+	if domainErr, ok := entity.AsError(err); !ok || domainErr.Kind != entity.KindFailedPrecondition {
+		t.Errorf("error kind = %v, want %v", domainErr, entity.KindFailedPrecondition)
+	}
+	if got.From != want.From || got.To != want.To ||
+		!slices.Equal(got.Allowed, want.Allowed) || !slices.Equal(got.FailedGuards, want.FailedGuards) {
+		t.Errorf("error = %+v, want %+v", got, want)
+	}
+}
@@ -1 +0,0 @@
-DROP TABLE IF EXISTS tasks;
\ No newline at end of file
@@ -1,3 +1,4 @@
+-- +goose Up
 CREATE TABLE tasks (
     id UUID PRIMARY KEY,
     title VARCHAR(255) NOT NULL,
@@ -5,4 +6,7 @@ CREATE TABLE tasks (
     status VARCHAR(50) NOT NULL,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL
-);
\ No newline at end of file
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS tasks;
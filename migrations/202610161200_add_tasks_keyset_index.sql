@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_tasks_created_at_id ON tasks (created_at, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_created_at_id;
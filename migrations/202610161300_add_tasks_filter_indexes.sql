@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_tasks_updated_at_id ON tasks (updated_at, id);
+CREATE INDEX idx_tasks_title_id ON tasks (title, id);
+CREATE INDEX idx_tasks_status ON tasks (status);
+CREATE INDEX idx_tasks_title_trgm ON tasks USING GIN (title gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_title_trgm;
+DROP INDEX IF EXISTS idx_tasks_status;
+DROP INDEX IF EXISTS idx_tasks_title_id;
+DROP INDEX IF EXISTS idx_tasks_updated_at_id;
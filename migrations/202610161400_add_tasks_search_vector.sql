@@ -0,0 +1,12 @@
+-- +goose Up
+-- Конфигурация 'simple' не зависит от языка: описания задач пишутся и на русском, и на английском.
+ALTER TABLE tasks ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE tasks ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
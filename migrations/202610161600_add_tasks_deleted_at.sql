@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_tasks_deleted_at ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
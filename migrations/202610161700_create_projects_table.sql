@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE projects (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Задачи без проекта остаются в общем списке; проект с задачами удалить нельзя
+ALTER TABLE tasks ADD COLUMN project_id UUID REFERENCES projects (id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_tasks_project_created_at_id ON tasks (project_id, created_at, id) WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_project_created_at_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
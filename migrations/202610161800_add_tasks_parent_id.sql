@@ -0,0 +1,9 @@
+-- +goose Up
+-- При окончательном удалении родителя вложенные задачи становятся задачами верхнего уровня
+ALTER TABLE tasks ADD COLUMN parent_id UUID REFERENCES tasks (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id, created_at, id) WHERE parent_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE task_dependencies (
+    blocker_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+-- Первичный ключ обслуживает обход вниз по графу, этот индекс — обход к блокирующим задачам
+CREATE INDEX idx_task_dependencies_blocked_id ON task_dependencies (blocked_id, blocker_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_dependencies;
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE tasks
+    ADD COLUMN start_at TIMESTAMP,
+    ADD COLUMN due_at TIMESTAMP,
+    ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT tasks_priority_check CHECK (priority BETWEEN 0 AND 4),
+    ADD CONSTRAINT tasks_schedule_check CHECK (start_at IS NULL OR due_at IS NULL OR start_at <= due_at);
+
+-- Выражения совпадают с ключами сортировки due_at и priority в репозитории
+CREATE INDEX idx_tasks_due_at_id ON tasks ((COALESCE(due_at, 'infinity'::timestamp)), id)
+    WHERE deleted_at IS NULL;
+CREATE INDEX idx_tasks_priority_due_at_id ON tasks ((-priority), (COALESCE(due_at, 'infinity'::timestamp)), id)
+    WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_priority_due_at_id;
+DROP INDEX IF EXISTS idx_tasks_due_at_id;
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS tasks_schedule_check,
+    DROP CONSTRAINT IF EXISTS tasks_priority_check,
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS due_at,
+    DROP COLUMN IF EXISTS start_at;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE labels (
+    id UUID PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    color VARCHAR(7) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Удаление метки или окончательное удаление задачи снимает метку с задачи
+CREATE TABLE task_labels (
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+);
+
+-- Отбор задач по метке
+CREATE INDEX idx_task_labels_label_id ON task_labels (label_id, task_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_labels;
+DROP TABLE IF EXISTS labels;
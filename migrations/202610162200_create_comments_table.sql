@@ -0,0 +1,17 @@
+-- +goose Up
+-- Комментарии сохраняются, пока задача в корзине, и удаляются вместе с ней при очистке корзины
+CREATE TABLE comments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES comments (id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    edited_at TIMESTAMP
+);
+
+-- Страницы комментариев верхнего уровня и ответы на них
+CREATE INDEX idx_comments_task_created_at_id ON comments (task_id, created_at, id) WHERE parent_id IS NULL;
+CREATE INDEX idx_comments_parent_created_at_id ON comments (parent_id, created_at, id) WHERE parent_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS comments;
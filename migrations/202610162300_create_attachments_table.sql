@@ -0,0 +1,40 @@
+-- +goose Up
+-- Метаданные вложений; содержимое файлов хранится во внешнем BlobStore
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    checksum TEXT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_attachments_task_created_at ON attachments (task_id, created_at, id);
+
+-- Ключи файлов, метаданные которых удалены, в том числе каскадно при очистке корзины.
+-- Сами файлы удаляет фоновая очистка, поэтому сбой хранилища не теряет ссылки на них.
+CREATE TABLE attachment_blob_deletions (
+    storage_key TEXT PRIMARY KEY,
+    deleted_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- +goose StatementBegin
+CREATE FUNCTION enqueue_attachment_blob_deletion() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO attachment_blob_deletions (storage_key) VALUES (OLD.storage_key)
+    ON CONFLICT (storage_key) DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER attachments_enqueue_blob_deletion
+    AFTER DELETE ON attachments
+    FOR EACH ROW EXECUTE FUNCTION enqueue_attachment_blob_deletion();
+
+-- +goose Down
+DROP TABLE IF EXISTS attachments;
+DROP FUNCTION IF EXISTS enqueue_attachment_blob_deletion();
+DROP TABLE IF EXISTS attachment_blob_deletions;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Серии повторяющихся задач; у задачи-шаблона не больше одной серии
+CREATE TABLE task_recurrences (
+    id UUID PRIMARY KEY,
+    template_id UUID NOT NULL UNIQUE REFERENCES tasks (id) ON DELETE CASCADE,
+    rrule TEXT NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    paused BOOLEAN NOT NULL DEFAULT false,
+    next_run_at TIMESTAMP,
+    last_run_at TIMESTAMP,
+    -- Реплика, взявшая серию в работу, владеет ею до locked_until
+    locked_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Выборка серий, экземпляры которых пора создавать
+CREATE INDEX idx_task_recurrences_next_run_at ON task_recurrences (next_run_at)
+    WHERE NOT paused AND next_run_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS task_recurrences;
@@ -0,0 +1,77 @@
+-- +goose Up
+-- История изменений задачи: по записи на каждое изменение строки tasks. Записи удаляются
+-- вместе с задачей при очистке корзины.
+CREATE TABLE task_history (
+    task_id UUID NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    version BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    -- changes — измененные поля в виде {"поле": {"old": ..., "new": ...}}
+    changes JSONB NOT NULL,
+    -- snapshot — отслеживаемые поля задачи после изменения; по нему задача возвращается к ревизии
+    snapshot JSONB NOT NULL,
+    actor TEXT,
+    request_id TEXT,
+    reverted_to BIGINT,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (task_id, revision)
+);
+
+-- Триггер пишет историю в той же транзакции, что и изменение задачи. Автора, ID запроса
+-- и ревизию, к которой возвращается задача, репозиторий передает параметрами транзакции
+-- task_history.actor, task_history.request_id и task_history.reverted_to.
+-- Служебные поля не отслеживаются: они меняются при каждом изменении.
+-- +goose StatementBegin
+CREATE FUNCTION record_task_history() RETURNS trigger AS $$
+DECLARE
+    ignored CONSTANT TEXT[] := ARRAY['id', 'created_at', 'updated_at', 'version', 'search_vector'];
+    old_row JSONB := '{}';
+    new_row JSONB := to_jsonb(NEW) - ignored;
+    diff JSONB;
+    entry_action TEXT;
+    reverted BIGINT := NULLIF(current_setting('task_history.reverted_to', true), '')::BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        entry_action := 'create';
+    ELSE
+        old_row := to_jsonb(OLD) - ignored;
+        entry_action := CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restore'
+            WHEN reverted IS NOT NULL THEN 'revert'
+            ELSE 'update'
+        END;
+    END IF;
+
+    SELECT COALESCE(jsonb_object_agg(n.key, jsonb_build_object('old', COALESCE(o.value, 'null'), 'new', n.value)), '{}')
+    INTO diff
+    FROM jsonb_each(new_row) n
+    LEFT JOIN jsonb_each(old_row) o ON o.key = n.key
+    WHERE COALESCE(o.value, 'null') <> n.value;
+
+    -- Обновление без изменений отслеживаемых полей в историю не попадает
+    IF diff = '{}' AND entry_action = 'update' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_history (task_id, revision, version, action, changes, snapshot, actor, request_id, reverted_to, created_at)
+    SELECT NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version, entry_action, diff, new_row,
+        NULLIF(current_setting('task_history.actor', true), ''),
+        NULLIF(current_setting('task_history.request_id', true), ''),
+        CASE WHEN entry_action = 'revert' THEN reverted END,
+        (now() AT TIME ZONE 'UTC')
+    FROM task_history WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER tasks_record_history
+    AFTER INSERT OR UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION record_task_history();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS tasks_record_history ON tasks;
+DROP FUNCTION IF EXISTS record_task_history();
+DROP TABLE IF EXISTS task_history;
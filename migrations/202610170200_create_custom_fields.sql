@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE custom_fields (
+    id UUID PRIMARY KEY,
+    key VARCHAR(63) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE tasks ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+
+-- Отбор по равенству значения поля (custom_fields @> '{"key": value}'). Индексы для
+-- сортировки и сравнений по отдельным полям создаются вместе со схемой поля.
+CREATE INDEX idx_tasks_custom_fields ON tasks USING GIN (custom_fields jsonb_path_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_custom_fields;
+ALTER TABLE tasks DROP COLUMN IF EXISTS custom_fields;
+DROP TABLE IF EXISTS custom_fields;
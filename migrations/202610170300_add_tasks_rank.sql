@@ -0,0 +1,118 @@
+-- +goose Up
+-- rank — положение задачи в колонке доски её статуса. Сравнение в collation "C" совпадает
+-- с побайтовым сравнением рангов в приложении.
+ALTER TABLE tasks ADD COLUMN rank TEXT COLLATE "C" NOT NULL DEFAULT '';
+
+-- Ранг не отслеживается историей: перемещение между колонками видно по статусу,
+-- а перебалансировка переписывает ранги целых колонок.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_task_history() RETURNS trigger AS $$
+DECLARE
+    ignored CONSTANT TEXT[] := ARRAY['id', 'created_at', 'updated_at', 'version', 'search_vector', 'rank'];
+    old_row JSONB := '{}';
+    new_row JSONB := to_jsonb(NEW) - ignored;
+    diff JSONB;
+    entry_action TEXT;
+    reverted BIGINT := NULLIF(current_setting('task_history.reverted_to', true), '')::BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        entry_action := 'create';
+    ELSE
+        old_row := to_jsonb(OLD) - ignored;
+        entry_action := CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restore'
+            WHEN reverted IS NOT NULL THEN 'revert'
+            ELSE 'update'
+        END;
+    END IF;
+
+    SELECT COALESCE(jsonb_object_agg(n.key, jsonb_build_object('old', COALESCE(o.value, 'null'), 'new', n.value)), '{}')
+    INTO diff
+    FROM jsonb_each(new_row) n
+    LEFT JOIN jsonb_each(old_row) o ON o.key = n.key
+    WHERE COALESCE(o.value, 'null') <> n.value;
+
+    -- Обновление без изменений отслеживаемых полей в историю не попадает
+    IF diff = '{}' AND entry_action = 'update' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_history (task_id, revision, version, action, changes, snapshot, actor, request_id, reverted_to, created_at)
+    SELECT NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version, entry_action, diff, new_row,
+        NULLIF(current_setting('task_history.actor', true), ''),
+        NULLIF(current_setting('task_history.request_id', true), ''),
+        CASE WHEN entry_action = 'revert' THEN reverted END,
+        (now() AT TIME ZONE 'UTC')
+    FROM task_history WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Существующие задачи выстраиваются в колонках в порядке создания. Шестнадцатеричные
+-- цифры входят в алфавит рангов и упорядочены так же; суффикс V не дает рангу оканчиваться нулем.
+UPDATE tasks SET rank = ranked.rank
+FROM (
+    SELECT id, lpad(to_hex(row_number() OVER (PARTITION BY status ORDER BY created_at, id)), 8, '0') || 'V' AS rank
+    FROM tasks
+) AS ranked
+WHERE tasks.id = ranked.id;
+
+-- Новые задачи получают ранг от репозитория
+ALTER TABLE tasks ALTER COLUMN rank DROP DEFAULT;
+
+CREATE INDEX idx_tasks_status_rank ON tasks (status, rank, id);
+-- Колонки с рангами длиннее entity.MaxRankLength, которые нужно перебалансировать
+CREATE INDEX idx_tasks_long_rank ON tasks (status) WHERE length(rank) > 24;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_long_rank;
+DROP INDEX IF EXISTS idx_tasks_status_rank;
+ALTER TABLE tasks DROP COLUMN IF EXISTS rank;
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_task_history() RETURNS trigger AS $$
+DECLARE
+    ignored CONSTANT TEXT[] := ARRAY['id', 'created_at', 'updated_at', 'version', 'search_vector'];
+    old_row JSONB := '{}';
+    new_row JSONB := to_jsonb(NEW) - ignored;
+    diff JSONB;
+    entry_action TEXT;
+    reverted BIGINT := NULLIF(current_setting('task_history.reverted_to', true), '')::BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        entry_action := 'create';
+    ELSE
+        old_row := to_jsonb(OLD) - ignored;
+        entry_action := CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restore'
+            WHEN reverted IS NOT NULL THEN 'revert'
+            ELSE 'update'
+        END;
+    END IF;
+
+    SELECT COALESCE(jsonb_object_agg(n.key, jsonb_build_object('old', COALESCE(o.value, 'null'), 'new', n.value)), '{}')
+    INTO diff
+    FROM jsonb_each(new_row) n
+    LEFT JOIN jsonb_each(old_row) o ON o.key = n.key
+    WHERE COALESCE(o.value, 'null') <> n.value;
+
+    -- Обновление без изменений отслеживаемых полей в историю не попадает
+    IF diff = '{}' AND entry_action = 'update' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_history (task_id, revision, version, action, changes, snapshot, actor, request_id, reverted_to, created_at)
+    SELECT NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version, entry_action, diff, new_row,
+        NULLIF(current_setting('task_history.actor', true), ''),
+        NULLIF(current_setting('task_history.request_id', true), ''),
+        CASE WHEN entry_action = 'revert' THEN reverted END,
+        (now() AT TIME ZONE 'UTC')
+    FROM task_history WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- created_by — пользователь, создавший задачу; assignee_id — исполнитель. Пустая строка
+-- означает, что значение не задано: у задач, созданных до включения аутентификации, автора нет.
+ALTER TABLE tasks ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN assignee_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tasks_created_by ON tasks (created_by) WHERE deleted_at IS NULL AND created_by <> '';
+CREATE INDEX idx_tasks_assignee_id ON tasks (assignee_id) WHERE deleted_at IS NULL AND assignee_id <> '';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_assignee_id;
+DROP INDEX IF EXISTS idx_tasks_created_by;
+ALTER TABLE tasks DROP COLUMN IF EXISTS assignee_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_by;
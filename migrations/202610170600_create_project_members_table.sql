@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE project_members (
+    project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('viewer', 'member', 'maintainer', 'owner')),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (project_id, subject)
+);
+
+-- Списки задач отбираются по проектам, в которых у пользователя есть роль
+CREATE INDEX idx_project_members_subject ON project_members (subject, project_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS project_members;
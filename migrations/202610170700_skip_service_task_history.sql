@@ -0,0 +1,102 @@
+-- +goose Up
+-- Параметр task_history.skip отключает запись истории до конца транзакции. Им пользуется
+-- удаление схемы настраиваемого поля: иначе оно записывало бы по событию на каждую задачу
+-- со значением поля.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_task_history() RETURNS trigger AS $$
+DECLARE
+    ignored CONSTANT TEXT[] := ARRAY['id', 'created_at', 'updated_at', 'version', 'search_vector', 'rank'];
+    old_row JSONB := '{}';
+    new_row JSONB := to_jsonb(NEW) - ignored;
+    diff JSONB;
+    entry_action TEXT;
+    reverted BIGINT := NULLIF(current_setting('task_history.reverted_to', true), '')::BIGINT;
+BEGIN
+    -- Изменения, которые делает сервис, а не пользователь (например, удаление значений
+    -- при удалении схемы настраиваемого поля), в историю не попадают
+    IF current_setting('task_history.skip', true) = 'on' THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        entry_action := 'create';
+    ELSE
+        old_row := to_jsonb(OLD) - ignored;
+        entry_action := CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restore'
+            WHEN reverted IS NOT NULL THEN 'revert'
+            ELSE 'update'
+        END;
+    END IF;
+
+    SELECT COALESCE(jsonb_object_agg(n.key, jsonb_build_object('old', COALESCE(o.value, 'null'), 'new', n.value)), '{}')
+    INTO diff
+    FROM jsonb_each(new_row) n
+    LEFT JOIN jsonb_each(old_row) o ON o.key = n.key
+    WHERE COALESCE(o.value, 'null') <> n.value;
+
+    -- Обновление без изменений отслеживаемых полей в историю не попадает
+    IF diff = '{}' AND entry_action = 'update' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_history (task_id, revision, version, action, changes, snapshot, actor, request_id, reverted_to, created_at)
+    SELECT NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version, entry_action, diff, new_row,
+        NULLIF(current_setting('task_history.actor', true), ''),
+        NULLIF(current_setting('task_history.request_id', true), ''),
+        CASE WHEN entry_action = 'revert' THEN reverted END,
+        (now() AT TIME ZONE 'UTC')
+    FROM task_history WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_task_history() RETURNS trigger AS $$
+DECLARE
+    ignored CONSTANT TEXT[] := ARRAY['id', 'created_at', 'updated_at', 'version', 'search_vector', 'rank'];
+    old_row JSONB := '{}';
+    new_row JSONB := to_jsonb(NEW) - ignored;
+    diff JSONB;
+    entry_action TEXT;
+    reverted BIGINT := NULLIF(current_setting('task_history.reverted_to', true), '')::BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        entry_action := 'create';
+    ELSE
+        old_row := to_jsonb(OLD) - ignored;
+        entry_action := CASE
+            WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'delete'
+            WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restore'
+            WHEN reverted IS NOT NULL THEN 'revert'
+            ELSE 'update'
+        END;
+    END IF;
+
+    SELECT COALESCE(jsonb_object_agg(n.key, jsonb_build_object('old', COALESCE(o.value, 'null'), 'new', n.value)), '{}')
+    INTO diff
+    FROM jsonb_each(new_row) n
+    LEFT JOIN jsonb_each(old_row) o ON o.key = n.key
+    WHERE COALESCE(o.value, 'null') <> n.value;
+
+    -- Обновление без изменений отслеживаемых полей в историю не попадает
+    IF diff = '{}' AND entry_action = 'update' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_history (task_id, revision, version, action, changes, snapshot, actor, request_id, reverted_to, created_at)
+    SELECT NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version, entry_action, diff, new_row,
+        NULLIF(current_setting('task_history.actor', true), ''),
+        NULLIF(current_setting('task_history.request_id', true), ''),
+        CASE WHEN entry_action = 'revert' THEN reverted END,
+        (now() AT TIME ZONE 'UTC')
+    FROM task_history WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
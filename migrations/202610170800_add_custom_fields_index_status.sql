@@ -0,0 +1,19 @@
+-- +goose Up
+-- Индекс значений поля строится в фоне после создания схемы. index_status — состояние
+-- построения: pending — ожидает, ready — построен, failed — построить не удалось.
+-- index_locked_until — срок аренды построения одной репликой.
+ALTER TABLE custom_fields
+    ADD COLUMN index_status VARCHAR(20) NOT NULL DEFAULT 'ready'
+        CHECK (index_status IN ('pending', 'ready', 'failed')),
+    ADD COLUMN index_locked_until TIMESTAMP;
+
+-- Поля, созданные до миграции, уже получили индекс синхронно
+ALTER TABLE custom_fields ALTER COLUMN index_status SET DEFAULT 'pending';
+
+CREATE INDEX idx_custom_fields_pending_index ON custom_fields (created_at) WHERE index_status = 'pending';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_custom_fields_pending_index;
+ALTER TABLE custom_fields
+    DROP COLUMN IF EXISTS index_locked_until,
+    DROP COLUMN IF EXISTS index_status;
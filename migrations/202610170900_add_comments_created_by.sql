@@ -0,0 +1,7 @@
+-- +goose Up
+-- created_by — пользователь, оставивший комментарий. Пустая строка у комментариев,
+-- созданных до записи автора или без аутентификации.
+ALTER TABLE comments ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE comments DROP COLUMN IF EXISTS created_by;
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,31 +23,1846 @@ const (
 )
 
 type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status      string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Description string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Version     int64                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// Пусто у задач вне проектов.
+	ProjectId string `protobuf:"bytes,8,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Пусто у задач верхнего уровня.
+	ParentId string `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// RFC 3339; пусто, если дата не задана.
+	StartAt string `protobuf:"bytes,10,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	DueAt   string `protobuf:"bytes,11,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// От 0 (без приоритета) до 4 (срочно).
+	Priority int32    `protobuf:"varint,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	Labels   []*Label `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Значения пользовательских полей по ключу поля в JSON: "\"high\"", "42", "\"2026-01-31\"".
+	CustomFields map[string]string `protobuf:"bytes,14,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Положение задачи в колонке её статуса: задачи колонки упорядочены по рангу побайтово.
+	Rank string `protobuf:"bytes,15,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Пользователь, создавший задачу; пусто у задач, созданных без аутентификации.
+	CreatedBy string `protobuf:"bytes,16,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// Исполнитель задачи; пусто, если не назначен.
+	AssigneeId    string `protobuf:"bytes,17,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_proto_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Task) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Task) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Task) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Task) GetStartAt() string {
+	if x != nil {
+		return x.StartAt
+	}
+	return ""
+}
+
+func (x *Task) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Task) GetLabels() []*Label {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Task) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *Task) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+func (x *Task) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Task) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+type Label struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// #RRGGBB.
+	Color         string `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Label) Reset() {
+	*x = Label{}
+	mi := &file_proto_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Label) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Label) ProtoMessage() {}
+
+func (x *Label) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Label.ProtoReflect.Descriptor instead.
+func (*Label) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Label) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Label) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Label) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Status      string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ProjectId   string                 `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ParentId    string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// RFC 3339; пустое значение означает, что дата не задана.
+	StartAt  string `protobuf:"bytes,6,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	DueAt    string `protobuf:"bytes,7,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Priority int32  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Значения пользовательских полей в JSON, как в Task.custom_fields.
+	CustomFields  map[string]string `protobuf:"bytes,9,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AssigneeId    string            `protobuf:"bytes,10,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_proto_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetStartAt() string {
+	if x != nil {
+		return x.StartAt
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+type CreateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
+	mi := &file_proto_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskResponse) ProtoMessage() {}
+
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_proto_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: используйте cursor.
+	//
+	// Deprecated: Marked as deprecated in proto/task.proto.
+	Page          int32    `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string   `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Statuses      []string `protobuf:"bytes,4,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	TitleContains string   `protobuf:"bytes,5,opt,name=title_contains,json=titleContains,proto3" json:"title_contains,omitempty"`
+	// RFC 3339; пустое значение не ограничивает выборку.
+	CreatedFrom string `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   string `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom string `protobuf:"bytes,8,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo   string `protobuf:"bytes,9,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	// created_at, updated_at, title, due_at, priority или rank.
+	SortBy   string `protobuf:"bytes,10,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortDesc bool   `protobuf:"varint,11,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	// Ограничивает выборку задачами проекта.
+	ProjectId string `protobuf:"bytes,12,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// RFC 3339; пустое значение не ограничивает выборку.
+	DueFrom string `protobuf:"bytes,13,opt,name=due_from,json=dueFrom,proto3" json:"due_from,omitempty"`
+	DueTo   string `protobuf:"bytes,14,opt,name=due_to,json=dueTo,proto3" json:"due_to,omitempty"`
+	// Только невыполненные задачи с истекшим сроком.
+	Overdue bool `protobuf:"varint,15,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	// Только задачи со сроком сегодня.
+	DueToday bool `protobuf:"varint,16,opt,name=due_today,json=dueToday,proto3" json:"due_today,omitempty"`
+	// Только задачи со сроком в ближайшие N суток; 0 не ограничивает выборку.
+	DueWithinDays int32 `protobuf:"varint,17,opt,name=due_within_days,json=dueWithinDays,proto3" json:"due_within_days,omitempty"`
+	// Имена меток: задача должна иметь хотя бы одну из labels_any и все из labels_all.
+	LabelsAny []string `protobuf:"bytes,18,rep,name=labels_any,json=labelsAny,proto3" json:"labels_any,omitempty"`
+	LabelsAll []string `protobuf:"bytes,19,rep,name=labels_all,json=labelsAll,proto3" json:"labels_all,omitempty"`
+	// Фильтры по значениям пользовательских полей; sort_by также принимает cf.<key>.
+	CustomFields []*CustomFieldFilter `protobuf:"bytes,20,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	// Отбирают задачи исполнителя и автора.
+	AssigneeId    string `protobuf:"bytes,21,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	CreatedBy     string `protobuf:"bytes,22,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_proto_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{6}
+}
+
+// Deprecated: Marked as deprecated in proto/task.proto.
+func (x *ListTasksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetTitleContains() string {
+	if x != nil {
+		return x.TitleContains
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetUpdatedFrom() string {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetUpdatedTo() string {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetSortDesc() bool {
+	if x != nil {
+		return x.SortDesc
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetDueFrom() string {
+	if x != nil {
+		return x.DueFrom
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetDueTo() string {
+	if x != nil {
+		return x.DueTo
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetDueToday() bool {
+	if x != nil {
+		return x.DueToday
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetDueWithinDays() int32 {
+	if x != nil {
+		return x.DueWithinDays
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetLabelsAny() []string {
+	if x != nil {
+		return x.LabelsAny
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetLabelsAll() []string {
+	if x != nil {
+		return x.LabelsAll
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetCustomFields() []*CustomFieldFilter {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type CustomFieldFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// eq, gt, gte, lt или lte; пустое значение означает eq.
+	Op string `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	// Значение в текстовом виде, например high, 42 или 2026-01-31.
+	Value         string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldFilter) Reset() {
+	*x = CustomFieldFilter{}
+	mi := &file_proto_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldFilter) ProtoMessage() {}
+
+func (x *CustomFieldFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldFilter.ProtoReflect.Descriptor instead.
+func (*CustomFieldFilter) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CustomFieldFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CustomFieldFilter) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *CustomFieldFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_proto_task_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListTasksResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type SearchTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Statuses      []string               `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksRequest) Reset() {
+	*x = SearchTasksRequest{}
+	mi := &file_proto_task_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksRequest) ProtoMessage() {}
+
+func (x *SearchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Task                 *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Rank                 float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	TitleHighlight       string                 `protobuf:"bytes,3,opt,name=title_highlight,json=titleHighlight,proto3" json:"title_highlight,omitempty"`
+	DescriptionHighlight string                 `protobuf:"bytes,4,opt,name=description_highlight,json=descriptionHighlight,proto3" json:"description_highlight,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_task_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetTitleHighlight() string {
+	if x != nil {
+		return x.TitleHighlight
+	}
+	return ""
+}
+
+func (x *SearchResult) GetDescriptionHighlight() string {
+	if x != nil {
+		return x.DescriptionHighlight
+	}
+	return ""
+}
+
+type SearchTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksResponse) Reset() {
+	*x = SearchTasksResponse{}
+	mi := &file_proto_task_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksResponse) ProtoMessage() {}
+
+func (x *SearchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchTasksResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type UpdateTaskRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status      string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Если задана, обновляются только перечисленные поля
+	// (title, description, status, project_id, start_at, due_at, priority, custom_fields, assignee_id).
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Ожидаемая версия задачи; 0 отключает проверку.
+	Version int64 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// Пустое значение убирает задачу из проекта.
+	ProjectId string `protobuf:"bytes,7,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Разрешает сменить статус при невыполненных блокирующих задачах.
+	IgnoreBlockers bool `protobuf:"varint,8,opt,name=ignore_blockers,json=ignoreBlockers,proto3" json:"ignore_blockers,omitempty"`
+	// RFC 3339; пустое значение сбрасывает дату.
+	StartAt  string `protobuf:"bytes,9,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	DueAt    string `protobuf:"bytes,10,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Priority int32  `protobuf:"varint,11,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Значения пользовательских полей в JSON; отсутствующие поля сбрасываются.
+	CustomFields  map[string]string `protobuf:"bytes,12,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AssigneeId    string            `protobuf:"bytes,13,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_proto_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetIgnoreBlockers() bool {
+	if x != nil {
+		return x.IgnoreBlockers
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetStartAt() string {
+	if x != nil {
+		return x.StartAt
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+type UpdateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_proto_task_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type DeleteTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Ожидаемая версия задачи; 0 отключает проверку.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_proto_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_proto_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// При atomic все операции применяются в одной транзакции либо не применяется ни одна.
+type BatchCreateTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*CreateTaskRequest   `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Atomic        bool                   `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTasksRequest) Reset() {
+	*x = BatchCreateTasksRequest{}
+	mi := &file_proto_task_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTasksRequest) ProtoMessage() {}
+
+func (x *BatchCreateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchCreateTasksRequest) GetTasks() []*CreateTaskRequest {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *BatchCreateTasksRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+// update_mask в элементах пакета не поддерживается: задачи обновляются целиком.
+type BatchUpdateTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*UpdateTaskRequest   `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Atomic        bool                   `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTasksRequest) Reset() {
+	*x = BatchUpdateTasksRequest{}
+	mi := &file_proto_task_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTasksRequest) ProtoMessage() {}
+
+func (x *BatchUpdateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchUpdateTasksRequest) GetTasks() []*UpdateTaskRequest {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *BatchUpdateTasksRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+type BatchDeleteTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*DeleteTaskRequest   `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Atomic        bool                   `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteTasksRequest) Reset() {
+	*x = BatchDeleteTasksRequest{}
+	mi := &file_proto_task_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteTasksRequest) ProtoMessage() {}
+
+func (x *BatchDeleteTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchDeleteTasksRequest) GetTasks() []*DeleteTaskRequest {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *BatchDeleteTasksRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+type BatchItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Task          *Task                  `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_proto_task_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchItemResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *BatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Committed     bool                   `protobuf:"varint,1,opt,name=committed,proto3" json:"committed,omitempty"`
+	Succeeded     int32                  `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Items         []*BatchItemResult     `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResponse) Reset() {
+	*x = BatchResponse{}
+	mi := &file_proto_task_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResponse) ProtoMessage() {}
+
+func (x *BatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BatchResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *BatchResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BatchResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BatchResponse) GetItems() []*BatchItemResult {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ListTransitionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransitionsRequest) Reset() {
+	*x = ListTransitionsRequest{}
+	mi := &file_proto_task_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransitionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransitionsRequest) ProtoMessage() {}
+
+func (x *ListTransitionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransitionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransitionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTransitionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StatusTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Allowed       bool                   `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	FailedGuards  []string               `protobuf:"bytes,3,rep,name=failed_guards,json=failedGuards,proto3" json:"failed_guards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusTransition) Reset() {
+	*x = StatusTransition{}
+	mi := &file_proto_task_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusTransition) ProtoMessage() {}
+
+func (x *StatusTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusTransition.ProtoReflect.Descriptor instead.
+func (*StatusTransition) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StatusTransition) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusTransition) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *StatusTransition) GetFailedGuards() []string {
+	if x != nil {
+		return x.FailedGuards
+	}
+	return nil
+}
+
+type ListTransitionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transitions   []*StatusTransition    `protobuf:"bytes,1,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransitionsResponse) Reset() {
+	*x = ListTransitionsResponse{}
+	mi := &file_proto_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransitionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransitionsResponse) ProtoMessage() {}
+
+func (x *ListTransitionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransitionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransitionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTransitionsResponse) GetTransitions() []*StatusTransition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+// Переносит задачу вместе с подзадачами; пустой parent_id делает её задачей верхнего уровня.
+type MoveTaskRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Ожидаемая версия задачи; 0 отключает проверку.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTaskRequest) Reset() {
+	*x = MoveTaskRequest{}
+	mi := &file_proto_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTaskRequest) ProtoMessage() {}
+
+func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTaskRequest.ProtoReflect.Descriptor instead.
+func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MoveTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MoveTaskRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *MoveTaskRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type MoveTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task) Reset() {
-	*x = Task{}
-	mi := &file_proto_task_proto_msgTypes[0]
+func (x *MoveTaskResponse) Reset() {
+	*x = MoveTaskResponse{}
+	mi := &file_proto_task_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task) String() string {
+func (x *MoveTaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task) ProtoMessage() {}
+func (*MoveTaskResponse) ProtoMessage() {}
 
-func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[0]
+func (x *MoveTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,69 +1873,95 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task.ProtoReflect.Descriptor instead.
-func (*Task) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use MoveTaskResponse.ProtoReflect.Descriptor instead.
+func (*MoveTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Task) GetId() string {
+func (x *MoveTaskResponse) GetTask() *Task {
 	if x != nil {
-		return x.Id
+		return x.Task
 	}
-	return ""
+	return nil
 }
 
-func (x *Task) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+// Отмечает, что задача blocker_id блокирует задачу blocked_id.
+type AddDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockedId     string                 `protobuf:"bytes,1,opt,name=blocked_id,json=blockedId,proto3" json:"blocked_id,omitempty"`
+	BlockerId     string                 `protobuf:"bytes,2,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task) GetStatus() string {
+func (x *AddDependencyRequest) Reset() {
+	*x = AddDependencyRequest{}
+	mi := &file_proto_task_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyRequest) ProtoMessage() {}
+
+func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[26]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Task) GetCreatedAt() string {
+// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
+func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AddDependencyRequest) GetBlockedId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.BlockedId
 	}
 	return ""
 }
 
-func (x *Task) GetUpdatedAt() string {
+func (x *AddDependencyRequest) GetBlockerId() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.BlockerId
 	}
 	return ""
 }
 
-type CreateTaskRequest struct {
+type TaskDependency struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	BlockerId     string                 `protobuf:"bytes,1,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
+	BlockedId     string                 `protobuf:"bytes,2,opt,name=blocked_id,json=blockedId,proto3" json:"blocked_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTaskRequest) Reset() {
-	*x = CreateTaskRequest{}
-	mi := &file_proto_task_proto_msgTypes[1]
+func (x *TaskDependency) Reset() {
+	*x = TaskDependency{}
+	mi := &file_proto_task_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTaskRequest) String() string {
+func (x *TaskDependency) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTaskRequest) ProtoMessage() {}
+func (*TaskDependency) ProtoMessage() {}
 
-func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[1]
+func (x *TaskDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -130,47 +1972,54 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
-func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use TaskDependency.ProtoReflect.Descriptor instead.
+func (*TaskDependency) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *CreateTaskRequest) GetTitle() string {
+func (x *TaskDependency) GetBlockerId() string {
 	if x != nil {
-		return x.Title
+		return x.BlockerId
 	}
 	return ""
 }
 
-func (x *CreateTaskRequest) GetStatus() string {
+func (x *TaskDependency) GetBlockedId() string {
 	if x != nil {
-		return x.Status
+		return x.BlockedId
 	}
 	return ""
 }
 
-type CreateTaskResponse struct {
+func (x *TaskDependency) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type AddDependencyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Dependency    *TaskDependency        `protobuf:"bytes,1,opt,name=dependency,proto3" json:"dependency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTaskResponse) Reset() {
-	*x = CreateTaskResponse{}
-	mi := &file_proto_task_proto_msgTypes[2]
+func (x *AddDependencyResponse) Reset() {
+	*x = AddDependencyResponse{}
+	mi := &file_proto_task_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTaskResponse) String() string {
+func (x *AddDependencyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTaskResponse) ProtoMessage() {}
+func (*AddDependencyResponse) ProtoMessage() {}
 
-func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[2]
+func (x *AddDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -181,40 +2030,41 @@ func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
-func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use AddDependencyResponse.ProtoReflect.Descriptor instead.
+func (*AddDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *CreateTaskResponse) GetTask() *Task {
+func (x *AddDependencyResponse) GetDependency() *TaskDependency {
 	if x != nil {
-		return x.Task
+		return x.Dependency
 	}
 	return nil
 }
 
-type GetTaskRequest struct {
+type RemoveDependencyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlockedId     string                 `protobuf:"bytes,1,opt,name=blocked_id,json=blockedId,proto3" json:"blocked_id,omitempty"`
+	BlockerId     string                 `protobuf:"bytes,2,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
-	mi := &file_proto_task_proto_msgTypes[3]
+func (x *RemoveDependencyRequest) Reset() {
+	*x = RemoveDependencyRequest{}
+	mi := &file_proto_task_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *RemoveDependencyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*RemoveDependencyRequest) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[3]
+func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -225,40 +2075,46 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *GetTaskRequest) GetId() string {
+func (x *RemoveDependencyRequest) GetBlockedId() string {
 	if x != nil {
-		return x.Id
+		return x.BlockedId
 	}
 	return ""
 }
 
-type GetTaskResponse struct {
+func (x *RemoveDependencyRequest) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+type RemoveDependencyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskResponse) Reset() {
-	*x = GetTaskResponse{}
-	mi := &file_proto_task_proto_msgTypes[4]
+func (x *RemoveDependencyResponse) Reset() {
+	*x = RemoveDependencyResponse{}
+	mi := &file_proto_task_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskResponse) String() string {
+func (x *RemoveDependencyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskResponse) ProtoMessage() {}
+func (*RemoveDependencyResponse) ProtoMessage() {}
 
-func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[4]
+func (x *RemoveDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,41 +2125,35 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *GetTaskResponse) GetTask() *Task {
-	if x != nil {
-		return x.Task
-	}
-	return nil
+// Deprecated: Use RemoveDependencyResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{30}
 }
 
-type ListTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+type GetDependencyGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Максимальное число связей от задачи; 0 означает значение по умолчанию.
+	Depth         int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksRequest) Reset() {
-	*x = ListTasksRequest{}
-	mi := &file_proto_task_proto_msgTypes[5]
+func (x *GetDependencyGraphRequest) Reset() {
+	*x = GetDependencyGraphRequest{}
+	mi := &file_proto_task_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksRequest) String() string {
+func (x *GetDependencyGraphRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksRequest) ProtoMessage() {}
+func (*GetDependencyGraphRequest) ProtoMessage() {}
 
-func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[5]
+func (x *GetDependencyGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -314,47 +2164,48 @@ func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListTasksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetDependencyGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetDependencyGraphRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *ListTasksRequest) GetPage() int32 {
+func (x *GetDependencyGraphRequest) GetId() string {
 	if x != nil {
-		return x.Page
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *ListTasksRequest) GetLimit() int32 {
+func (x *GetDependencyGraphRequest) GetDepth() int32 {
 	if x != nil {
-		return x.Limit
+		return x.Depth
 	}
 	return 0
 }
 
-type ListTasksResponse struct {
+type GetDependencyGraphResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Nodes         []*Task                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*TaskDependency      `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksResponse) Reset() {
-	*x = ListTasksResponse{}
-	mi := &file_proto_task_proto_msgTypes[6]
+func (x *GetDependencyGraphResponse) Reset() {
+	*x = GetDependencyGraphResponse{}
+	mi := &file_proto_task_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksResponse) String() string {
+func (x *GetDependencyGraphResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksResponse) ProtoMessage() {}
+func (*GetDependencyGraphResponse) ProtoMessage() {}
 
-func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[6]
+func (x *GetDependencyGraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,42 +2216,48 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListTasksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetDependencyGraphResponse.ProtoReflect.Descriptor instead.
+func (*GetDependencyGraphResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *ListTasksResponse) GetTasks() []*Task {
+func (x *GetDependencyGraphResponse) GetNodes() []*Task {
 	if x != nil {
-		return x.Tasks
+		return x.Nodes
 	}
 	return nil
 }
 
-type UpdateTaskRequest struct {
+func (x *GetDependencyGraphResponse) GetEdges() []*TaskDependency {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type AttachLabelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	LabelId       string                 `protobuf:"bytes,2,opt,name=label_id,json=labelId,proto3" json:"label_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskRequest) Reset() {
-	*x = UpdateTaskRequest{}
-	mi := &file_proto_task_proto_msgTypes[7]
+func (x *AttachLabelRequest) Reset() {
+	*x = AttachLabelRequest{}
+	mi := &file_proto_task_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskRequest) String() string {
+func (x *AttachLabelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskRequest) ProtoMessage() {}
+func (*AttachLabelRequest) ProtoMessage() {}
 
-func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[7]
+func (x *AttachLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,54 +2268,47 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *UpdateTaskRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use AttachLabelRequest.ProtoReflect.Descriptor instead.
+func (*AttachLabelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *UpdateTaskRequest) GetTitle() string {
+func (x *AttachLabelRequest) GetTaskId() string {
 	if x != nil {
-		return x.Title
+		return x.TaskId
 	}
 	return ""
 }
 
-func (x *UpdateTaskRequest) GetStatus() string {
+func (x *AttachLabelRequest) GetLabelId() string {
 	if x != nil {
-		return x.Status
+		return x.LabelId
 	}
 	return ""
 }
 
-type UpdateTaskResponse struct {
+type AttachLabelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskResponse) Reset() {
-	*x = UpdateTaskResponse{}
-	mi := &file_proto_task_proto_msgTypes[8]
+func (x *AttachLabelResponse) Reset() {
+	*x = AttachLabelResponse{}
+	mi := &file_proto_task_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskResponse) String() string {
+func (x *AttachLabelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskResponse) ProtoMessage() {}
+func (*AttachLabelResponse) ProtoMessage() {}
 
-func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[8]
+func (x *AttachLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,40 +2319,41 @@ func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AttachLabelResponse.ProtoReflect.Descriptor instead.
+func (*AttachLabelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *UpdateTaskResponse) GetTask() *Task {
+func (x *AttachLabelResponse) GetTask() *Task {
 	if x != nil {
 		return x.Task
 	}
 	return nil
 }
 
-type DeleteTaskRequest struct {
+type DetachLabelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	LabelId       string                 `protobuf:"bytes,2,opt,name=label_id,json=labelId,proto3" json:"label_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskRequest) Reset() {
-	*x = DeleteTaskRequest{}
-	mi := &file_proto_task_proto_msgTypes[9]
+func (x *DetachLabelRequest) Reset() {
+	*x = DetachLabelRequest{}
+	mi := &file_proto_task_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskRequest) String() string {
+func (x *DetachLabelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskRequest) ProtoMessage() {}
+func (*DetachLabelRequest) ProtoMessage() {}
 
-func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[9]
+func (x *DetachLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,40 +2364,47 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use DetachLabelRequest.ProtoReflect.Descriptor instead.
+func (*DetachLabelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *DeleteTaskRequest) GetId() string {
+func (x *DetachLabelRequest) GetTaskId() string {
 	if x != nil {
-		return x.Id
+		return x.TaskId
 	}
 	return ""
 }
 
-type DeleteTaskResponse struct {
+func (x *DetachLabelRequest) GetLabelId() string {
+	if x != nil {
+		return x.LabelId
+	}
+	return ""
+}
+
+type DetachLabelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTaskResponse) Reset() {
-	*x = DeleteTaskResponse{}
-	mi := &file_proto_task_proto_msgTypes[10]
+func (x *DetachLabelResponse) Reset() {
+	*x = DetachLabelResponse{}
+	mi := &file_proto_task_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTaskResponse) String() string {
+func (x *DetachLabelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTaskResponse) ProtoMessage() {}
+func (*DetachLabelResponse) ProtoMessage() {}
 
-func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_proto_msgTypes[10]
+func (x *DetachLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,23 +2415,23 @@ func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DetachLabelResponse.ProtoReflect.Descriptor instead.
+func (*DetachLabelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *DeleteTaskResponse) GetSuccess() bool {
+func (x *DetachLabelResponse) GetTask() *Task {
 	if x != nil {
-		return x.Success
+		return x.Task
 	}
-	return false
+	return nil
 }
 
 var File_proto_task_proto protoreflect.FileDescriptor
 
 const file_proto_task_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/task.proto\x12\x04task\"\x82\x01\n" +
+	"\x10proto/task.proto\x12\x04task\x1a google/protobuf/field_mask.proto\"\xc5\x04\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
@@ -581,10 +2439,47 @@ const file_proto_task_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"A\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\b \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\t \x01(\tR\bparentId\x12\x19\n" +
+	"\bstart_at\x18\n" +
+	" \x01(\tR\astartAt\x12\x15\n" +
+	"\x06due_at\x18\v \x01(\tR\x05dueAt\x12\x1a\n" +
+	"\bpriority\x18\f \x01(\x05R\bpriority\x12#\n" +
+	"\x06labels\x18\r \x03(\v2\v.task.LabelR\x06labels\x12A\n" +
+	"\rcustom_fields\x18\x0e \x03(\v2\x1c.task.Task.CustomFieldsEntryR\fcustomFields\x12\x12\n" +
+	"\x04rank\x18\x0f \x01(\tR\x04rank\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x10 \x01(\tR\tcreatedBy\x12\x1f\n" +
+	"\vassignee_id\x18\x11 \x01(\tR\n" +
+	"assigneeId\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
+	"\x05Label\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\"\x9f\x03\n" +
 	"\x11CreateTaskRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"4\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\x12\x19\n" +
+	"\bstart_at\x18\x06 \x01(\tR\astartAt\x12\x15\n" +
+	"\x06due_at\x18\a \x01(\tR\x05dueAt\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\x12N\n" +
+	"\rcustom_fields\x18\t \x03(\v2).task.CreateTaskRequest.CustomFieldsEntryR\fcustomFields\x12\x1f\n" +
+	"\vassignee_id\x18\n" +
+	" \x01(\tR\n" +
+	"assigneeId\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"4\n" +
 	"\x12CreateTaskResponse\x12\x1e\n" +
 	"\x04task\x18\x01 \x01(\v2\n" +
 	".task.TaskR\x04task\" \n" +
@@ -592,33 +2487,188 @@ const file_proto_task_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
 	"\x0fGetTaskResponse\x12\x1e\n" +
 	"\x04task\x18\x01 \x01(\v2\n" +
-	".task.TaskR\x04task\"<\n" +
-	"\x10ListTasksRequest\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"5\n" +
+	".task.TaskR\x04task\"\xc1\x05\n" +
+	"\x10ListTasksRequest\x12\x16\n" +
+	"\x04page\x18\x01 \x01(\x05B\x02\x18\x01R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1a\n" +
+	"\bstatuses\x18\x04 \x03(\tR\bstatuses\x12%\n" +
+	"\x0etitle_contains\x18\x05 \x01(\tR\rtitleContains\x12!\n" +
+	"\fcreated_from\x18\x06 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\a \x01(\tR\tcreatedTo\x12!\n" +
+	"\fupdated_from\x18\b \x01(\tR\vupdatedFrom\x12\x1d\n" +
+	"\n" +
+	"updated_to\x18\t \x01(\tR\tupdatedTo\x12\x17\n" +
+	"\asort_by\x18\n" +
+	" \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\v \x01(\bR\bsortDesc\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\f \x01(\tR\tprojectId\x12\x19\n" +
+	"\bdue_from\x18\r \x01(\tR\adueFrom\x12\x15\n" +
+	"\x06due_to\x18\x0e \x01(\tR\x05dueTo\x12\x18\n" +
+	"\aoverdue\x18\x0f \x01(\bR\aoverdue\x12\x1b\n" +
+	"\tdue_today\x18\x10 \x01(\bR\bdueToday\x12&\n" +
+	"\x0fdue_within_days\x18\x11 \x01(\x05R\rdueWithinDays\x12\x1d\n" +
+	"\n" +
+	"labels_any\x18\x12 \x03(\tR\tlabelsAny\x12\x1d\n" +
+	"\n" +
+	"labels_all\x18\x13 \x03(\tR\tlabelsAll\x12<\n" +
+	"\rcustom_fields\x18\x14 \x03(\v2\x17.task.CustomFieldFilterR\fcustomFields\x12\x1f\n" +
+	"\vassignee_id\x18\x15 \x01(\tR\n" +
+	"assigneeId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x16 \x01(\tR\tcreatedBy\"K\n" +
+	"\x11CustomFieldFilter\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"q\n" +
 	"\x11ListTasksResponse\x12 \n" +
 	"\x05tasks\x18\x01 \x03(\v2\n" +
-	".task.TaskR\x05tasks\"Q\n" +
+	".task.TaskR\x05tasks\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"\x93\x01\n" +
+	"\x12SearchTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bstatuses\x18\x02 \x03(\tR\bstatuses\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\tR\tprojectId\"\xa0\x01\n" +
+	"\fSearchResult\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12'\n" +
+	"\x0ftitle_highlight\x18\x03 \x01(\tR\x0etitleHighlight\x123\n" +
+	"\x15description_highlight\x18\x04 \x01(\tR\x14descriptionHighlight\"C\n" +
+	"\x13SearchTasksResponse\x12,\n" +
+	"\aresults\x18\x01 \x03(\v2\x12.task.SearchResultR\aresults\"\x92\x04\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\"4\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\a \x01(\tR\tprojectId\x12'\n" +
+	"\x0fignore_blockers\x18\b \x01(\bR\x0eignoreBlockers\x12\x19\n" +
+	"\bstart_at\x18\t \x01(\tR\astartAt\x12\x15\n" +
+	"\x06due_at\x18\n" +
+	" \x01(\tR\x05dueAt\x12\x1a\n" +
+	"\bpriority\x18\v \x01(\x05R\bpriority\x12N\n" +
+	"\rcustom_fields\x18\f \x03(\v2).task.UpdateTaskRequest.CustomFieldsEntryR\fcustomFields\x12\x1f\n" +
+	"\vassignee_id\x18\r \x01(\tR\n" +
+	"assigneeId\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"4\n" +
 	"\x12UpdateTaskResponse\x12\x1e\n" +
 	"\x04task\x18\x01 \x01(\v2\n" +
-	".task.TaskR\x04task\"#\n" +
+	".task.TaskR\x04task\"=\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\".\n" +
 	"\x12DeleteTaskResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xd0\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"`\n" +
+	"\x17BatchCreateTasksRequest\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.task.CreateTaskRequestR\x05tasks\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"`\n" +
+	"\x17BatchUpdateTasksRequest\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.task.UpdateTaskRequestR\x05tasks\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"`\n" +
+	"\x17BatchDeleteTasksRequest\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.task.DeleteTaskRequestR\x05tasks\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"m\n" +
+	"\x0fBatchItemResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1e\n" +
+	"\x04task\x18\x03 \x01(\v2\n" +
+	".task.TaskR\x04task\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x90\x01\n" +
+	"\rBatchResponse\x12\x1c\n" +
+	"\tcommitted\x18\x01 \x01(\bR\tcommitted\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12+\n" +
+	"\x05items\x18\x04 \x03(\v2\x15.task.BatchItemResultR\x05items\"(\n" +
+	"\x16ListTransitionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"i\n" +
+	"\x10StatusTransition\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\aallowed\x18\x02 \x01(\bR\aallowed\x12#\n" +
+	"\rfailed_guards\x18\x03 \x03(\tR\ffailedGuards\"S\n" +
+	"\x17ListTransitionsResponse\x128\n" +
+	"\vtransitions\x18\x01 \x03(\v2\x16.task.StatusTransitionR\vtransitions\"X\n" +
+	"\x0fMoveTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"2\n" +
+	"\x10MoveTaskResponse\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task\"T\n" +
+	"\x14AddDependencyRequest\x12\x1d\n" +
+	"\n" +
+	"blocked_id\x18\x01 \x01(\tR\tblockedId\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x02 \x01(\tR\tblockerId\"m\n" +
+	"\x0eTaskDependency\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x01 \x01(\tR\tblockerId\x12\x1d\n" +
+	"\n" +
+	"blocked_id\x18\x02 \x01(\tR\tblockedId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"M\n" +
+	"\x15AddDependencyResponse\x124\n" +
+	"\n" +
+	"dependency\x18\x01 \x01(\v2\x14.task.TaskDependencyR\n" +
+	"dependency\"W\n" +
+	"\x17RemoveDependencyRequest\x12\x1d\n" +
+	"\n" +
+	"blocked_id\x18\x01 \x01(\tR\tblockedId\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x02 \x01(\tR\tblockerId\"\x1a\n" +
+	"\x18RemoveDependencyResponse\"A\n" +
+	"\x19GetDependencyGraphRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"j\n" +
+	"\x1aGetDependencyGraphResponse\x12 \n" +
+	"\x05nodes\x18\x01 \x03(\v2\n" +
+	".task.TaskR\x05nodes\x12*\n" +
+	"\x05edges\x18\x02 \x03(\v2\x14.task.TaskDependencyR\x05edges\"H\n" +
+	"\x12AttachLabelRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x19\n" +
+	"\blabel_id\x18\x02 \x01(\tR\alabelId\"5\n" +
+	"\x13AttachLabelResponse\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task\"H\n" +
+	"\x12DetachLabelRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x19\n" +
+	"\blabel_id\x18\x02 \x01(\tR\alabelId\"5\n" +
+	"\x13DetachLabelResponse\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task2\x8b\t\n" +
 	"\vTaskService\x12A\n" +
 	"\n" +
 	"CreateTask\x12\x17.task.CreateTaskRequest\x1a\x18.task.CreateTaskResponse\"\x00\x128\n" +
 	"\aGetTask\x12\x14.task.GetTaskRequest\x1a\x15.task.GetTaskResponse\"\x00\x12>\n" +
-	"\tListTasks\x12\x16.task.ListTasksRequest\x1a\x17.task.ListTasksResponse\"\x00\x12A\n" +
+	"\tListTasks\x12\x16.task.ListTasksRequest\x1a\x17.task.ListTasksResponse\"\x00\x12D\n" +
+	"\vSearchTasks\x12\x18.task.SearchTasksRequest\x1a\x19.task.SearchTasksResponse\"\x00\x12A\n" +
 	"\n" +
 	"UpdateTask\x12\x17.task.UpdateTaskRequest\x1a\x18.task.UpdateTaskResponse\"\x00\x12A\n" +
 	"\n" +
-	"DeleteTask\x12\x17.task.DeleteTaskRequest\x1a\x18.task.DeleteTaskResponse\"\x00B\tZ\a./protob\x06proto3"
+	"DeleteTask\x12\x17.task.DeleteTaskRequest\x1a\x18.task.DeleteTaskResponse\"\x00\x12H\n" +
+	"\x10BatchCreateTasks\x12\x1d.task.BatchCreateTasksRequest\x1a\x13.task.BatchResponse\"\x00\x12H\n" +
+	"\x10BatchUpdateTasks\x12\x1d.task.BatchUpdateTasksRequest\x1a\x13.task.BatchResponse\"\x00\x12H\n" +
+	"\x10BatchDeleteTasks\x12\x1d.task.BatchDeleteTasksRequest\x1a\x13.task.BatchResponse\"\x00\x12P\n" +
+	"\x0fListTransitions\x12\x1c.task.ListTransitionsRequest\x1a\x1d.task.ListTransitionsResponse\"\x00\x12;\n" +
+	"\bMoveTask\x12\x15.task.MoveTaskRequest\x1a\x16.task.MoveTaskResponse\"\x00\x12J\n" +
+	"\rAddDependency\x12\x1a.task.AddDependencyRequest\x1a\x1b.task.AddDependencyResponse\"\x00\x12S\n" +
+	"\x10RemoveDependency\x12\x1d.task.RemoveDependencyRequest\x1a\x1e.task.RemoveDependencyResponse\"\x00\x12Y\n" +
+	"\x12GetDependencyGraph\x12\x1f.task.GetDependencyGraphRequest\x1a .task.GetDependencyGraphResponse\"\x00\x12D\n" +
+	"\vAttachLabel\x12\x18.task.AttachLabelRequest\x1a\x19.task.AttachLabelResponse\"\x00\x12D\n" +
+	"\vDetachLabel\x12\x18.task.DetachLabelRequest\x1a\x19.task.DetachLabelResponse\"\x00B\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_task_proto_rawDescOnce sync.Once
@@ -632,40 +2682,112 @@ func file_proto_task_proto_rawDescGZIP() []byte {
 	return file_proto_task_proto_rawDescData
 }
 
-var file_proto_task_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_task_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_proto_task_proto_goTypes = []any{
-	(*Task)(nil),               // 0: task.Task
-	(*CreateTaskRequest)(nil),  // 1: task.CreateTaskRequest
-	(*CreateTaskResponse)(nil), // 2: task.CreateTaskResponse
-	(*GetTaskRequest)(nil),     // 3: task.GetTaskRequest
-	(*GetTaskResponse)(nil),    // 4: task.GetTaskResponse
-	(*ListTasksRequest)(nil),   // 5: task.ListTasksRequest
-	(*ListTasksResponse)(nil),  // 6: task.ListTasksResponse
-	(*UpdateTaskRequest)(nil),  // 7: task.UpdateTaskRequest
-	(*UpdateTaskResponse)(nil), // 8: task.UpdateTaskResponse
-	(*DeleteTaskRequest)(nil),  // 9: task.DeleteTaskRequest
-	(*DeleteTaskResponse)(nil), // 10: task.DeleteTaskResponse
+	(*Task)(nil),                       // 0: task.Task
+	(*Label)(nil),                      // 1: task.Label
+	(*CreateTaskRequest)(nil),          // 2: task.CreateTaskRequest
+	(*CreateTaskResponse)(nil),         // 3: task.CreateTaskResponse
+	(*GetTaskRequest)(nil),             // 4: task.GetTaskRequest
+	(*GetTaskResponse)(nil),            // 5: task.GetTaskResponse
+	(*ListTasksRequest)(nil),           // 6: task.ListTasksRequest
+	(*CustomFieldFilter)(nil),          // 7: task.CustomFieldFilter
+	(*ListTasksResponse)(nil),          // 8: task.ListTasksResponse
+	(*SearchTasksRequest)(nil),         // 9: task.SearchTasksRequest
+	(*SearchResult)(nil),               // 10: task.SearchResult
+	(*SearchTasksResponse)(nil),        // 11: task.SearchTasksResponse
+	(*UpdateTaskRequest)(nil),          // 12: task.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),         // 13: task.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),          // 14: task.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),         // 15: task.DeleteTaskResponse
+	(*BatchCreateTasksRequest)(nil),    // 16: task.BatchCreateTasksRequest
+	(*BatchUpdateTasksRequest)(nil),    // 17: task.BatchUpdateTasksRequest
+	(*BatchDeleteTasksRequest)(nil),    // 18: task.BatchDeleteTasksRequest
+	(*BatchItemResult)(nil),            // 19: task.BatchItemResult
+	(*BatchResponse)(nil),              // 20: task.BatchResponse
+	(*ListTransitionsRequest)(nil),     // 21: task.ListTransitionsRequest
+	(*StatusTransition)(nil),           // 22: task.StatusTransition
+	(*ListTransitionsResponse)(nil),    // 23: task.ListTransitionsResponse
+	(*MoveTaskRequest)(nil),            // 24: task.MoveTaskRequest
+	(*MoveTaskResponse)(nil),           // 25: task.MoveTaskResponse
+	(*AddDependencyRequest)(nil),       // 26: task.AddDependencyRequest
+	(*TaskDependency)(nil),             // 27: task.TaskDependency
+	(*AddDependencyResponse)(nil),      // 28: task.AddDependencyResponse
+	(*RemoveDependencyRequest)(nil),    // 29: task.RemoveDependencyRequest
+	(*RemoveDependencyResponse)(nil),   // 30: task.RemoveDependencyResponse
+	(*GetDependencyGraphRequest)(nil),  // 31: task.GetDependencyGraphRequest
+	(*GetDependencyGraphResponse)(nil), // 32: task.GetDependencyGraphResponse
+	(*AttachLabelRequest)(nil),         // 33: task.AttachLabelRequest
+	(*AttachLabelResponse)(nil),        // 34: task.AttachLabelResponse
+	(*DetachLabelRequest)(nil),         // 35: task.DetachLabelRequest
+	(*DetachLabelResponse)(nil),        // 36: task.DetachLabelResponse
+	nil,                                // 37: task.Task.CustomFieldsEntry
+	nil,                                // 38: task.CreateTaskRequest.CustomFieldsEntry
+	nil,                                // 39: task.UpdateTaskRequest.CustomFieldsEntry
+	(*fieldmaskpb.FieldMask)(nil),      // 40: google.protobuf.FieldMask
 }
 var file_proto_task_proto_depIdxs = []int32{
-	0,  // 0: task.CreateTaskResponse.task:type_name -> task.Task
-	0,  // 1: task.GetTaskResponse.task:type_name -> task.Task
-	0,  // 2: task.ListTasksResponse.tasks:type_name -> task.Task
-	0,  // 3: task.UpdateTaskResponse.task:type_name -> task.Task
-	1,  // 4: task.TaskService.CreateTask:input_type -> task.CreateTaskRequest
-	3,  // 5: task.TaskService.GetTask:input_type -> task.GetTaskRequest
-	5,  // 6: task.TaskService.ListTasks:input_type -> task.ListTasksRequest
-	7,  // 7: task.TaskService.UpdateTask:input_type -> task.UpdateTaskRequest
-	9,  // 8: task.TaskService.DeleteTask:input_type -> task.DeleteTaskRequest
-	2,  // 9: task.TaskService.CreateTask:output_type -> task.CreateTaskResponse
-	4,  // 10: task.TaskService.GetTask:output_type -> task.GetTaskResponse
-	6,  // 11: task.TaskService.ListTasks:output_type -> task.ListTasksResponse
-	8,  // 12: task.TaskService.UpdateTask:output_type -> task.UpdateTaskResponse
-	10, // 13: task.TaskService.DeleteTask:output_type -> task.DeleteTaskResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	1,  // 0: task.Task.labels:type_name -> task.Label
+	37, // 1: task.Task.custom_fields:type_name -> task.Task.CustomFieldsEntry
+	38, // 2: task.CreateTaskRequest.custom_fields:type_name -> task.CreateTaskRequest.CustomFieldsEntry
+	0,  // 3: task.CreateTaskResponse.task:type_name -> task.Task
+	0,  // 4: task.GetTaskResponse.task:type_name -> task.Task
+	7,  // 5: task.ListTasksRequest.custom_fields:type_name -> task.CustomFieldFilter
+	0,  // 6: task.ListTasksResponse.tasks:type_name -> task.Task
+	0,  // 7: task.SearchResult.task:type_name -> task.Task
+	10, // 8: task.SearchTasksResponse.results:type_name -> task.SearchResult
+	40, // 9: task.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	39, // 10: task.UpdateTaskRequest.custom_fields:type_name -> task.UpdateTaskRequest.CustomFieldsEntry
+	0,  // 11: task.UpdateTaskResponse.task:type_name -> task.Task
+	2,  // 12: task.BatchCreateTasksRequest.tasks:type_name -> task.CreateTaskRequest
+	12, // 13: task.BatchUpdateTasksRequest.tasks:type_name -> task.UpdateTaskRequest
+	14, // 14: task.BatchDeleteTasksRequest.tasks:type_name -> task.DeleteTaskRequest
+	0,  // 15: task.BatchItemResult.task:type_name -> task.Task
+	19, // 16: task.BatchResponse.items:type_name -> task.BatchItemResult
+	22, // 17: task.ListTransitionsResponse.transitions:type_name -> task.StatusTransition
+	0,  // 18: task.MoveTaskResponse.task:type_name -> task.Task
+	27, // 19: task.AddDependencyResponse.dependency:type_name -> task.TaskDependency
+	0,  // 20: task.GetDependencyGraphResponse.nodes:type_name -> task.Task
+	27, // 21: task.GetDependencyGraphResponse.edges:type_name -> task.TaskDependency
+	0,  // 22: task.AttachLabelResponse.task:type_name -> task.Task
+	0,  // 23: task.DetachLabelResponse.task:type_name -> task.Task
+	2,  // 24: task.TaskService.CreateTask:input_type -> task.CreateTaskRequest
+	4,  // 25: task.TaskService.GetTask:input_type -> task.GetTaskRequest
+	6,  // 26: task.TaskService.ListTasks:input_type -> task.ListTasksRequest
+	9,  // 27: task.TaskService.SearchTasks:input_type -> task.SearchTasksRequest
+	12, // 28: task.TaskService.UpdateTask:input_type -> task.UpdateTaskRequest
+	14, // 29: task.TaskService.DeleteTask:input_type -> task.DeleteTaskRequest
+	16, // 30: task.TaskService.BatchCreateTasks:input_type -> task.BatchCreateTasksRequest
+	17, // 31: task.TaskService.BatchUpdateTasks:input_type -> task.BatchUpdateTasksRequest
+	18, // 32: task.TaskService.BatchDeleteTasks:input_type -> task.BatchDeleteTasksRequest
+	21, // 33: task.TaskService.ListTransitions:input_type -> task.ListTransitionsRequest
+	24, // 34: task.TaskService.MoveTask:input_type -> task.MoveTaskRequest
+	26, // 35: task.TaskService.AddDependency:input_type -> task.AddDependencyRequest
+	29, // 36: task.TaskService.RemoveDependency:input_type -> task.RemoveDependencyRequest
+	31, // 37: task.TaskService.GetDependencyGraph:input_type -> task.GetDependencyGraphRequest
+	33, // 38: task.TaskService.AttachLabel:input_type -> task.AttachLabelRequest
+	35, // 39: task.TaskService.DetachLabel:input_type -> task.DetachLabelRequest
+	3,  // 40: task.TaskService.CreateTask:output_type -> task.CreateTaskResponse
+	5,  // 41: task.TaskService.GetTask:output_type -> task.GetTaskResponse
+	8,  // 42: task.TaskService.ListTasks:output_type -> task.ListTasksResponse
+	11, // 43: task.TaskService.SearchTasks:output_type -> task.SearchTasksResponse
+	13, // 44: task.TaskService.UpdateTask:output_type -> task.UpdateTaskResponse
+	15, // 45: task.TaskService.DeleteTask:output_type -> task.DeleteTaskResponse
+	20, // 46: task.TaskService.BatchCreateTasks:output_type -> task.BatchResponse
+	20, // 47: task.TaskService.BatchUpdateTasks:output_type -> task.BatchResponse
+	20, // 48: task.TaskService.BatchDeleteTasks:output_type -> task.BatchResponse
+	23, // 49: task.TaskService.ListTransitions:output_type -> task.ListTransitionsResponse
+	25, // 50: task.TaskService.MoveTask:output_type -> task.MoveTaskResponse
+	28, // 51: task.TaskService.AddDependency:output_type -> task.AddDependencyResponse
+	30, // 52: task.TaskService.RemoveDependency:output_type -> task.RemoveDependencyResponse
+	32, // 53: task.TaskService.GetDependencyGraph:output_type -> task.GetDependencyGraphResponse
+	34, // 54: task.TaskService.AttachLabel:output_type -> task.AttachLabelResponse
+	36, // 55: task.TaskService.DetachLabel:output_type -> task.DetachLabelResponse
+	40, // [40:56] is the sub-list for method output_type
+	24, // [24:40] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_proto_task_proto_init() }
@@ -679,7 +2801,7 @@ func file_proto_task_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_task_proto_rawDesc), len(file_proto_task_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},